@@ -0,0 +1,259 @@
+package mongodb
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Operators making a field a range predicate in the equality, sort, range rule
+var rangeOperators = map[string]bool{
+	"$gt": true, "$gte": true, "$lt": true, "$lte": true, "$ne": true, "$nin": true, "$regex": true, "$exists": true,
+}
+
+// IndexSuggestion is a candidate compound index for a query shape
+type IndexSuggestion struct {
+	Collection  string
+	Shape       string      // Normalised query shape, e.g. "eq(status,tenant) sort(createdAt:-1) range(amount)"
+	Index       *IndexModel // Candidate index following the equality, sort, range rule
+	Queries     int         // Number of observed queries having the shape
+	TotalMillis int64       // Total observed duration of the queries
+}
+
+// queryShape ...
+type queryShape struct {
+	collection  string
+	equality    []string
+	sort        bson.D
+	rng         []string
+	queries     int
+	totalMillis int64
+}
+
+// IndexAdvisor collects slow query shapes and suggests compound indexes
+// Feed it from the profiler with ObserveProfile or from any slow query hook with Observe
+type IndexAdvisor struct {
+	mu     sync.Mutex
+	shapes map[string]*queryShape
+}
+
+// NewIndexAdvisor ...
+func NewIndexAdvisor() *IndexAdvisor {
+	return &IndexAdvisor{shapes: map[string]*queryShape{}}
+}
+
+// Observe records a query of the collection with its filter, sort and duration
+func (a *IndexAdvisor) Observe(collection string, filter interface{}, sortBy interface{}, duration time.Duration) (err error) {
+	shape := &queryShape{collection: collection}
+
+	// Classifies filter fields
+	if filter != nil {
+		doc, err := toDoc(filter)
+		if err != nil {
+			return err
+		}
+		classifyFilter(doc, shape)
+	}
+	shape.equality = uniqueSorted(shape.equality)
+	shape.rng = uniqueSorted(shape.rng)
+
+	// Reads sort
+	if sortBy != nil {
+		shape.sort, err = toDoc(sortBy)
+		if err != nil {
+			return err
+		}
+	}
+
+	// Records
+	key := collection + " " + shape.String()
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	current, ok := a.shapes[key]
+	if !ok {
+		current = shape
+		a.shapes[key] = current
+	}
+	current.queries++
+	current.totalMillis += duration.Milliseconds()
+
+	// Returns
+	return nil
+}
+
+// ObserveProfile records the find queries of the database profiler (system.profile) slower than minMillis since the given time
+// Profiling must be enabled on the database, e.g. db.setProfilingLevel(1, {slowms: 100})
+func (a *IndexAdvisor) ObserveProfile(ctx context.Context, c *Client, since time.Time, minMillis int) (err error) {
+	// Hits DB
+	query := bson.D{
+		{Key: "op", Value: "query"},
+		{Key: "ts", Value: bson.D{{Key: "$gte", Value: since}}},
+		{Key: "millis", Value: bson.D{{Key: "$gte", Value: minMillis}}},
+	}
+	cursor, err := c.collection(ctx, "system.profile").Find(ctx, query)
+	if err != nil {
+		return err
+	}
+
+	// Close connection at the last
+	defer cursor.Close(ctx)
+
+	// Observes entries
+	for cursor.Next(ctx) {
+		var entry struct {
+			Millis  int64 `bson:"millis"`
+			Command struct {
+				Find   string      `bson:"find"`
+				Filter interface{} `bson:"filter"`
+				Sort   interface{} `bson:"sort"`
+			} `bson:"command"`
+		}
+		if cursor.Decode(&entry) != nil || entry.Command.Find == "" {
+			continue
+		}
+		err = a.Observe(entry.Command.Find, entry.Command.Filter, entry.Command.Sort, time.Duration(entry.Millis)*time.Millisecond)
+		if err != nil {
+			return err
+		}
+	}
+
+	// Returns
+	return cursor.Err()
+}
+
+// Suggestions returns a candidate index per observed query shape, the costliest shapes first
+// Shapes only filtering on _id are skipped as the _id index already serves them
+func (a *IndexAdvisor) Suggestions() (res []IndexSuggestion) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	for _, shape := range a.shapes {
+		keys := shape.keys()
+		if len(keys) == 0 || (len(keys) == 1 && keys[0].Key == "_id") {
+			continue
+		}
+		res = append(res, IndexSuggestion{
+			Collection:  shape.collection,
+			Shape:       shape.String(),
+			Index:       &IndexModel{Keys: keys},
+			Queries:     shape.queries,
+			TotalMillis: shape.totalMillis,
+		})
+	}
+	sort.Slice(res, func(i, j int) bool {
+		if res[i].TotalMillis != res[j].TotalMillis {
+			return res[i].TotalMillis > res[j].TotalMillis
+		}
+		return res[i].Collection+res[i].Shape < res[j].Collection+res[j].Shape
+	})
+
+	// Returns
+	return res
+}
+
+// keys orders the index keys: equality fields, then sort fields, then range fields
+func (s *queryShape) keys() (keys bson.D) {
+	seen := map[string]bool{}
+	add := func(field string, direction interface{}) {
+		if !seen[field] {
+			seen[field] = true
+			keys = append(keys, bson.E{Key: field, Value: direction})
+		}
+	}
+	for _, f := range s.equality {
+		add(f, 1)
+	}
+	for _, e := range s.sort {
+		direction := 1
+		if n, ok := toFloat(e.Value); ok && n < 0 {
+			direction = -1
+		}
+		add(e.Key, direction)
+	}
+	for _, f := range s.rng {
+		add(f, 1)
+	}
+	return keys
+}
+
+// String ...
+func (s *queryShape) String() string {
+	parts := []string{}
+	if len(s.equality) != 0 {
+		parts = append(parts, "eq("+strings.Join(s.equality, ",")+")")
+	}
+	if len(s.sort) != 0 {
+		fields := []string{}
+		for _, e := range s.sort {
+			direction := "1"
+			if n, ok := toFloat(e.Value); ok && n < 0 {
+				direction = "-1"
+			}
+			fields = append(fields, e.Key+":"+direction)
+		}
+		parts = append(parts, "sort("+strings.Join(fields, ",")+")")
+	}
+	if len(s.rng) != 0 {
+		parts = append(parts, "range("+strings.Join(s.rng, ",")+")")
+	}
+	return strings.Join(parts, " ")
+}
+
+// classifyFilter sorts filter fields into equality and range predicates, $and clauses are flattened
+// $or, $nor and $expr clauses can not be served by a single compound index and are ignored
+func classifyFilter(filter bson.D, shape *queryShape) {
+	for _, e := range filter {
+		if e.Key == "$and" {
+			if clauses, ok := e.Value.(bson.A); ok {
+				for _, clause := range clauses {
+					if doc, ok := clause.(bson.D); ok {
+						classifyFilter(doc, shape)
+					}
+				}
+			}
+			continue
+		}
+		if strings.HasPrefix(e.Key, "$") {
+			continue
+		}
+		if _, ok := equalityValue(e); ok {
+			shape.equality = append(shape.equality, e.Key)
+			continue
+		}
+		// A regular expression scans a range of keys, at best its literal prefix
+		if _, ok := e.Value.(primitive.Regex); ok {
+			shape.rng = append(shape.rng, e.Key)
+			continue
+		}
+		operators, _ := e.Value.(bson.D)
+		isRange := false
+		for _, op := range operators {
+			if rangeOperators[op.Key] {
+				isRange = true
+			}
+		}
+		if isRange {
+			shape.rng = append(shape.rng, e.Key)
+		} else {
+			// $in, $all and $elemMatch behave as equality for key ordering
+			shape.equality = append(shape.equality, e.Key)
+		}
+	}
+}
+
+// uniqueSorted ...
+func uniqueSorted(values []string) []string {
+	sort.Strings(values)
+	res := values[:0]
+	for i, v := range values {
+		if i == 0 || v != values[i-1] {
+			res = append(res, v)
+		}
+	}
+	return res
+}
@@ -0,0 +1,244 @@
+package mongodb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// Stages holding all their input in memory unless allowed to use disk
+var blockingStages = map[string]bool{"$group": true, "$sort": true, "$bucket": true, "$bucketAuto": true, "$setWindowFields": true, "$sortByCount": true}
+
+// Server error codes of stages exceeding their memory limit
+var memoryLimitCodes = map[int32]bool{292: true, 16819: true, 16820: true, 16945: true}
+
+// AggregationGuardrails limits the aggregation pipelines run by the client
+type AggregationGuardrails struct {
+	MaxTime         int   // In milliseconds, maxTimeMS of pipelines setting none, larger values are capped to it. (default is 0, no limit)
+	LargeCollection int64 // Estimated documents above which pipelines with $group, $sort or other blocking stages must set AllowDiskUse explicitly. (default is 0, never required)
+}
+
+// DiskUseRequiredError is returned when a pipeline with blocking stages over a large collection leaves AllowDiskUse unset
+type DiskUseRequiredError struct {
+	Collection string
+	Stage      string
+	Documents  int64 // Estimated documents of the collection
+}
+
+// Error ...
+func (e *DiskUseRequiredError) Error() string {
+	return fmt.Sprintf("pipeline on %s (~%d documents) has a %s stage, set AllowDiskUse explicitly", e.Collection, e.Documents, e.Stage)
+}
+
+// MemoryLimitError is returned when a pipeline stage exceeded the server memory limit
+// The pipeline can be retried with AllowDiskUse set to true, or reduced with an earlier $match or $project
+type MemoryLimitError struct {
+	Collection string
+	Err        error
+}
+
+// Error ...
+func (e *MemoryLimitError) Error() string {
+	return "pipeline on " + e.Collection + " exceeded the memory limit, retry with AllowDiskUse set to true -> " + e.Err.Error()
+}
+
+// Unwrap ...
+func (e *MemoryLimitError) Unwrap() error {
+	return e.Err
+}
+
+// collectionCount ...
+type collectionCount struct {
+	count     int64
+	expiresAt time.Time
+}
+
+// collectionCounts caches the estimated documents of collections for a minute
+type collectionCounts struct {
+	mu     sync.Mutex
+	counts map[string]collectionCount
+}
+
+// aggregateGuard applies the guardrails
+type aggregateGuard struct {
+	config *AggregationGuardrails
+	counts collectionCounts
+}
+
+// Aggregate runs the pipeline and decodes every resulting document into out, a pointer to a slice
+// Opts may set allowDiskUse, maxTime, collation, hint... within the limits of Config.Aggregation
+// Past the result limits the documents decoded so far are kept in out and a *PartialResultsError is returned
+func (c *Client) Aggregate(ctx context.Context, collection string, pipeline interface{}, out interface{}, opts *options.AggregateOptions) (err error) {
+	// Checks tenant quota
+	err = c.checkQuota(ctx, false)
+	if err != nil {
+		return err
+	}
+
+	// Hits DB
+	cursor, err := c.aggregate(ctx, collection, pipeline, opts)
+	if err != nil {
+		return err
+	}
+
+	// Close connection at the last
+	defer cursor.Close(ctx)
+
+	// Binds cursor response
+	if limits := c.resultLimits(ctx); limits != nil {
+		partial, err := c.bindLimitedAll(ctx, collection, cursor, limits, out)
+		if err != nil {
+			return aggregateError(collection, err)
+		}
+		if partial != nil {
+			return partial
+		}
+		return nil
+	}
+	err = cursor.All(ctx, out)
+	if err != nil {
+		return aggregateError(collection, err)
+	}
+
+	// Returns
+	return nil
+}
+
+// aggregate runs a pipeline within the guardrails of the client
+func (c *Client) aggregate(ctx context.Context, collection string, pipeline interface{}, opts *options.AggregateOptions) (cursor *mongo.Cursor, err error) {
+	if opts == nil {
+		opts = options.Aggregate()
+	}
+	coll := c.collection(ctx, collection)
+
+	// Applies guardrails
+	if g := c.aggregateGuard; g != nil {
+		opts, err = g.check(ctx, coll, pipeline, opts)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// Hits DB
+	cursor, err = coll.Aggregate(ctx, pipeline, opts)
+	if err != nil {
+		return nil, aggregateError(collection, err)
+	}
+
+	// Returns
+	return cursor, nil
+}
+
+// check caps the time of the pipeline and requires explicit disk use for blocking stages over large collections
+func (g *aggregateGuard) check(ctx context.Context, coll *mongo.Collection, pipeline interface{}, opts *options.AggregateOptions) (res *options.AggregateOptions, err error) {
+	res = options.MergeAggregateOptions(opts)
+
+	// Caps time
+	if g.config.MaxTime > 0 {
+		maxTime := time.Duration(g.config.MaxTime) * time.Millisecond
+		if res.MaxTime == nil || *res.MaxTime > maxTime {
+			res.SetMaxTime(maxTime)
+		}
+	}
+
+	// Checks disk use
+	if g.config.LargeCollection <= 0 || res.AllowDiskUse != nil {
+		return res, nil
+	}
+	stage, err := blockingStage(pipeline)
+	if err != nil || stage == "" {
+		return res, err
+	}
+	count, err := g.counts.count(ctx, coll)
+	if err != nil {
+		return nil, err
+	}
+	if count > g.config.LargeCollection {
+		return nil, &DiskUseRequiredError{Collection: coll.Name(), Stage: stage, Documents: count}
+	}
+
+	// Returns
+	return res, nil
+}
+
+// count returns the estimated documents of the collection
+func (g *collectionCounts) count(ctx context.Context, coll *mongo.Collection) (count int64, err error) {
+	g.mu.Lock()
+	cached, ok := g.counts[coll.Name()]
+	g.mu.Unlock()
+	if ok && time.Now().Before(cached.expiresAt) {
+		return cached.count, nil
+	}
+
+	// Hits DB
+	count, err = coll.EstimatedDocumentCount(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	// Caches
+	g.mu.Lock()
+	if g.counts == nil {
+		g.counts = map[string]collectionCount{}
+	}
+	g.counts[coll.Name()] = collectionCount{count: count, expiresAt: time.Now().Add(time.Minute)}
+	g.mu.Unlock()
+
+	// Returns
+	return count, nil
+}
+
+// blockingStage returns the first blocking stage of the pipeline, empty when none
+func blockingStage(pipeline interface{}) (stage string, err error) {
+	var stages []bson.D
+	switch p := pipeline.(type) {
+	case mongo.Pipeline:
+		stages = p
+	case []bson.D:
+		stages = p
+	default:
+		// Converts any other pipeline representation through bson
+		raw, err := bson.Marshal(bson.D{{Key: "pipeline", Value: pipeline}})
+		if err != nil {
+			return "", err
+		}
+		var wrapped struct {
+			Pipeline []bson.D `bson:"pipeline"`
+		}
+		err = bson.Unmarshal(raw, &wrapped)
+		if err != nil {
+			return "", err
+		}
+		stages = wrapped.Pipeline
+	}
+	for _, s := range stages {
+		if len(s) != 0 && blockingStages[s[0].Key] {
+			return s[0].Key, nil
+		}
+	}
+	return "", nil
+}
+
+// aggregateError converts memory limit failures into a *MemoryLimitError
+func aggregateError(collection string, err error) error {
+	if isMemoryLimit(err) {
+		return &MemoryLimitError{Collection: collection, Err: err}
+	}
+	return err
+}
+
+// isMemoryLimit reports whether a server error is a sort or blocking stage exceeding its memory limit
+func isMemoryLimit(err error) bool {
+	var serverErr mongo.CommandError
+	if !errors.As(err, &serverErr) {
+		return false
+	}
+	return memoryLimitCodes[serverErr.Code] || strings.Contains(serverErr.Message, "exceeded memory limit") || strings.Contains(serverErr.Message, "used more than the maximum")
+}
@@ -0,0 +1,55 @@
+package mongodb
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// privilegeKey is the context key of privileged writes
+type privilegeKey struct{}
+
+// AppendOnlyError is returned when an update or delete targets an append-only collection without privilege
+type AppendOnlyError struct {
+	Collection string
+	Operation  string // e.g. UpdateOne, DeleteMany
+}
+
+// Error ...
+func (e *AppendOnlyError) Error() string {
+	return "collection " + e.Collection + " is append-only, " + e.Operation + " requires a context from WithPrivilege"
+}
+
+// SetAppendOnly makes the collection append-only for this client, e.g. for ledgers
+// Inserts are allowed, updates, replacements, deletes and renames return an *AppendOnlyError unless run with a WithPrivilege context
+// It is enforced on client side only, other clients of the database are not restricted
+func (c *Client) SetAppendOnly(collection string, enabled bool) {
+	c.configure(collection, func(s *collectionSettings) { s.appendOnly = enabled })
+}
+
+// WithPrivilege allows the operations run with the returned context to modify append-only collections, e.g. for corrections or retention jobs
+func WithPrivilege(ctx context.Context) context.Context {
+	return context.WithValue(ctx, privilegeKey{}, true)
+}
+
+// checkAppendOnly rejects a modification of an append-only collection run without privilege
+func (c *Client) checkAppendOnly(ctx context.Context, collection string, operation string) error {
+	s := c.settings(collection)
+	if s == nil || !s.appendOnly {
+		return nil
+	}
+	if privileged, _ := ctx.Value(privilegeKey{}).(bool); privileged {
+		return nil
+	}
+	return &AppendOnlyError{Collection: collection, Operation: operation}
+}
+
+// checkAppendOnlyModels rejects write models other than inserts on an append-only collection run without privilege
+func (c *Client) checkAppendOnlyModels(ctx context.Context, collection string, models []mongo.WriteModel) error {
+	for _, model := range models {
+		if _, ok := model.(*mongo.InsertOneModel); !ok {
+			return c.checkAppendOnly(ctx, collection, "BulkWrite")
+		}
+	}
+	return nil
+}
@@ -0,0 +1,323 @@
+// Package atlas reads metadata of the MongoDB Atlas cluster a client is connected to through the Atlas Admin API
+package atlas
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// Default Atlas Admin API endpoint
+const defaultBaseURL = "https://cloud.mongodb.com/api/atlas/v1.0"
+
+// Config contains the properties required to call the Atlas Admin API
+type Config struct {
+	PublicKey   string       // Atlas programmatic API public key
+	PrivateKey  string       // Atlas programmatic API private key
+	ProjectID   string       // Atlas project (group) id of the cluster
+	ClusterName string       // Name of the cluster
+	BaseURL     string       // Admin API base URL. (default is https://cloud.mongodb.com/api/atlas/v1.0)
+	HTTPClient  *http.Client // HTTP client used for requests. (default is a client with a 30 seconds timeout)
+}
+
+// Client calls the Atlas Admin API
+type Client struct {
+	config     *Config
+	baseURL    string
+	httpClient *http.Client
+}
+
+// Cluster is the Atlas metadata of a cluster
+type Cluster struct {
+	Name           string
+	Tier           string // Instance size, e.g. M30
+	Provider       string // Cloud provider, e.g. AWS
+	Region         string
+	MongoDBVersion string
+	State          string  // e.g. IDLE, UPDATING
+	DiskSizeGB     float64 // Provisioned disk size
+}
+
+// DiskUsage is the latest disk usage of a cluster process
+type DiskUsage struct {
+	Process     string // Host and port of the process
+	UsedBytes   float64
+	FreeBytes   float64
+	UsedPercent float64
+	Timestamp   time.Time
+}
+
+// Alert is an open Atlas alert of the project
+type Alert struct {
+	ID           string
+	EventType    string
+	Status       string
+	ClusterName  string
+	MetricName   string
+	CurrentValue float64
+	Units        string
+	Created      time.Time
+}
+
+// Report is the Atlas view of the cluster
+type Report struct {
+	Cluster *Cluster
+	Disks   []DiskUsage
+	Alerts  []Alert // Open alerts of the cluster, or of the whole project when an alert is not tied to a cluster
+}
+
+// New returns a new Atlas Admin API client
+func New(config *Config) (client *Client, err error) {
+	// Validates
+	if config.PublicKey == "" || config.PrivateKey == "" {
+		return nil, errors.New("atlas api keys are required")
+	}
+	if config.ProjectID == "" || config.ClusterName == "" {
+		return nil, errors.New("atlas project id and cluster name are required")
+	}
+
+	// Sets defaults
+	client = &Client{config: config, baseURL: strings.TrimSuffix(config.BaseURL, "/"), httpClient: config.HTTPClient}
+	if client.baseURL == "" {
+		client.baseURL = defaultBaseURL
+	}
+	if client.httpClient == nil {
+		client.httpClient = &http.Client{Timeout: 30 * time.Second}
+	}
+
+	// Returns
+	return client, nil
+}
+
+// Report fetches the cluster tier, disk usage and open alerts of the cluster
+func (c *Client) Report(ctx context.Context) (report *Report, err error) {
+	report = &Report{}
+
+	// Gets cluster
+	report.Cluster, err = c.Cluster(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// Gets disks
+	report.Disks, err = c.DiskUsage(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// Gets alerts
+	report.Alerts, err = c.OpenAlerts(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// Returns
+	return report, nil
+}
+
+// Cluster fetches the cluster metadata
+func (c *Client) Cluster(ctx context.Context) (cluster *Cluster, err error) {
+	// Hits API
+	var res struct {
+		Name             string  `json:"name"`
+		MongoDBVersion   string  `json:"mongoDBVersion"`
+		StateName        string  `json:"stateName"`
+		DiskSizeGB       float64 `json:"diskSizeGB"`
+		ProviderSettings struct {
+			ProviderName     string `json:"providerName"`
+			InstanceSizeName string `json:"instanceSizeName"`
+			RegionName       string `json:"regionName"`
+		} `json:"providerSettings"`
+	}
+	err = c.get(ctx, "/groups/"+url.PathEscape(c.config.ProjectID)+"/clusters/"+url.PathEscape(c.config.ClusterName), nil, &res)
+	if err != nil {
+		return nil, err
+	}
+
+	// Returns
+	return &Cluster{
+		Name:           res.Name,
+		Tier:           res.ProviderSettings.InstanceSizeName,
+		Provider:       res.ProviderSettings.ProviderName,
+		Region:         res.ProviderSettings.RegionName,
+		MongoDBVersion: res.MongoDBVersion,
+		State:          res.StateName,
+		DiskSizeGB:     res.DiskSizeGB,
+	}, nil
+}
+
+// DiskUsage fetches the latest data partition usage of every process of the cluster
+func (c *Client) DiskUsage(ctx context.Context) (res []DiskUsage, err error) {
+	// Lists processes
+	var processes struct {
+		Results []struct {
+			ID        string `json:"id"`
+			UserAlias string `json:"userAlias"`
+			Hostname  string `json:"hostname"`
+		} `json:"results"`
+	}
+	err = c.get(ctx, "/groups/"+url.PathEscape(c.config.ProjectID)+"/processes", nil, &processes)
+	if err != nil {
+		return nil, err
+	}
+
+	// Gets measurements of the cluster processes
+	prefix := strings.ToLower(c.config.ClusterName) + "-"
+	for _, p := range processes.Results {
+		if !strings.HasPrefix(strings.ToLower(p.UserAlias), prefix) && !strings.HasPrefix(strings.ToLower(p.Hostname), prefix) {
+			continue
+		}
+		query := url.Values{
+			"granularity": {"PT1M"},
+			"period":      {"PT10M"},
+			"m":           {"DISK_PARTITION_SPACE_USED", "DISK_PARTITION_SPACE_FREE", "DISK_PARTITION_SPACE_PERCENT_USED"},
+		}
+		var measurements struct {
+			Measurements []struct {
+				Name       string `json:"name"`
+				DataPoints []struct {
+					Timestamp time.Time `json:"timestamp"`
+					Value     *float64  `json:"value"`
+				} `json:"dataPoints"`
+			} `json:"measurements"`
+		}
+		err = c.get(ctx, "/groups/"+url.PathEscape(c.config.ProjectID)+"/processes/"+url.PathEscape(p.ID)+"/disks/data/measurements", query, &measurements)
+		if err != nil {
+			return nil, err
+		}
+
+		// Keeps latest data points
+		usage := DiskUsage{Process: p.ID}
+		for _, m := range measurements.Measurements {
+			for i := len(m.DataPoints) - 1; i >= 0; i-- {
+				point := m.DataPoints[i]
+				if point.Value == nil {
+					continue
+				}
+				switch m.Name {
+				case "DISK_PARTITION_SPACE_USED":
+					usage.UsedBytes = *point.Value
+				case "DISK_PARTITION_SPACE_FREE":
+					usage.FreeBytes = *point.Value
+				case "DISK_PARTITION_SPACE_PERCENT_USED":
+					usage.UsedPercent = *point.Value
+				}
+				if point.Timestamp.After(usage.Timestamp) {
+					usage.Timestamp = point.Timestamp
+				}
+				break
+			}
+		}
+		res = append(res, usage)
+	}
+
+	// Returns
+	return res, nil
+}
+
+// OpenAlerts fetches the open alerts of the cluster, alerts not tied to a cluster are included
+func (c *Client) OpenAlerts(ctx context.Context) (res []Alert, err error) {
+	// Hits API
+	var alerts struct {
+		Results []struct {
+			ID            string    `json:"id"`
+			EventTypeName string    `json:"eventTypeName"`
+			Status        string    `json:"status"`
+			ClusterName   string    `json:"clusterName"`
+			MetricName    string    `json:"metricName"`
+			Created       time.Time `json:"created"`
+			CurrentValue  struct {
+				Number float64 `json:"number"`
+				Units  string  `json:"units"`
+			} `json:"currentValue"`
+		} `json:"results"`
+	}
+	err = c.get(ctx, "/groups/"+url.PathEscape(c.config.ProjectID)+"/alerts", url.Values{"status": {"OPEN"}}, &alerts)
+	if err != nil {
+		return nil, err
+	}
+
+	// Filters cluster alerts
+	for _, a := range alerts.Results {
+		if a.ClusterName != "" && a.ClusterName != c.config.ClusterName {
+			continue
+		}
+		res = append(res, Alert{
+			ID:           a.ID,
+			EventType:    a.EventTypeName,
+			Status:       a.Status,
+			ClusterName:  a.ClusterName,
+			MetricName:   a.MetricName,
+			CurrentValue: a.CurrentValue.Number,
+			Units:        a.CurrentValue.Units,
+			Created:      a.Created,
+		})
+	}
+
+	// Returns
+	return res, nil
+}
+
+// get calls the API with digest authentication and decodes the JSON response
+func (c *Client) get(ctx context.Context, path string, query url.Values, out interface{}) (err error) {
+	endpoint := c.baseURL + path
+	if len(query) != 0 {
+		endpoint += "?" + query.Encode()
+	}
+
+	// Gets digest challenge
+	res, err := c.do(ctx, endpoint, nil)
+	if err != nil {
+		return err
+	}
+	if res.StatusCode == http.StatusUnauthorized {
+		challenge, err := parseDigestChallenge(res.Header.Get("WWW-Authenticate"))
+		drain(res.Body)
+		if err != nil {
+			return err
+		}
+		res, err = c.do(ctx, endpoint, challenge)
+		if err != nil {
+			return err
+		}
+	}
+	defer drain(res.Body)
+
+	// Checks status
+	if res.StatusCode != http.StatusOK {
+		body, _ := ioutil.ReadAll(io.LimitReader(res.Body, 4096))
+		return fmt.Errorf("atlas api %s failed with status %d -> %s", path, res.StatusCode, strings.TrimSpace(string(body)))
+	}
+
+	// Decodes
+	return json.NewDecoder(res.Body).Decode(out)
+}
+
+// do sends a GET request, authorized when a challenge is given
+func (c *Client) do(ctx context.Context, endpoint string, challenge *digestChallenge) (res *http.Response, err error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/json")
+	if challenge != nil {
+		err = challenge.authorize(req, c.config.PublicKey, c.config.PrivateKey)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return c.httpClient.Do(req)
+}
+
+// drain reads and closes a response body so the connection can be reused
+func drain(body io.ReadCloser) {
+	io.Copy(ioutil.Discard, body)
+	body.Close()
+}
@@ -0,0 +1,115 @@
+package atlas
+
+import (
+	"crypto/md5"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// digestChallenge is a parsed WWW-Authenticate digest challenge
+type digestChallenge struct {
+	realm     string
+	nonce     string
+	opaque    string
+	qop       string
+	algorithm string
+}
+
+// parseDigestChallenge ...
+func parseDigestChallenge(header string) (challenge *digestChallenge, err error) {
+	if !strings.HasPrefix(header, "Digest ") {
+		return nil, errors.New("unsupported authentication challenge " + header)
+	}
+
+	// Reads parameters
+	params := map[string]string{}
+	for _, part := range splitChallenge(strings.TrimPrefix(header, "Digest ")) {
+		kv := strings.SplitN(strings.TrimSpace(part), "=", 2)
+		if len(kv) != 2 {
+			continue
+		}
+		params[strings.ToLower(kv[0])] = strings.Trim(kv[1], `"`)
+	}
+	challenge = &digestChallenge{
+		realm:     params["realm"],
+		nonce:     params["nonce"],
+		opaque:    params["opaque"],
+		qop:       params["qop"],
+		algorithm: params["algorithm"],
+	}
+	if challenge.nonce == "" {
+		return nil, errors.New("digest challenge has no nonce")
+	}
+
+	// Returns
+	return challenge, nil
+}
+
+// splitChallenge splits challenge parameters on commas outside quotes
+func splitChallenge(s string) (parts []string) {
+	quoted := false
+	start := 0
+	for i, r := range s {
+		switch r {
+		case '"':
+			quoted = !quoted
+		case ',':
+			if !quoted {
+				parts = append(parts, s[start:i])
+				start = i + 1
+			}
+		}
+	}
+	return append(parts, s[start:])
+}
+
+// authorize sets the digest Authorization header of the request
+func (c *digestChallenge) authorize(req *http.Request, username string, password string) (err error) {
+	// Generates client nonce
+	buf := make([]byte, 8)
+	_, err = rand.Read(buf)
+	if err != nil {
+		return err
+	}
+	cnonce := hex.EncodeToString(buf)
+	nc := "00000001"
+	uri := req.URL.RequestURI()
+
+	// Computes response
+	ha1 := md5Hex(username + ":" + c.realm + ":" + password)
+	ha2 := md5Hex(req.Method + ":" + uri)
+	var response string
+	qop := ""
+	if strings.Contains(c.qop, "auth") {
+		qop = "auth"
+		response = md5Hex(strings.Join([]string{ha1, c.nonce, nc, cnonce, qop, ha2}, ":"))
+	} else {
+		response = md5Hex(ha1 + ":" + c.nonce + ":" + ha2)
+	}
+
+	// Sets header
+	header := fmt.Sprintf(`Digest username="%s", realm="%s", nonce="%s", uri="%s", response="%s"`, username, c.realm, c.nonce, uri, response)
+	if qop != "" {
+		header += fmt.Sprintf(`, qop=%s, nc=%s, cnonce="%s"`, qop, nc, cnonce)
+	}
+	if c.opaque != "" {
+		header += fmt.Sprintf(`, opaque="%s"`, c.opaque)
+	}
+	if c.algorithm != "" {
+		header += ", algorithm=" + c.algorithm
+	}
+	req.Header.Set("Authorization", header)
+
+	// Returns
+	return nil
+}
+
+// md5Hex ...
+func md5Hex(s string) string {
+	sum := md5.Sum([]byte(s))
+	return hex.EncodeToString(sum[:])
+}
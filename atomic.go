@@ -0,0 +1,239 @@
+package mongodb
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// GetOrCreate decodes the document matching the filter into dest, inserting defaultDoc first when none matches
+// Find and insert happen atomically in a single findOneAndUpdate upsert with $setOnInsert
+// The inserted document holds the equalities of the filter, e.g. its _id, overridden by the fields of defaultDoc
+// Created reports whether the document was inserted by this call
+func (c *Client) GetOrCreate(ctx context.Context, collection string, filter interface{}, defaultDoc interface{}, dest interface{}) (created bool, err error) {
+	// Checks tenant quota
+	err = c.checkQuota(ctx, true)
+	if err != nil {
+		return false, err
+	}
+
+	// Merges filter equalities, the server copies them into the inserted document
+	query := bson.D{}
+	if filter != nil {
+		query, err = toDoc(filter)
+		if err != nil {
+			return false, err
+		}
+	}
+	document, err := toDoc(defaultDoc)
+	if err != nil {
+		return false, err
+	}
+	document = upsertDocument(query, document)
+
+	// Applies collection rules, after the merge so the checksum covers the stored document
+	prepared, err := c.prepareInsert(collection, document)
+	if err != nil {
+		return false, err
+	}
+	doc, err := withID(prepared, nil)
+	if err != nil {
+		return false, err
+	}
+
+	// Hits DB, the document before the update is only missing when this call inserted it
+	var res interface{}
+	opts := options.FindOneAndUpdate().SetUpsert(true).SetReturnDocument(options.Before)
+	update := bson.D{{Key: "$setOnInsert", Value: doc}}
+	err = c.collection(ctx, collection).FindOneAndUpdate(ctx, query, update, opts).Decode(&res)
+	if err == mongo.ErrNoDocuments {
+		created, res, err = true, doc, nil
+	}
+	if err != nil {
+		return false, duplicateError(err)
+	}
+	if created {
+		c.addTenantStorage(ctx, doc)
+	}
+
+	// Verifies checksums
+	err = c.verifyChecksums(collection, res)
+	if err != nil {
+		return created, err
+	}
+
+	// Decompresses fields
+	err = c.decompressResults(collection, res)
+	if err != nil {
+		return created, err
+	}
+
+	// Decodes
+	err = c.decodeInto(res, dest)
+	if err != nil {
+		return created, err
+	}
+
+	// Returns
+	return created, nil
+}
+
+// upsertDocument returns the document an upsert inserts: the equalities of the filter, $and clauses included, then the fields of the document
+func upsertDocument(filter bson.D, document bson.D) bson.D {
+	res := bson.D{}
+	var addEqualities func(filter bson.D)
+	addEqualities = func(filter bson.D) {
+		for _, e := range filter {
+			if e.Key == "$and" {
+				clauses, _ := e.Value.(bson.A)
+				for _, clause := range clauses {
+					if doc, ok := clause.(bson.D); ok {
+						addEqualities(doc)
+					}
+				}
+				continue
+			}
+			if value, ok := equalityValue(e); ok {
+				res = setPath(res, strings.Split(e.Key, "."), value)
+			}
+		}
+	}
+	addEqualities(filter)
+	for _, e := range document {
+		res = setPath(res, []string{e.Key}, e.Value)
+	}
+	return res
+}
+
+// ConflictError is returned by CompareAndSet when its precondition fails
+type ConflictError struct {
+	Collection string
+	Field      string
+	Expected   interface{}
+	Missing    bool // No document matches the filter at all
+}
+
+// Error ...
+func (e *ConflictError) Error() string {
+	if e.Missing {
+		return "compare and set on " + e.Collection + " failed -> no document matches the filter"
+	}
+	return fmt.Sprintf("compare and set on %s failed -> field %s no longer holds %v", e.Collection, e.Field, e.Expected)
+}
+
+// CompareAndSet sets the field of the document matching the filter to newValue only if it still holds expected
+// A failed precondition returns a *ConflictError, callers typically re-read the document and retry
+func (c *Client) CompareAndSet(ctx context.Context, collection string, filter interface{}, field string, expected interface{}, newValue interface{}) (err error) {
+	// Checks tenant quota
+	err = c.checkQuota(ctx, true)
+	if err != nil {
+		return err
+	}
+
+	// Checks append-only
+	err = c.checkAppendOnly(ctx, collection, "CompareAndSet")
+	if err != nil {
+		return err
+	}
+
+	// Adds precondition
+	query, err := MergeFilters(filter, bson.D{{Key: field, Value: expected}})
+	if err != nil {
+		return err
+	}
+
+	// Applies collection rules
+	update, err := c.prepareUpdate(collection, bson.D{{Key: "$set", Value: bson.D{{Key: field, Value: newValue}}}})
+	if err != nil {
+		return err
+	}
+
+	// Hits DB
+	coll := c.collection(ctx, collection)
+	res, err := coll.UpdateOne(ctx, query, update)
+	if err != nil {
+		return duplicateError(err)
+	}
+	if res.MatchedCount != 0 {
+		return nil
+	}
+
+	// Reports conflict
+	conflict := &ConflictError{Collection: collection, Field: field, Expected: expected}
+	if filter == nil {
+		filter = bson.D{}
+	}
+	count, err := coll.CountDocuments(ctx, filter, options.Count().SetLimit(1))
+	if err != nil {
+		return err
+	}
+	conflict.Missing = count == 0
+
+	// Returns
+	return conflict
+}
+
+// FindOneAndUpdate atomically updates the document matching the filter and decodes it into dest
+// Opts sets the returned version (options.Before or options.After), upsert, sort and projection, the default sort of the collection applies when none is set
+// Found is false when no document was returned, e.g. nothing matched or an upsert inserted with options.Before, dest is then untouched
+func (c *Client) FindOneAndUpdate(ctx context.Context, collection string, filter interface{}, update interface{}, opts *options.FindOneAndUpdateOptions, dest interface{}) (found bool, err error) {
+	// Checks tenant quota
+	err = c.checkQuota(ctx, true)
+	if err != nil {
+		return false, err
+	}
+
+	// Checks append-only
+	err = c.checkAppendOnly(ctx, collection, "FindOneAndUpdate")
+	if err != nil {
+		return false, err
+	}
+
+	// Applies collection rules
+	update, err = c.prepareUpdate(collection, update)
+	if err != nil {
+		return false, err
+	}
+	opts = options.MergeFindOneAndUpdateOptions(opts)
+	if s := c.settings(collection); opts.Sort == nil && s != nil && s.defaultSort != nil {
+		opts.SetSort(s.defaultSort)
+	}
+
+	// Hits DB
+	var res interface{}
+	err = c.collection(ctx, collection).FindOneAndUpdate(ctx, filter, update, opts).Decode(&res)
+	if err != nil {
+		// Handles no document found
+		if err == mongo.ErrNoDocuments {
+			return false, nil
+		}
+		return false, duplicateError(err)
+	}
+
+	// Verifies checksums, projected documents can not be verified
+	if opts.Projection == nil {
+		err = c.verifyChecksums(collection, res)
+		if err != nil {
+			return false, err
+		}
+	}
+
+	// Decompresses fields
+	err = c.decompressResults(collection, res)
+	if err != nil {
+		return false, err
+	}
+
+	// Decodes
+	err = c.decodeInto(res, dest)
+	if err != nil {
+		return false, err
+	}
+
+	// Returns
+	return true, nil
+}
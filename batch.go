@@ -0,0 +1,240 @@
+package mongodb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// Server limits of a single batch write
+const (
+	maxBatchOperations = 100000
+	maxBatchBytes      = 16 * 1024 * 1024
+)
+
+// BatchOptions ...
+type BatchOptions struct {
+	Ordered       bool                  // Stops at the first failure (default is false, unordered)
+	MaxOperations int                   // Operations per sub batch (default is 100000, the server limit)
+	MaxBytes      int                   // Bytes per sub batch (default is 16MB, the server limit)
+	FirstBatch    int                   // Operations of the first sub batch when the context has a deadline, later ones are sized from the measured throughput (default is 1000)
+	OnProgress    func(done, total int) // Called after every sub batch
+}
+
+// PartialWriteError is returned when the context expires before all sub batches were written
+// The first Done documents or operations were written, the rest can be retried from that position
+// The sub batch in flight when the context expired may have been partially written
+type PartialWriteError struct {
+	Done  int
+	Total int
+	Err   error
+}
+
+// Error ...
+func (e *PartialWriteError) Error() string {
+	return fmt.Sprintf("batch write stopped after %d of %d -> %s", e.Done, e.Total, e.Err.Error())
+}
+
+// Unwrap ...
+func (e *PartialWriteError) Unwrap() error {
+	return e.Err
+}
+
+// InsertMany inserts the documents in sub batches fitting the server limits and the remaining context deadline
+// Failed documents are returned as a *BulkError indexed within documents, an expired context as a *PartialWriteError
+func (c *Client) InsertMany(ctx context.Context, collection string, documents []interface{}, opts *BatchOptions) (done int, err error) {
+	// Checks tenant quota
+	err = c.checkQuota(ctx, true)
+	if err != nil {
+		return 0, err
+	}
+
+	// Applies collection rules
+	docs := make([]interface{}, len(documents))
+	sizes := make([]int, len(documents))
+	for i, document := range documents {
+		docs[i], err = c.prepareInsert(collection, document)
+		if err != nil {
+			return 0, err
+		}
+		sizes[i] = bsonSize(docs[i])
+	}
+
+	// Hits DB
+	coll := c.collection(ctx, collection)
+	return writeBatches(ctx, sizes, opts, c.panicHandler, func(ctx context.Context, from, to int, ordered bool) error {
+		_, err := coll.InsertMany(ctx, docs[from:to], options.InsertMany().SetOrdered(ordered))
+		if err == nil {
+			c.addTenantStorage(ctx, docs[from:to]...)
+		}
+		return err
+	})
+}
+
+// BulkWrite runs the write models in sub batches fitting the server limits and the remaining context deadline
+// Failed operations are returned as a *BulkError indexed within models, an expired context as a *PartialWriteError
+func (c *Client) BulkWrite(ctx context.Context, collection string, models []mongo.WriteModel, opts *BatchOptions) (done int, err error) {
+	// Checks tenant quota
+	err = c.checkQuota(ctx, true)
+	if err != nil {
+		return 0, err
+	}
+
+	// Checks append-only
+	err = c.checkAppendOnlyModels(ctx, collection, models)
+	if err != nil {
+		return 0, err
+	}
+
+	sizes := make([]int, len(models))
+	for i, model := range models {
+		sizes[i] = modelSize(model)
+	}
+
+	// Hits DB
+	coll := c.collection(ctx, collection)
+	return writeBatches(ctx, sizes, opts, c.panicHandler, func(ctx context.Context, from, to int, ordered bool) error {
+		_, err := coll.BulkWrite(ctx, models[from:to], options.BulkWrite().SetOrdered(ordered))
+		if err == nil {
+			c.addTenantStorage(ctx, insertedDocuments(models[from:to])...)
+		}
+		return err
+	})
+}
+
+// writeBatches splits the operations of the given sizes into sub batches written in order
+func writeBatches(ctx context.Context, sizes []int, opts *BatchOptions, panicHandler func(err *PanicError), write func(ctx context.Context, from, to int, ordered bool) error) (done int, err error) {
+	if opts == nil {
+		opts = &BatchOptions{}
+	}
+	maxOps := opts.MaxOperations
+	if maxOps <= 0 || maxOps > maxBatchOperations {
+		maxOps = maxBatchOperations
+	}
+	maxBytes := opts.MaxBytes
+	if maxBytes <= 0 || maxBytes > maxBatchBytes {
+		maxBytes = maxBatchBytes
+	}
+	firstBatch := opts.FirstBatch
+	if firstBatch <= 0 {
+		firstBatch = 1000
+	}
+
+	var failures *BulkError
+	var rate float64 // Operations per second measured so far
+	total := len(sizes)
+	for done < total {
+		// Checks context
+		if ctx.Err() != nil {
+			return done, &PartialWriteError{Done: done, Total: total, Err: ctx.Err()}
+		}
+
+		// Sizes sub batch
+		limit := maxOps
+		if deadline, ok := ctx.Deadline(); ok {
+			limit = firstBatch
+			if rate > 0 {
+				// Keeps a fifth of the remaining time as margin
+				limit = int(rate * time.Until(deadline).Seconds() * 0.8)
+			}
+			if limit > maxOps {
+				limit = maxOps
+			}
+			if limit < 1 {
+				limit = 1
+			}
+		}
+		end, bytes := done, 0
+		for end < total && end-done < limit && (end == done || bytes+sizes[end] <= maxBytes) {
+			bytes += sizes[end]
+			end++
+		}
+
+		// Writes
+		start := time.Now()
+		err = write(ctx, done, end, opts.Ordered)
+		if elapsed := time.Since(start).Seconds(); elapsed > 0 {
+			rate = float64(end-done) / elapsed
+		}
+		if err != nil {
+			if ctx.Err() != nil || errors.Is(err, context.DeadlineExceeded) || errors.Is(err, context.Canceled) {
+				return done, &PartialWriteError{Done: done, Total: total, Err: err}
+			}
+			converted := newBulkError(err, done)
+			bulkErr, ok := converted.(*BulkError)
+			if !ok {
+				return done, err
+			}
+			if opts.Ordered {
+				return done, bulkErr
+			}
+			if failures == nil {
+				failures = bulkErr
+			} else {
+				failures.Failures = append(failures.Failures, bulkErr.Failures...)
+				if bulkErr.WriteConcernError != "" {
+					failures.WriteConcernError = bulkErr.WriteConcernError
+				}
+			}
+		}
+		done = end
+		if opts.OnProgress != nil {
+			err = safeCall(panicHandler, "BatchOptions.OnProgress", func() { opts.OnProgress(done, total) })
+			if err != nil {
+				return done, err
+			}
+		}
+	}
+
+	// Returns
+	if failures != nil {
+		return done, failures
+	}
+	return done, nil
+}
+
+// modelSize estimates the encoded size of a write model
+func modelSize(model mongo.WriteModel) int {
+	switch m := model.(type) {
+	case *mongo.InsertOneModel:
+		return bsonSize(m.Document)
+	case *mongo.ReplaceOneModel:
+		return bsonSize(m.Filter) + bsonSize(m.Replacement)
+	case *mongo.UpdateOneModel:
+		return bsonSize(m.Filter) + bsonSize(m.Update)
+	case *mongo.UpdateManyModel:
+		return bsonSize(m.Filter) + bsonSize(m.Update)
+	case *mongo.DeleteOneModel:
+		return bsonSize(m.Filter)
+	case *mongo.DeleteManyModel:
+		return bsonSize(m.Filter)
+	}
+	return 0
+}
+
+// bsonSize returns the encoded size of a document, 0 when it can not be encoded
+func bsonSize(document interface{}) int {
+	if document == nil {
+		return 0
+	}
+	switch d := document.(type) {
+	case bson.Raw:
+		return len(d)
+	case mongo.Pipeline:
+		size := 0
+		for _, stage := range d {
+			size += bsonSize(stage)
+		}
+		return size
+	}
+	raw, err := bson.Marshal(document)
+	if err != nil {
+		return 0
+	}
+	return len(raw)
+}
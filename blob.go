@@ -0,0 +1,25 @@
+package mongodb
+
+import (
+	"context"
+	"io"
+)
+
+// BlobSink stores objects streamed to it, e.g. an S3 compatible bucket, see the s3 package
+type BlobSink interface {
+	// Create returns a writer of the object, it is stored once the writer is closed without error
+	Create(ctx context.Context, key string) (w io.WriteCloser, err error)
+}
+
+// BlobSource reads objects back from a storage
+type BlobSource interface {
+	Open(ctx context.Context, key string) (r io.ReadCloser, err error)
+}
+
+// BlobExportWriters streams ExportSnapshot collections to objects of the sink named prefix + collection + ".jsonl"
+// e.g. BlobExportWriters(ctx, bucket, "exports/2024-01-31/")
+func BlobExportWriters(ctx context.Context, sink BlobSink, prefix string) ExportWriterFactory {
+	return func(collection string) (io.WriteCloser, error) {
+		return sink.Create(ctx, prefix+collection+".jsonl")
+	}
+}
@@ -0,0 +1,196 @@
+package mongodb
+
+import (
+	"context"
+	"errors"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/bsontype"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// Default bucket fields
+const (
+	defaultBucketKeyField          = "key"
+	defaultBucketMeasurementsField = "measurements"
+	defaultBucketCountField        = "count"
+	defaultBucketMaxCount          = 200
+	bucketStartField               = "start"
+	bucketEndField                 = "end"
+)
+
+// BucketOptions describes how measurements are grouped into bucket documents
+type BucketOptions struct {
+	KeyField          string // Field identifying the series of a bucket, e.g. device id. (default is "key")
+	MeasurementsField string // Array field holding the measurements of a bucket. (default is "measurements")
+	CountField        string // Field holding the number of measurements in a bucket. (default is "count")
+	TimeField         string // Measurement field holding its timestamp, when set buckets keep the time range of their measurements in "start" & "end" fields
+	MaxCount          int    // Maximum number of measurements in a bucket, a new bucket is started once reached. (default is 200)
+}
+
+// withDefaults ...
+func (o *BucketOptions) withDefaults() *BucketOptions {
+	opts := BucketOptions{}
+	if o != nil {
+		opts = *o
+	}
+	if opts.KeyField == "" {
+		opts.KeyField = defaultBucketKeyField
+	}
+	if opts.MeasurementsField == "" {
+		opts.MeasurementsField = defaultBucketMeasurementsField
+	}
+	if opts.CountField == "" {
+		opts.CountField = defaultBucketCountField
+	}
+	if opts.MaxCount <= 0 {
+		opts.MaxCount = defaultBucketMaxCount
+	}
+	return &opts
+}
+
+// AppendToBucket appends a measurement to the open bucket of the key
+// A new bucket is created when the key has no bucket yet or its latest bucket is full
+// Concurrent appends missing an open bucket may each create one, the key then has several partially filled buckets,
+// ReadBucketed still returns all of their measurements but their order across those buckets follows the bucket _id
+func (c *Client) AppendToBucket(ctx context.Context, collection string, key interface{}, measurement interface{}, opts *BucketOptions) (err error) {
+	opts = opts.withDefaults()
+
+	// Checks tenant quota
+	err = c.checkQuota(ctx, true)
+	if err != nil {
+		return err
+	}
+
+	// Checks append-only, appending updates the open bucket
+	err = c.checkAppendOnly(ctx, collection, "AppendToBucket")
+	if err != nil {
+		return err
+	}
+
+	// Matches a bucket of the key with free space
+	query := bson.D{
+		{Key: opts.KeyField, Value: key},
+		{Key: opts.CountField, Value: bson.D{{Key: "$lt", Value: opts.MaxCount}}},
+	}
+
+	// Pushes measurement
+	update := bson.D{
+		{Key: "$push", Value: bson.D{{Key: opts.MeasurementsField, Value: measurement}}},
+		{Key: "$inc", Value: bson.D{{Key: opts.CountField, Value: 1}}},
+	}
+
+	// Tracks time range
+	if opts.TimeField != "" {
+		raw, err := bson.Marshal(measurement)
+		if err != nil {
+			return err
+		}
+		value, err := bson.Raw(raw).LookupErr(opts.TimeField)
+		if err != nil || value.Type != bsontype.DateTime {
+			return errors.New("measurement has no date field " + opts.TimeField)
+		}
+		update = append(update,
+			bson.E{Key: "$min", Value: bson.D{{Key: bucketStartField, Value: value}}},
+			bson.E{Key: "$max", Value: bson.D{{Key: bucketEndField, Value: value}}},
+		)
+	}
+
+	// Applies collection rules
+	prepared, err := c.prepareUpdate(collection, update)
+	if err != nil {
+		return err
+	}
+
+	// Hits DB
+	_, err = c.collection(ctx, collection).UpdateOne(ctx, query, prepared, options.Update().SetUpsert(true))
+	if err != nil {
+		return duplicateError(err)
+	}
+	c.addTenantStorage(ctx, measurement)
+
+	// Returns
+	return nil
+}
+
+// ReadBucketed returns the measurements of the key unwound from their buckets, in insertion order
+// Query is matched against the individual measurements, nil returns all of them
+func (c *Client) ReadBucketed(ctx context.Context, collection string, key interface{}, query interface{}, opts *BucketOptions) (res []interface{}, err error) {
+	opts = opts.withDefaults()
+
+	// Builds pipeline
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: bson.D{{Key: opts.KeyField, Value: key}}}},
+		{{Key: "$sort", Value: bson.D{{Key: "_id", Value: 1}}}},
+		{{Key: "$unwind", Value: "$" + opts.MeasurementsField}},
+		{{Key: "$replaceRoot", Value: bson.D{{Key: "newRoot", Value: "$" + opts.MeasurementsField}}}},
+	}
+	if query != nil {
+		pipeline = append(pipeline, bson.D{{Key: "$match", Value: query}})
+	}
+
+	// Hits DB
+	cursor, err := c.aggregate(ctx, collection, pipeline, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	// Close connection at the last
+	defer cursor.Close(ctx)
+
+	// Binds cursor response
+	err = cursor.All(ctx, &res)
+	if err != nil {
+		return nil, err
+	}
+	if len(res) == 0 {
+		return nil, nil
+	}
+
+	// Returns
+	return res, nil
+}
+
+// ReadBucketedBetween returns the measurements of the key with a timestamp in [from, to]
+// Only buckets overlapping the range are unwound, requires BucketOptions.TimeField
+func (c *Client) ReadBucketedBetween(ctx context.Context, collection string, key interface{}, from interface{}, to interface{}, opts *BucketOptions) (res []interface{}, err error) {
+	opts = opts.withDefaults()
+	if opts.TimeField == "" {
+		return nil, errors.New("bucket time field is not set")
+	}
+
+	// Builds pipeline
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: bson.D{
+			{Key: opts.KeyField, Value: key},
+			{Key: bucketStartField, Value: bson.D{{Key: "$lte", Value: to}}},
+			{Key: bucketEndField, Value: bson.D{{Key: "$gte", Value: from}}},
+		}}},
+		{{Key: "$sort", Value: bson.D{{Key: bucketStartField, Value: 1}, {Key: "_id", Value: 1}}}},
+		{{Key: "$unwind", Value: "$" + opts.MeasurementsField}},
+		{{Key: "$replaceRoot", Value: bson.D{{Key: "newRoot", Value: "$" + opts.MeasurementsField}}}},
+		{{Key: "$match", Value: bson.D{{Key: opts.TimeField, Value: bson.D{{Key: "$gte", Value: from}, {Key: "$lte", Value: to}}}}}},
+	}
+
+	// Hits DB
+	cursor, err := c.aggregate(ctx, collection, pipeline, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	// Close connection at the last
+	defer cursor.Close(ctx)
+
+	// Binds cursor response
+	err = cursor.All(ctx, &res)
+	if err != nil {
+		return nil, err
+	}
+	if len(res) == 0 {
+		return nil, nil
+	}
+
+	// Returns
+	return res, nil
+}
@@ -0,0 +1,147 @@
+package mongodb
+
+import (
+	"context"
+	"errors"
+	"strconv"
+
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// WriteOperation is an operation of BulkWriteOperations: *InsertOperation, *UpdateOperation, *ReplaceOperation or *DeleteOperation
+type WriteOperation interface {
+	model(ctx context.Context, c *Client, collection string) (model mongo.WriteModel, err error)
+}
+
+// InsertOperation inserts a document
+type InsertOperation struct {
+	Document interface{}
+}
+
+// UpdateOperation applies an update document or pipeline to the first, or every, document matching the filter
+type UpdateOperation struct {
+	Filter interface{}
+	Update interface{}
+	Many   bool // Updates every matching document
+	Upsert bool // Inserts a document built from the filter and update when none matches
+}
+
+// ReplaceOperation replaces the first document matching the filter
+type ReplaceOperation struct {
+	Filter      interface{}
+	Replacement interface{}
+	Upsert      bool // Inserts the replacement when no document matches
+}
+
+// DeleteOperation deletes the first, or every, document matching the filter
+type DeleteOperation struct {
+	Filter interface{}
+	Many   bool // Deletes every matching document
+}
+
+// BulkResult ...
+type BulkResult struct {
+	InsertedCount int64
+	MatchedCount  int64
+	ModifiedCount int64
+	DeletedCount  int64
+	UpsertedCount int64
+	UpsertedIDs   map[int64]interface{} // _id of upserted documents by operation index
+}
+
+// BulkWriteOperations runs mixed operations in a single bulk write, ordered bulks stop at the first failure
+// Collection rules apply to every operation, failed operations are returned as a *BulkError along with the result of the others
+func (c *Client) BulkWriteOperations(ctx context.Context, collection string, operations []WriteOperation, ordered bool) (res *BulkResult, err error) {
+	if len(operations) == 0 {
+		return &BulkResult{}, nil
+	}
+
+	// Checks tenant quota
+	err = c.checkQuota(ctx, true)
+	if err != nil {
+		return nil, err
+	}
+
+	// Applies collection rules
+	models := make([]mongo.WriteModel, len(operations))
+	for i, op := range operations {
+		if op == nil {
+			return nil, errors.New("nil bulk operation #" + strconv.Itoa(i))
+		}
+		models[i], err = op.model(ctx, c, collection)
+		if err != nil {
+			return nil, errors.New("bulk operation #" + strconv.Itoa(i) + " -> " + err.Error())
+		}
+	}
+
+	// Hits DB
+	result, err := c.collection(ctx, collection).BulkWrite(ctx, models, options.BulkWrite().SetOrdered(ordered))
+	if result != nil {
+		res = &BulkResult{
+			InsertedCount: result.InsertedCount,
+			MatchedCount:  result.MatchedCount,
+			ModifiedCount: result.ModifiedCount,
+			DeletedCount:  result.DeletedCount,
+			UpsertedCount: result.UpsertedCount,
+			UpsertedIDs:   result.UpsertedIDs,
+		}
+	}
+	if err != nil {
+		return res, newBulkError(err, 0)
+	}
+	c.addTenantStorage(ctx, insertedDocuments(models)...)
+
+	// Returns
+	return res, nil
+}
+
+// model ...
+func (o *InsertOperation) model(ctx context.Context, c *Client, collection string) (model mongo.WriteModel, err error) {
+	document, err := c.prepareInsert(collection, o.Document)
+	if err != nil {
+		return nil, err
+	}
+	return mongo.NewInsertOneModel().SetDocument(document), nil
+}
+
+// model ...
+func (o *UpdateOperation) model(ctx context.Context, c *Client, collection string) (model mongo.WriteModel, err error) {
+	err = c.checkAppendOnly(ctx, collection, "BulkWriteOperations")
+	if err != nil {
+		return nil, err
+	}
+	update, err := c.prepareUpdate(collection, o.Update)
+	if err != nil {
+		return nil, err
+	}
+	if o.Many {
+		return mongo.NewUpdateManyModel().SetFilter(o.Filter).SetUpdate(update).SetUpsert(o.Upsert), nil
+	}
+	return mongo.NewUpdateOneModel().SetFilter(o.Filter).SetUpdate(update).SetUpsert(o.Upsert), nil
+}
+
+// model ...
+func (o *ReplaceOperation) model(ctx context.Context, c *Client, collection string) (model mongo.WriteModel, err error) {
+	err = c.checkAppendOnly(ctx, collection, "BulkWriteOperations")
+	if err != nil {
+		return nil, err
+	}
+	replacement, err := c.prepareUpdate(collection, o.Replacement)
+	if err != nil {
+		return nil, err
+	}
+	return mongo.NewReplaceOneModel().SetFilter(o.Filter).SetReplacement(replacement).SetUpsert(o.Upsert), nil
+}
+
+// model ...
+func (o *DeleteOperation) model(ctx context.Context, c *Client, collection string) (model mongo.WriteModel, err error) {
+	err = c.checkAppendOnly(ctx, collection, "BulkWriteOperations")
+	if err != nil {
+		return nil, err
+	}
+	if o.Many {
+		return mongo.NewDeleteManyModel().SetFilter(o.Filter), nil
+	}
+	return mongo.NewDeleteOneModel().SetFilter(o.Filter), nil
+}
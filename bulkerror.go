@@ -0,0 +1,117 @@
+package mongodb
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// BulkFailure is the failure of a single document or operation of a batch write
+type BulkFailure struct {
+	Index   int    // Position of the document or operation in the batch
+	Code    int    // Server error code, e.g. 11000 for a duplicate key
+	Message string // Server error message
+}
+
+// BulkError is returned by batch writes when some documents or operations failed
+// Documents not listed in Failures were written, unless the batch was ordered and stopped at the first failure
+type BulkError struct {
+	Failures          []BulkFailure // Failed documents ordered by index
+	WriteConcernError string        // Write concern failure of the batch, if any
+	Err               error         // Underlying driver error
+}
+
+// Error ...
+func (e *BulkError) Error() string {
+	msgs := make([]string, 0, len(e.Failures)+1)
+	for _, f := range e.Failures {
+		msgs = append(msgs, fmt.Sprintf("#%d (code %d) %s", f.Index, f.Code, f.Message))
+	}
+	if e.WriteConcernError != "" {
+		msgs = append(msgs, "write concern: "+e.WriteConcernError)
+	}
+	return fmt.Sprintf("%d batch writes failed -> %s", len(e.Failures), strings.Join(msgs, "; "))
+}
+
+// Unwrap ...
+func (e *BulkError) Unwrap() error {
+	return e.Err
+}
+
+// FailedIndexes returns the positions of the failed documents in the batch
+func (e *BulkError) FailedIndexes() []int {
+	indexes := make([]int, 0, len(e.Failures))
+	for _, f := range e.Failures {
+		indexes = append(indexes, f.Index)
+	}
+	return indexes
+}
+
+// Failed returns the subset of the batch documents which failed, so only those can be retried
+// Documents must be the batch given to the failed write
+func (e *BulkError) Failed(documents []interface{}) []interface{} {
+	failed := make([]interface{}, 0, len(e.Failures))
+	for _, f := range e.Failures {
+		if f.Index >= 0 && f.Index < len(documents) {
+			failed = append(failed, documents[f.Index])
+		}
+	}
+	return failed
+}
+
+// written returns the documents of the batch which were written, an ordered batch stops at its first failure
+func (e *BulkError) written(documents []interface{}, ordered bool) []interface{} {
+	failed := make(map[int]bool, len(e.Failures))
+	for _, f := range e.Failures {
+		failed[f.Index] = true
+	}
+	res := make([]interface{}, 0, len(documents))
+	for i, document := range documents {
+		if failed[i] {
+			if ordered {
+				break
+			}
+			continue
+		}
+		res = append(res, document)
+	}
+	return res
+}
+
+// newBulkError converts driver bulk write errors into a *BulkError, other errors are returned as is
+// Offset shifts failure indexes when the batch was sent in several parts
+func newBulkError(err error, offset int) error {
+	// Reads failures
+	var failures []mongo.BulkWriteError
+	var writeConcern *mongo.WriteConcernError
+	var bulkErr mongo.BulkWriteException
+	var writeErr mongo.WriteException
+	switch {
+	case errors.As(err, &bulkErr):
+		failures = bulkErr.WriteErrors
+		writeConcern = bulkErr.WriteConcernError
+	case errors.As(err, &writeErr):
+		for _, we := range writeErr.WriteErrors {
+			failures = append(failures, mongo.BulkWriteError{WriteError: we})
+		}
+		writeConcern = writeErr.WriteConcernError
+	default:
+		return err
+	}
+
+	// Converts
+	res := &BulkError{Err: err}
+	for _, f := range failures {
+		res.Failures = append(res.Failures, BulkFailure{Index: f.Index + offset, Code: f.Code, Message: f.Message})
+	}
+	sort.Slice(res.Failures, func(i, j int) bool { return res.Failures[i].Index < res.Failures[j].Index })
+	if writeConcern != nil {
+		res.WriteConcernError = writeConcern.Message
+	}
+
+	// Returns
+	return res
+}
@@ -0,0 +1,204 @@
+package mongodb
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"go.mongodb.org/mongo-driver/event"
+)
+
+// Commands modifying the documents of the collection named by their first field
+var writeCommands = map[string]bool{"insert": true, "update": true, "delete": true, "findAndModify": true}
+
+// CacheOptions ...
+type CacheOptions struct {
+	UniqueKeys [][]string // Fields of the unique indexes lookups may filter on, e.g. [["_id"], ["tenant", "code"]]
+	TTL        int        // In milliseconds, How long a looked up document is served from the cache. (default is 1 minute)
+	MaxEntries int        // Cached lookups, the cache is emptied once full. (default is 10000)
+}
+
+// cacheEntry ...
+type cacheEntry struct {
+	value   interface{}
+	expires time.Time
+}
+
+// readCache caches lookups of a collection by unique key
+type readCache struct {
+	keys       map[string]bool // Sorted unique key fields joined by commas
+	ttl        time.Duration
+	maxEntries int
+	mu         sync.Mutex
+	generation uint64 // Incremented by every write, lookups started before a write are not cached
+	entries    map[string]cacheEntry
+}
+
+// SetReadCache enables CachedReadOne for the collection, nil disables it
+// Every write to the collection through this client empties its cache
+func (c *Client) SetReadCache(collection string, opts *CacheOptions) (err error) {
+	// Disables
+	if opts == nil {
+		c.configure(collection, func(s *collectionSettings) { s.cache = nil })
+		return nil
+	}
+
+	// Builds cache
+	cache := &readCache{keys: map[string]bool{}, ttl: time.Minute, maxEntries: 10000, entries: map[string]cacheEntry{}}
+	if opts.TTL != 0 {
+		cache.ttl = time.Duration(opts.TTL) * time.Millisecond
+	}
+	if opts.MaxEntries != 0 {
+		cache.maxEntries = opts.MaxEntries
+	}
+	for _, fields := range opts.UniqueKeys {
+		if len(fields) == 0 {
+			return errors.New("unique key without fields")
+		}
+		sorted := append([]string(nil), fields...)
+		sort.Strings(sorted)
+		cache.keys[strings.Join(sorted, ",")] = true
+	}
+	if len(cache.keys) == 0 {
+		return errors.New("read cache of collection " + collection + " needs at least one unique key")
+	}
+
+	// Registers
+	c.configure(collection, func(s *collectionSettings) { s.cache = cache })
+
+	// Returns
+	return nil
+}
+
+// CachedReadOne is ReadOne served from an in process cache
+// The query must only be equalities on the fields of a unique key declared with SetReadCache
+// The cached document is shared between callers and must not be modified
+func (c *Client) CachedReadOne(ctx context.Context, collection string, query interface{}) (res interface{}, err error) {
+	// Gets cache
+	s := c.settings(collection)
+	if s == nil || s.cache == nil {
+		return nil, errors.New("collection " + collection + " has no read cache")
+	}
+	cache := s.cache
+
+	// Builds key
+	key, err := cache.key(query)
+	if err != nil {
+		return nil, err
+	}
+
+	// Looks up
+	cache.mu.Lock()
+	entry, ok := cache.entries[key]
+	generation := cache.generation
+	cache.mu.Unlock()
+	if ok && time.Now().Before(entry.expires) {
+		return entry.value, nil
+	}
+
+	// Hits DB
+	res, err = c.ReadOne(ctx, collection, query)
+	if err != nil {
+		return nil, err
+	}
+
+	// Stores, unless a write happened meanwhile
+	cache.mu.Lock()
+	if cache.generation == generation {
+		if len(cache.entries) >= cache.maxEntries {
+			cache.entries = map[string]cacheEntry{}
+		}
+		cache.entries[key] = cacheEntry{value: res, expires: time.Now().Add(cache.ttl)}
+	}
+	cache.mu.Unlock()
+
+	// Returns
+	return res, nil
+}
+
+// key returns the cache key of a query, which must match a declared unique key
+func (r *readCache) key(query interface{}) (key string, err error) {
+	filter, err := toDoc(query)
+	if err != nil {
+		return "", err
+	}
+
+	// Reads equalities
+	fields := make([]string, 0, len(filter))
+	values := map[string]string{}
+	for _, e := range filter {
+		value, ok := equalityValue(e)
+		if !ok {
+			return "", errors.New("cached lookups only support equalities, got " + e.Key)
+		}
+		values[e.Key], err = idKey(value)
+		if err != nil {
+			return "", err
+		}
+		fields = append(fields, e.Key)
+	}
+	sort.Strings(fields)
+	if !r.keys[strings.Join(fields, ",")] {
+		return "", errors.New("fields " + strings.Join(fields, ",") + " are not a declared unique key")
+	}
+
+	// Builds key
+	var b strings.Builder
+	for _, field := range fields {
+		b.WriteString(field)
+		b.WriteByte(0)
+		b.WriteString(values[field])
+		b.WriteByte(0)
+	}
+	return b.String(), nil
+}
+
+// invalidate empties the cache
+func (r *readCache) invalidate() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.generation++
+	r.entries = map[string]cacheEntry{}
+}
+
+// cacheMonitor returns the command monitor emptying read caches on writes
+// Caches are emptied when the write starts and again when it ends, so lookups running meanwhile are not cached
+func (c *Client) cacheMonitor() *event.CommandMonitor {
+	var mu sync.Mutex
+	writes := map[int64]*readCache{}
+	invalidate := func(requestID int64) {
+		mu.Lock()
+		cache, ok := writes[requestID]
+		delete(writes, requestID)
+		mu.Unlock()
+		if ok {
+			cache.invalidate()
+		}
+	}
+	return &event.CommandMonitor{
+		Started: func(_ context.Context, e *event.CommandStartedEvent) {
+			if !writeCommands[e.CommandName] {
+				return
+			}
+			collection, _ := e.Command.Lookup(e.CommandName).StringValueOK()
+			s := c.settings(collection)
+			if s == nil || s.cache == nil {
+				return
+			}
+			s.cache.invalidate()
+			mu.Lock()
+			writes[e.RequestID] = s.cache
+			mu.Unlock()
+		},
+		Succeeded: func(_ context.Context, e *event.CommandSucceededEvent) {
+			invalidate(e.RequestID)
+		},
+		Failed: func(_ context.Context, e *event.CommandFailedEvent) {
+			invalidate(e.RequestID)
+		},
+	}
+}
@@ -0,0 +1,120 @@
+package mongodb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// Topologies of a deployment
+const (
+	TopologyStandalone = "standalone"
+	TopologyReplicaSet = "replicaSet"
+	TopologySharded    = "sharded"
+)
+
+// Capabilities of a deployment
+const (
+	CapabilityTransactions        = "transactions"
+	CapabilityChangeStreams       = "change streams"
+	CapabilityTimeSeries          = "time series collections"
+	CapabilityQueryableEncryption = "queryable encryption"
+)
+
+// ServerCapabilities are the features of the deployment, detected at connect time
+type ServerCapabilities struct {
+	Version             string // Server version, e.g. "6.0.4"
+	Topology            string // TopologyStandalone, TopologyReplicaSet or TopologySharded
+	Transactions        bool   // Multi-document transactions, replica sets 4.0+ and sharded clusters 4.2+
+	ChangeStreams       bool   // Change streams, replica sets and sharded clusters 3.6+
+	TimeSeries          bool   // Time series collections, 5.0+
+	QueryableEncryption bool   // Queryable encryption, replica sets and sharded clusters 7.0+
+}
+
+// CapabilityError is returned when a feature is used against a deployment lacking the capability it requires
+type CapabilityError struct {
+	Feature    string // Library feature used, e.g. "WatchCollections"
+	Capability string // Missing capability, e.g. CapabilityChangeStreams
+	Version    string
+	Topology   string
+}
+
+// Error ...
+func (e *CapabilityError) Error() string {
+	return fmt.Sprintf("%s requires %s, unsupported by %s server %s", e.Feature, e.Capability, e.Topology, e.Version)
+}
+
+// ServerCapabilities returns the capabilities of the deployment detected at connect time
+func (c *Client) ServerCapabilities() ServerCapabilities {
+	if c.capabilities == nil {
+		return ServerCapabilities{}
+	}
+	return *c.capabilities
+}
+
+// requireCapability returns a *CapabilityError when the deployment lacks the capability the feature requires
+func (c *Client) requireCapability(feature string, capability string) (err error) {
+	if c.capabilities == nil {
+		return nil
+	}
+	supported := map[string]bool{
+		CapabilityTransactions:        c.capabilities.Transactions,
+		CapabilityChangeStreams:       c.capabilities.ChangeStreams,
+		CapabilityTimeSeries:          c.capabilities.TimeSeries,
+		CapabilityQueryableEncryption: c.capabilities.QueryableEncryption,
+	}
+	if supported[capability] {
+		return nil
+	}
+	return &CapabilityError{Feature: feature, Capability: capability, Version: c.capabilities.Version, Topology: c.capabilities.Topology}
+}
+
+// detectCapabilities reads the version and topology of the deployment
+func detectCapabilities(ctx context.Context, client *mongo.Client) (capabilities *ServerCapabilities, err error) {
+	admin := client.Database("admin")
+
+	// Reads version
+	var info struct {
+		Version string `bson:"version"`
+	}
+	err = admin.RunCommand(ctx, bson.D{{Key: "buildInfo", Value: 1}}).Decode(&info)
+	if err != nil {
+		return nil, errors.New("reading server version -> " + err.Error())
+	}
+
+	// Reads topology, hello requires 4.4.2+
+	var hello struct {
+		SetName string `bson:"setName"`
+		Msg     string `bson:"msg"`
+	}
+	err = admin.RunCommand(ctx, bson.D{{Key: "hello", Value: 1}}).Decode(&hello)
+	if err != nil {
+		err = admin.RunCommand(ctx, bson.D{{Key: "isMaster", Value: 1}}).Decode(&hello)
+		if err != nil {
+			return nil, errors.New("reading server topology -> " + err.Error())
+		}
+	}
+	capabilities = &ServerCapabilities{Version: info.Version, Topology: TopologyStandalone}
+	switch {
+	case hello.Msg == "isdbgrid":
+		capabilities.Topology = TopologySharded
+	case hello.SetName != "":
+		capabilities.Topology = TopologyReplicaSet
+	}
+
+	// Derives capabilities
+	atLeast := func(version string) bool {
+		return compareVersions(info.Version, version) >= 0
+	}
+	distributed := capabilities.Topology != TopologyStandalone
+	capabilities.Transactions = (capabilities.Topology == TopologyReplicaSet && atLeast("4.0")) || (capabilities.Topology == TopologySharded && atLeast("4.2"))
+	capabilities.ChangeStreams = distributed && atLeast("3.6")
+	capabilities.TimeSeries = atLeast("5.0")
+	capabilities.QueryableEncryption = distributed && atLeast("7.0")
+
+	// Returns
+	return capabilities, nil
+}
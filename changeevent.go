@@ -0,0 +1,136 @@
+package mongodb
+
+import (
+	"strconv"
+	"strings"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Namespace is the database and collection of a change event
+type Namespace struct {
+	Database   string `bson:"db"`
+	Collection string `bson:"coll"`
+}
+
+// UpdateDescription describes the fields changed by an update event
+type UpdateDescription[T any] struct {
+	Updated         *T       // Updated fields decoded into T, fields updated through an array index are only in UpdatedFields
+	UpdatedFields   bson.M   // New values by dotted path
+	RemovedFields   []string // Dotted paths of the removed fields
+	TruncatedArrays []string // Dotted paths of the arrays shortened by the update
+}
+
+// ChangeEvent is a change stream event whose documents are decoded into T
+type ChangeEvent[T any] struct {
+	ResumeToken       bson.Raw              // Token resuming the stream after this event
+	OperationType     string                // OpInsert, OpUpdate, OpReplace, OpDelete...
+	Namespace         Namespace             // Collection of the changed document
+	DocumentKey       bson.M                // _id and shard key fields of the changed document
+	FullDocument      *T                    // Document after the change, nil for deletes and for updates without the updateLookup option
+	UpdateDescription *UpdateDescription[T] // Changed fields, nil unless OperationType is OpUpdate
+	ClusterTime       primitive.Timestamp   // Time of the change in the oplog
+}
+
+// DecodeChangeEvent decodes a raw change stream event, e.g. the Current document of a driver change stream
+func DecodeChangeEvent[T any](raw bson.Raw) (event *ChangeEvent[T], err error) {
+	// Decodes envelope
+	var envelope struct {
+		ID                bson.Raw            `bson:"_id"`
+		OperationType     string              `bson:"operationType"`
+		Namespace         Namespace           `bson:"ns"`
+		DocumentKey       bson.M              `bson:"documentKey"`
+		FullDocument      bson.Raw            `bson:"fullDocument"`
+		ClusterTime       primitive.Timestamp `bson:"clusterTime"`
+		UpdateDescription *struct {
+			UpdatedFields   bson.D   `bson:"updatedFields"`
+			RemovedFields   []string `bson:"removedFields"`
+			TruncatedArrays []struct {
+				Field string `bson:"field"`
+			} `bson:"truncatedArrays"`
+		} `bson:"updateDescription"`
+	}
+	err = bson.Unmarshal(raw, &envelope)
+	if err != nil {
+		return nil, err
+	}
+	event = &ChangeEvent[T]{
+		ResumeToken:   envelope.ID,
+		OperationType: envelope.OperationType,
+		Namespace:     envelope.Namespace,
+		DocumentKey:   envelope.DocumentKey,
+		ClusterTime:   envelope.ClusterTime,
+	}
+
+	// Decodes full document, it is null when the document no longer exists
+	if len(envelope.FullDocument) != 0 {
+		event.FullDocument = new(T)
+		err = bson.Unmarshal(envelope.FullDocument, event.FullDocument)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// Decodes update description
+	if d := envelope.UpdateDescription; d != nil {
+		desc := &UpdateDescription[T]{UpdatedFields: bson.M{}, RemovedFields: d.RemovedFields}
+		for _, e := range d.UpdatedFields {
+			desc.UpdatedFields[e.Key] = e.Value
+		}
+		for _, t := range d.TruncatedArrays {
+			desc.TruncatedArrays = append(desc.TruncatedArrays, t.Field)
+		}
+		updated, err := bson.Marshal(expandPaths(d.UpdatedFields))
+		if err != nil {
+			return nil, err
+		}
+		desc.Updated = new(T)
+		err = bson.Unmarshal(updated, desc.Updated)
+		if err != nil {
+			return nil, err
+		}
+		event.UpdateDescription = desc
+	}
+
+	// Returns
+	return event, nil
+}
+
+// expandPaths turns dotted paths into embedded documents, paths through an array index are skipped
+func expandPaths(fields bson.D) bson.D {
+	res := bson.D{}
+	for _, e := range fields {
+		parts := strings.Split(e.Key, ".")
+		skip := false
+		for _, part := range parts[1:] {
+			if _, err := strconv.Atoi(part); err == nil {
+				skip = true
+			}
+		}
+		if !skip {
+			res = setPath(res, parts, e.Value)
+		}
+	}
+	return res
+}
+
+// setPath ...
+func setPath(doc bson.D, parts []string, value interface{}) bson.D {
+	for i, e := range doc {
+		if e.Key != parts[0] {
+			continue
+		}
+		if len(parts) == 1 {
+			doc[i].Value = value
+			return doc
+		}
+		child, _ := e.Value.(bson.D)
+		doc[i].Value = setPath(child, parts[1:], value)
+		return doc
+	}
+	if len(parts) == 1 {
+		return append(doc, bson.E{Key: parts[0], Value: value})
+	}
+	return append(doc, bson.E{Key: parts[0], Value: setPath(bson.D{}, parts[1:], value)})
+}
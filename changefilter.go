@@ -0,0 +1,169 @@
+package mongodb
+
+import (
+	"strings"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// Change stream operation types
+const (
+	OpInsert       = "insert"
+	OpUpdate       = "update"
+	OpReplace      = "replace"
+	OpDelete       = "delete"
+	OpDrop         = "drop"
+	OpRename       = "rename"
+	OpDropDatabase = "dropDatabase"
+	OpInvalidate   = "invalidate"
+)
+
+// ChangeFilter builds the $match and $project stages of a change stream pipeline
+// Conditions are combined with AND, e.g. NewChangeFilter().Operations(OpUpdate).FieldsUpdated("status")
+type ChangeFilter struct {
+	clauses    bson.A
+	projection []string
+	err        error
+}
+
+// NewChangeFilter ...
+func NewChangeFilter() *ChangeFilter {
+	return &ChangeFilter{}
+}
+
+// Operations matches events of the given operation types
+func (f *ChangeFilter) Operations(types ...string) *ChangeFilter {
+	values := bson.A{}
+	for _, t := range types {
+		values = append(values, t)
+	}
+	f.clauses = append(f.clauses, bson.D{{Key: "operationType", Value: bson.D{{Key: "$in", Value: values}}}})
+	return f
+}
+
+// FieldsUpdated matches update events setting or removing any of the fields, a nested field or a parent of it
+// Replace events carry no update description and never match
+func (f *ChangeFilter) FieldsUpdated(fields ...string) *ChangeFilter {
+	or := bson.A{}
+	for _, field := range fields {
+		// Updated fields are keyed by dotted paths so they are matched by key
+		key := "$$this.k"
+		cond := bson.D{{Key: "$or", Value: bson.A{
+			bson.D{{Key: "$eq", Value: bson.A{key, field}}},
+			bson.D{{Key: "$eq", Value: bson.A{bson.D{{Key: "$indexOfCP", Value: bson.A{key, field + "."}}}, 0}}},
+			bson.D{{Key: "$eq", Value: bson.A{bson.D{{Key: "$indexOfCP", Value: bson.A{field, bson.D{{Key: "$concat", Value: bson.A{key, "."}}}}}}, 0}}},
+		}}}
+		updated := bson.D{{Key: "$filter", Value: bson.D{
+			{Key: "input", Value: bson.D{{Key: "$objectToArray", Value: bson.D{{Key: "$ifNull", Value: bson.A{"$updateDescription.updatedFields", bson.D{}}}}}}},
+			{Key: "cond", Value: cond},
+		}}}
+		or = append(or, bson.D{{Key: "$expr", Value: bson.D{{Key: "$gt", Value: bson.A{bson.D{{Key: "$size", Value: updated}}, 0}}}}})
+		or = append(or, bson.D{{Key: "updateDescription.removedFields", Value: field}})
+	}
+	f.clauses = append(f.clauses, bson.D{
+		{Key: "operationType", Value: OpUpdate},
+		{Key: "$or", Value: or},
+	})
+	return f
+}
+
+// DocumentKey matches events of documents whose key field (_id or a shard key field) equals the value
+func (f *ChangeFilter) DocumentKey(field string, value interface{}) *ChangeFilter {
+	f.clauses = append(f.clauses, bson.D{{Key: "documentKey." + field, Value: value}})
+	return f
+}
+
+// DocumentIDs matches events of the documents having any of the ids
+func (f *ChangeFilter) DocumentIDs(ids ...interface{}) *ChangeFilter {
+	values := bson.A{}
+	for _, id := range ids {
+		values = append(values, id)
+	}
+	f.clauses = append(f.clauses, bson.D{{Key: "documentKey._id", Value: bson.D{{Key: "$in", Value: values}}}})
+	return f
+}
+
+// FullDocument matches events whose full document matches the query filter
+// Update events only carry the full document when the stream is opened with the updateLookup option
+func (f *ChangeFilter) FullDocument(filter interface{}) *ChangeFilter {
+	doc, err := toDoc(filter)
+	if err != nil {
+		if f.err == nil {
+			f.err = err
+		}
+		return f
+	}
+	f.clauses = append(f.clauses, prefixFilter(doc, "fullDocument."))
+	return f
+}
+
+// Project limits the full document of the events to the fields, event metadata is always kept
+func (f *ChangeFilter) Project(fields ...string) *ChangeFilter {
+	f.projection = append(f.projection, fields...)
+	return f
+}
+
+// Match returns the $match stage, an empty match when no condition was added
+func (f *ChangeFilter) Match() (stage bson.D, err error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	match := bson.D{}
+	if len(f.clauses) == 1 {
+		match = f.clauses[0].(bson.D)
+	} else if len(f.clauses) > 1 {
+		match = bson.D{{Key: "$and", Value: f.clauses}}
+	}
+	return bson.D{{Key: "$match", Value: match}}, nil
+}
+
+// Pipeline returns the change stream pipeline of the filter
+func (f *ChangeFilter) Pipeline() (pipeline mongo.Pipeline, err error) {
+	// Adds match
+	match, err := f.Match()
+	if err != nil {
+		return nil, err
+	}
+	pipeline = mongo.Pipeline{match}
+
+	// Adds projection
+	if len(f.projection) != 0 {
+		projection := bson.D{}
+		for _, field := range []string{"operationType", "ns", "to", "documentKey", "updateDescription", "clusterTime", "txnNumber", "lsid"} {
+			projection = append(projection, bson.E{Key: field, Value: 1})
+		}
+		for _, field := range f.projection {
+			projection = append(projection, bson.E{Key: "fullDocument." + field, Value: 1})
+		}
+		pipeline = append(pipeline, bson.D{{Key: "$project", Value: projection}})
+	}
+
+	// Returns
+	return pipeline, nil
+}
+
+// prefixFilter prefixes the field names of a query filter, logical operators are descended into
+func prefixFilter(filter bson.D, prefix string) bson.D {
+	res := make(bson.D, 0, len(filter))
+	for _, e := range filter {
+		switch {
+		case e.Key == "$and" || e.Key == "$or" || e.Key == "$nor":
+			clauses := bson.A{}
+			if values, ok := e.Value.(bson.A); ok {
+				for _, v := range values {
+					if doc, ok := v.(bson.D); ok {
+						v = prefixFilter(doc, prefix)
+					}
+					clauses = append(clauses, v)
+				}
+			}
+			res = append(res, bson.E{Key: e.Key, Value: clauses})
+		case strings.HasPrefix(e.Key, "$"):
+			res = append(res, e)
+		default:
+			res = append(res, bson.E{Key: prefix + e.Key, Value: e.Value})
+		}
+	}
+	return res
+}
@@ -0,0 +1,155 @@
+package mongodb
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"hash"
+	"strings"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// Default field holding the checksum of a document
+const defaultChecksumField = "_checksum"
+
+// ChecksumOptions ...
+type ChecksumOptions struct {
+	Field      string                   // Field storing the hex checksum. (default is "_checksum")
+	Key        []byte                   // HMAC-SHA256 key, so the checksum can not be recomputed by whoever alters the documents. (default is nil, plain SHA-256)
+	OnMismatch func(err *ChecksumError) // Receives documents failing verification, which are still returned. (default is nil, the read fails with the *ChecksumError)
+}
+
+// ChecksumError reports a document whose content does not match its stored checksum
+type ChecksumError struct {
+	Collection string
+	ID         interface{}
+	Missing    bool // The document has no checksum at all
+}
+
+// Error ...
+func (e *ChecksumError) Error() string {
+	if e.Missing {
+		return fmt.Sprintf("document %v of collection %s has no checksum", e.ID, e.Collection)
+	}
+	return fmt.Sprintf("document %v of collection %s does not match its checksum, it was altered out of band or corrupted", e.ID, e.Collection)
+}
+
+// documentChecksum signs and verifies the documents of a collection
+type documentChecksum struct {
+	field      string
+	key        []byte
+	onMismatch func(err *ChecksumError)
+}
+
+// SetChecksum stores a hash of the content of every document written to the collection and verifies it on read, nil disables it
+// The hash covers every field but _id and the checksum itself, as stored after the other collection rules
+// Inserts and whole document replacements (ReplaceOne, SaveByID) are signed, operator updates are rejected as they would invalidate the checksum
+// ReadOne, Read, CachedReadOne, ReadOneRaw, ReadRaw, GetOrCreate, FindOneAndUpdate and ResolveDBRefs verify, projected reads can not be verified
+func (c *Client) SetChecksum(collection string, opts *ChecksumOptions) {
+	// Disables
+	if opts == nil {
+		c.configure(collection, func(s *collectionSettings) { s.checksum = nil })
+		return
+	}
+
+	// Registers
+	checksum := &documentChecksum{field: defaultChecksumField, key: opts.Key, onMismatch: opts.OnMismatch}
+	if opts.Field != "" {
+		checksum.field = opts.Field
+	}
+	c.configure(collection, func(s *collectionSettings) { s.checksum = checksum })
+}
+
+// sign returns the document with its checksum field set
+func (d *documentChecksum) sign(document interface{}) (res interface{}, err error) {
+	// Converts
+	doc, err := toDoc(document)
+	if err != nil {
+		return nil, err
+	}
+
+	// Hashes
+	sum, err := d.sum(doc)
+	if err != nil {
+		return nil, err
+	}
+
+	// Returns
+	return setPath(doc, []string{d.field}, sum), nil
+}
+
+// signUpdate signs a replacement document, operator and pipeline updates are rejected
+func (d *documentChecksum) signUpdate(collection string, update interface{}) (res interface{}, err error) {
+	if !isPipeline(update) {
+		doc, err := toDoc(update)
+		if err != nil {
+			return nil, err
+		}
+		if len(doc) == 0 || !strings.HasPrefix(doc[0].Key, "$") {
+			return d.sign(doc)
+		}
+	}
+	return nil, errors.New("collection " + collection + " has checksums, its documents can only be replaced as a whole")
+}
+
+// sum returns the hex hash of the document, _id and the checksum field excluded
+func (d *documentChecksum) sum(doc bson.D) (sum string, err error) {
+	content := make(bson.D, 0, len(doc))
+	for _, e := range doc {
+		if e.Key != "_id" && e.Key != d.field {
+			content = append(content, e)
+		}
+	}
+	raw, err := bson.Marshal(content)
+	if err != nil {
+		return "", err
+	}
+	var h hash.Hash
+	if d.key != nil {
+		h = hmac.New(sha256.New, d.key)
+	} else {
+		h = sha256.New()
+	}
+	h.Write(raw)
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// verifyChecksums checks the documents read from the collection, mismatches go to OnMismatch or fail the read
+func (c *Client) verifyChecksums(collection string, docs ...interface{}) (err error) {
+	s := c.settings(collection)
+	if s == nil || s.checksum == nil {
+		return nil
+	}
+	d := s.checksum
+	for _, document := range docs {
+		doc, ok := document.(bson.D)
+		if !ok {
+			continue
+		}
+
+		// Compares
+		id, _ := getPath(doc, []string{"_id"})
+		stored, ok := getPath(doc, []string{d.field})
+		sum, err := d.sum(doc)
+		if err != nil {
+			return err
+		}
+		if ok && stored == sum {
+			continue
+		}
+
+		// Reports
+		mismatch := &ChecksumError{Collection: collection, ID: id, Missing: !ok}
+		if d.onMismatch == nil {
+			return mismatch
+		}
+		err = safeCall(c.panicHandler, "ChecksumOptions.OnMismatch", func() { d.onMismatch(mismatch) })
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
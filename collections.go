@@ -0,0 +1,166 @@
+package mongodb
+
+import "go.mongodb.org/mongo-driver/bson"
+
+// collectionSettings holds the client side rules registered for a collection
+type collectionSettings struct {
+	schema      *jsonSchema        // Client side JSON schema checked before writes
+	registry    *registrySchema    // Schema fetched from a schema registry, checked before writes
+	idGenerator IDGenerator        // Generator of missing _id values
+	cache       *readCache         // Cache of lookups by unique key
+	compression *fieldCompression  // Compressed large fields
+	enums       map[string]EnumSet // Enum sets of fields by dotted path
+	appendOnly  bool               // Updates and deletes require privilege
+	checksum    *documentChecksum  // Content hash stored on write, verified on read
+	defaultSort bson.D             // Sort of reads, with an _id tiebreaker
+	querySchema *QuerySchema       // Fields exposed to the query language
+}
+
+// settings returns the rules registered for the collection, nil when none are registered
+func (c *Client) settings(collection string) *collectionSettings {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	// Returns
+	return c.collections[collection]
+}
+
+// configure updates the rules of the collection
+// Settings are copied on write so readers always see a consistent snapshot
+func (c *Client) configure(collection string, update func(s *collectionSettings)) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	// Copies current settings
+	s := &collectionSettings{}
+	if current, ok := c.collections[collection]; ok {
+		*s = *current
+	}
+	update(s)
+
+	// Stores
+	if c.collections == nil {
+		c.collections = map[string]*collectionSettings{}
+	}
+	c.collections[collection] = s
+}
+
+// prepareInsert applies the collection rules to a document before it is inserted
+func (c *Client) prepareInsert(collection string, document interface{}) (res interface{}, err error) {
+	// Gets rules
+	s := c.settings(collection)
+
+	// Generates id
+	if generator := c.idGenerator(s); generator != nil {
+		document, err = withID(document, generator.NewID)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if s == nil {
+		return document, nil
+	}
+
+	// Validates
+	err = s.validate(collection, document)
+	if err != nil {
+		return nil, err
+	}
+
+	// Compresses fields
+	if s.compression != nil {
+		document, err = s.compression.compressDocument(document)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// Signs
+	if s.checksum != nil {
+		document, err = s.checksum.sign(document)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// Returns
+	return document, nil
+}
+
+// validate checks the document against the schema and enum rules of the collection
+func (s *collectionSettings) validate(collection string, document interface{}) (err error) {
+	if s == nil {
+		return nil
+	}
+
+	// Validates schema
+	if schema := s.currentSchema(); schema != nil {
+		err = schema.validateDocument(collection, document)
+		if err != nil {
+			return err
+		}
+	}
+
+	// Validates enums
+	if len(s.enums) != 0 {
+		err = validateEnums(collection, s.enums, document)
+		if err != nil {
+			return err
+		}
+	}
+
+	// Returns
+	return nil
+}
+
+// prepareUpdate applies the collection rules to an update document before it is sent
+func (c *Client) prepareUpdate(collection string, update interface{}) (res interface{}, err error) {
+	// Gets rules
+	s := c.settings(collection)
+	if s == nil {
+		return update, nil
+	}
+
+	// Validates schema
+	if schema := s.currentSchema(); schema != nil {
+		err = schema.validateUpdate(collection, update)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// Validates enums
+	if len(s.enums) != 0 {
+		err = validateEnumUpdate(collection, s.enums, update)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// Compresses fields
+	if s.compression != nil {
+		update, err = s.compression.compressUpdate(update)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// Signs
+	if s.checksum != nil {
+		update, err = s.checksum.signUpdate(collection, update)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// Returns
+	return update, nil
+}
+
+// currentSchema returns the schema of the collection, nil when none is set
+func (s *collectionSettings) currentSchema() *jsonSchema {
+	if s.registry != nil {
+		return s.registry.current()
+	}
+	return s.schema
+}
@@ -0,0 +1,326 @@
+package mongodb
+
+import (
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"io"
+	"strings"
+
+	"github.com/klauspost/compress/zstd"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/bsontype"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Compression algorithms of compressed fields
+const (
+	CompressionGzip = "gzip"
+	CompressionZstd = "zstd"
+)
+
+// Default size in bytes from which field values are compressed
+const defaultCompressionMinSize = 1024
+
+// Fields of the marker subdocument replacing a compressed value
+const (
+	compressedAlgorithmField = "__compressed"
+	compressedTypeField      = "__type"
+	compressedDataField      = "__data"
+)
+
+// Original types of compressed values
+const (
+	compressedString = "string"
+	compressedBinary = "binary"
+)
+
+// Shared zstd codecs, safe for concurrent EncodeAll and DecodeAll calls
+var (
+	zstdEncoder, _ = zstd.NewWriter(nil)
+	zstdDecoder, _ = zstd.NewReader(nil)
+)
+
+// FieldCompressionOptions ...
+type FieldCompressionOptions struct {
+	Algorithm string // CompressionGzip or CompressionZstd. (default is CompressionZstd)
+	MinSize   int    // In bytes, Smaller values are stored as is. (default is 1024)
+}
+
+// fieldCompression compresses designated string and binary fields of a collection
+type fieldCompression struct {
+	fields    map[string]bool // Dotted paths
+	algorithm string
+	minSize   int
+}
+
+// SetFieldCompression transparently compresses the string or binary fields of the collection on write and decompresses them on read
+// Compressed values are stored as a {__compressed, __type, __data} subdocument, so they can not be filtered or indexed on
+// Inserts, updates setting whole fields with $set or $setOnInsert, replacements, ReadOne, Read, ReadWithProjection, CachedReadOne,
+// GetOrCreate, FindOneAndUpdate and ResolveDBRefs are covered
+// Empty fields disables compression of new writes, documents already compressed are still decompressed
+func (c *Client) SetFieldCompression(collection string, fields []string, opts *FieldCompressionOptions) (err error) {
+	if opts == nil {
+		opts = &FieldCompressionOptions{}
+	}
+
+	// Builds compression
+	compression := &fieldCompression{fields: map[string]bool{}, algorithm: CompressionZstd, minSize: defaultCompressionMinSize}
+	switch opts.Algorithm {
+	case "", CompressionZstd:
+	case CompressionGzip:
+		compression.algorithm = CompressionGzip
+	default:
+		return errors.New("unknown compression algorithm " + opts.Algorithm)
+	}
+	if opts.MinSize != 0 {
+		compression.minSize = opts.MinSize
+	}
+	for _, field := range fields {
+		if field == "" || field == "_id" {
+			return errors.New("field " + field + " can not be compressed")
+		}
+		compression.fields[field] = true
+	}
+
+	// Registers
+	c.configure(collection, func(s *collectionSettings) { s.compression = compression })
+
+	// Returns
+	return nil
+}
+
+// compressDocument compresses the designated fields of a document to insert or replace
+func (f *fieldCompression) compressDocument(document interface{}) (res interface{}, err error) {
+	if len(f.fields) == 0 {
+		return document, nil
+	}
+
+	// Converts
+	doc, err := toDoc(document)
+	if err != nil {
+		return nil, err
+	}
+
+	// Compresses
+	for field := range f.fields {
+		parts := strings.Split(field, ".")
+		value, ok := getPath(doc, parts)
+		if !ok {
+			continue
+		}
+		compressed, ok, err := f.compress(value)
+		if err != nil {
+			return nil, errors.New("compressing field " + field + " -> " + err.Error())
+		}
+		if ok {
+			doc = setPath(doc, parts, compressed)
+		}
+	}
+
+	// Returns
+	return doc, nil
+}
+
+// compressUpdate compresses the designated fields set by an update document
+func (f *fieldCompression) compressUpdate(update interface{}) (res interface{}, err error) {
+	if len(f.fields) == 0 || isPipeline(update) {
+		return update, nil
+	}
+
+	// Converts
+	doc, err := toDoc(update)
+	if err != nil {
+		return nil, err
+	}
+
+	// Checks replacement
+	if len(doc) == 0 || !strings.HasPrefix(doc[0].Key, "$") {
+		return f.compressDocument(doc)
+	}
+
+	// Compresses set values
+	for _, e := range doc {
+		if e.Key != "$set" && e.Key != "$setOnInsert" {
+			continue
+		}
+		fields, ok := e.Value.(bson.D)
+		if !ok {
+			continue
+		}
+		for j, field := range fields {
+			if f.fields[field.Key] {
+				compressed, ok, err := f.compress(field.Value)
+				if err != nil {
+					return nil, errors.New("compressing field " + field.Key + " -> " + err.Error())
+				}
+				if ok {
+					fields[j].Value = compressed
+				}
+				continue
+			}
+			// Whole embedded documents holding designated fields
+			if sub, ok := field.Value.(bson.D); ok {
+				nested := &fieldCompression{fields: map[string]bool{}, algorithm: f.algorithm, minSize: f.minSize}
+				for path := range f.fields {
+					if strings.HasPrefix(path, field.Key+".") {
+						nested.fields[strings.TrimPrefix(path, field.Key+".")] = true
+					}
+				}
+				compressed, err := nested.compressDocument(sub)
+				if err != nil {
+					return nil, err
+				}
+				fields[j].Value = compressed
+			}
+		}
+	}
+
+	// Returns
+	return doc, nil
+}
+
+// compress returns the marker subdocument of a large enough string or binary value
+func (f *fieldCompression) compress(value interface{}) (res bson.D, ok bool, err error) {
+	// Gets bytes
+	var data []byte
+	var kind string
+	switch v := value.(type) {
+	case string:
+		data, kind = []byte(v), compressedString
+	case []byte:
+		data, kind = v, compressedBinary
+	case primitive.Binary:
+		if v.Subtype != bsontype.BinaryGeneric {
+			return nil, false, nil
+		}
+		data, kind = v.Data, compressedBinary
+	default:
+		return nil, false, nil
+	}
+	if len(data) < f.minSize {
+		return nil, false, nil
+	}
+
+	// Compresses
+	var compressed []byte
+	switch f.algorithm {
+	case CompressionGzip:
+		var b bytes.Buffer
+		w := gzip.NewWriter(&b)
+		_, err = w.Write(data)
+		if err == nil {
+			err = w.Close()
+		}
+		if err != nil {
+			return nil, false, err
+		}
+		compressed = b.Bytes()
+	default:
+		compressed = zstdEncoder.EncodeAll(data, nil)
+	}
+
+	// Returns
+	return bson.D{
+		{Key: compressedAlgorithmField, Value: f.algorithm},
+		{Key: compressedTypeField, Value: kind},
+		{Key: compressedDataField, Value: primitive.Binary{Data: compressed}},
+	}, true, nil
+}
+
+// decompressResults restores the compressed fields of documents read from the collection
+func (c *Client) decompressResults(collection string, docs ...interface{}) (err error) {
+	s := c.settings(collection)
+	if s == nil || s.compression == nil {
+		return nil
+	}
+	for _, doc := range docs {
+		_, err = decompressValue(doc)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// decompressValue restores the compressed values within a value, embedded documents and arrays are updated in place
+func decompressValue(value interface{}) (res interface{}, err error) {
+	switch v := value.(type) {
+	case bson.D:
+		original, ok, err := decompress(v)
+		if err != nil || ok {
+			return original, err
+		}
+		for i, e := range v {
+			v[i].Value, err = decompressValue(e.Value)
+			if err != nil {
+				return nil, errors.New("decompressing field " + e.Key + " -> " + err.Error())
+			}
+		}
+	case bson.A:
+		for i, item := range v {
+			v[i], err = decompressValue(item)
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+	return value, nil
+}
+
+// decompress returns the original value of a marker subdocument
+func decompress(value interface{}) (res interface{}, ok bool, err error) {
+	// Reads marker
+	marker, isDoc := value.(bson.D)
+	if !isDoc || len(marker) != 3 || marker[0].Key != compressedAlgorithmField {
+		return nil, false, nil
+	}
+	algorithm, _ := marker[0].Value.(string)
+	kind, _ := marker[1].Value.(string)
+	compressed, _ := marker[2].Value.(primitive.Binary)
+
+	// Decompresses
+	var data []byte
+	switch algorithm {
+	case CompressionGzip:
+		r, err := gzip.NewReader(bytes.NewReader(compressed.Data))
+		if err != nil {
+			return nil, false, err
+		}
+		data, err = io.ReadAll(r)
+		if err != nil {
+			return nil, false, err
+		}
+	case CompressionZstd:
+		data, err = zstdDecoder.DecodeAll(compressed.Data, nil)
+		if err != nil {
+			return nil, false, err
+		}
+	default:
+		return nil, false, errors.New("unknown compression algorithm " + algorithm)
+	}
+
+	// Returns
+	if kind == compressedString {
+		return string(data), true, nil
+	}
+	return primitive.Binary{Data: data}, true, nil
+}
+
+// getPath returns the value at the dotted path of the document
+func getPath(doc bson.D, parts []string) (value interface{}, ok bool) {
+	for _, e := range doc {
+		if e.Key != parts[0] {
+			continue
+		}
+		if len(parts) == 1 {
+			return e.Value, true
+		}
+		child, isDoc := e.Value.(bson.D)
+		if !isDoc {
+			return nil, false
+		}
+		return getPath(child, parts[1:])
+	}
+	return nil, false
+}
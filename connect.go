@@ -6,6 +6,7 @@ import (
 	"errors"
 	"time"
 
+	"go.mongodb.org/mongo-driver/event"
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
 	"go.mongodb.org/mongo-driver/mongo/readconcern"
@@ -15,14 +16,27 @@ import (
 
 // Config contains all properties required for creating a connection
 type Config struct {
-	Hosts       []string    // Database server hosts
-	AuthEnabled bool        // Enables auth to required user & password to establish connection
-	User        string      // Db Username for authentication
-	Password    string      // Db password for authentication
-	AuthSource  string      // The name of database to use for authentication
-	TLSEnabled  bool        // TLS to encrypt all of mongodb's network traffic
-	Database    string      // Db name
-	Connection  *Connection // More client options
+	Hosts            []string               // Database server hosts
+	AuthEnabled      bool                   // Enables auth to required user & password to establish connection
+	User             string                 // Db Username for authentication
+	Password         string                 // Db password for authentication
+	AuthSource       string                 // The name of database to use for authentication
+	TLSEnabled       bool                   // TLS to encrypt all of mongodb's network traffic
+	Database         string                 // Db name
+	Connection       *Connection            // More client options
+	TimeZone         string                 // IANA time zone name, e.g. "Asia/Kolkata", time.Time values are decoded into. Times are always stored as UTC. (default is UTC)
+	IDGenerator      IDGenerator            // Generates the _id of inserted documents having none, e.g. &UUIDv7Generator{}. (default is nil, ObjectIDs generated by the driver)
+	FetchParallelism int                    // Maximum number of requests FetchMany runs concurrently. (default is 4)
+	ReadDistribution *ReadDistribution      // Round robins reads across tagged secondaries with ejection of failing nodes (default is nil, the driver selects servers)
+	Partitions       []*Partition           // Separate connection pools for heavy collections or operation classes (default is nil, a single pool)
+	Aggregation      *AggregationGuardrails // Time and memory guardrails of aggregation pipelines (default is nil, none)
+	StrictQueries    *StrictQueries         // Explains each new query shape of the reads once and rejects collection scans of large collections (default is nil, disabled)
+	TenantMetering   bool                   // Meters operations, documents and bytes per tenant of WithTenant contexts, see TenantUsage (default is false)
+	ResultLimits     *ResultLimits          // Documents and bytes Read, ReadWithProjection and Aggregate load at most, overridable per call with WithResultLimits (default is nil, unlimited)
+	PanicHandler     func(err *PanicError)  // Called with every panic recovered from a user callback, e.g. to log its stack. (default is nil)
+	OperationLog     *OperationLogConfig    // Keeps the last operations with redacted commands for DumpRecentOperations (default is nil, disabled)
+	Journal          *JournalConfig         // Persists writes to a local journal while the server is unreachable and replays them on reconnection (default is nil, disabled)
+	GridFS           *GridFSConfig          // Bucket of the GridFS file APIs (default is nil, bucket "fs" with 255 KiB chunks)
 }
 
 // Sets more client options
@@ -39,8 +53,11 @@ type Connection struct {
 	RetryWrites              bool   // Supported write operations should be retried once on certain error, such as network errors. (default is true)
 	ReadConcernWithMajority  bool   // Majority specifies that the query should return the instance's most recent data acknowledged as having been written to a majority of members in the replica set.
 	ReadSecondaryPreferred   bool   // In most situations, operation read from secondary members but if no secondary members are available, operations read from the primary on sharded clusters.
+	MaxStaleness             int    // In seconds, Secondaries lagging the primary by more than this are not read from, overridable per call with WithMaxStaleness. (default is 0, no bound) (minimum is 90)
 	WriteConcernWithMajority bool   // Majority of nodes must acknowledge write operations before the operation returns.
 	WriteConcernTimeout      int    // In milliseconds, How long write operations should wait for the correct number of nodes to acknowledge the operation.
+	WarmUpConnections        bool   // New waits until MinPoolSize connections are established to each server and server selection is primed, avoiding slow first requests after deploys. (default is false)
+	WarmUpTimeout            int    // In milliseconds, How long New waits for the warm up before failing. (default is 10 seconds)
 }
 
 // Intialise and return new mongodb client connection
@@ -55,10 +72,10 @@ func New(config *Config) (dbClient *Client, err error) {
 	return dbClient, nil
 }
 
-// Connect
-func (c *Config) connect() (dbClient *Client, err error) {
+// clientOptions builds the driver options of a client using the given connection options
+func (c *Config) clientOptions(connection *Connection) (mongoConnOptions *options.ClientOptions, err error) {
 	// Assigns hosts
-	mongoConnOptions := &options.ClientOptions{
+	mongoConnOptions = &options.ClientOptions{
 		Hosts: c.Hosts,
 	}
 
@@ -80,76 +97,208 @@ func (c *Config) connect() (dbClient *Client, err error) {
 		mongoConnOptions.Auth = creds
 	}
 
+	// Checks time zone
+	if c.TimeZone != "" {
+		loc, err := time.LoadLocation(c.TimeZone)
+		if err != nil {
+			return nil, errors.New("invalid time zone " + c.TimeZone + " -> " + err.Error())
+		}
+		mongoConnOptions.SetRegistry(timeZoneRegistry(loc))
+	}
+
 	// Checks & apply connection config
-	if c.Connection != nil {
+	if connection != nil {
 		// Sets min pool size
-		if c.Connection.MinPoolSize != 0 {
-			mongoConnOptions.SetMinPoolSize(c.Connection.MinPoolSize)
+		if connection.MinPoolSize != 0 {
+			mongoConnOptions.SetMinPoolSize(connection.MinPoolSize)
 		}
 
 		// Sets max pool size
-		if c.Connection.MaxPoolSize != 0 {
-			mongoConnOptions.SetMaxPoolSize(c.Connection.MaxPoolSize)
+		if connection.MaxPoolSize != 0 {
+			mongoConnOptions.SetMaxPoolSize(connection.MaxPoolSize)
 		}
 
 		// Sets max connecting
-		if c.Connection.MaxConnecting != 0 {
-			mongoConnOptions.SetMaxConnecting(c.Connection.MaxConnecting)
+		if connection.MaxConnecting != 0 {
+			mongoConnOptions.SetMaxConnecting(connection.MaxConnecting)
 		}
 
 		// Sets max connection idle time
-		if c.Connection.MaxConnIdleTime != 0 {
-			mongoConnOptions.SetMaxConnIdleTime(time.Duration(c.Connection.MaxConnIdleTime) * time.Millisecond)
+		if connection.MaxConnIdleTime != 0 {
+			mongoConnOptions.SetMaxConnIdleTime(time.Duration(connection.MaxConnIdleTime) * time.Millisecond)
 		}
 
 		// Sets server selection timeout
-		if c.Connection.ServerSelectionTimeout != 0 {
-			mongoConnOptions.SetServerSelectionTimeout(time.Duration(c.Connection.ServerSelectionTimeout) * time.Millisecond)
+		if connection.ServerSelectionTimeout != 0 {
+			mongoConnOptions.SetServerSelectionTimeout(time.Duration(connection.ServerSelectionTimeout) * time.Millisecond)
 		}
 
 		// Sets socket timeout
-		if c.Connection.SocketTimeout != 0 {
-			mongoConnOptions.SetSocketTimeout(time.Duration(c.Connection.SocketTimeout) * time.Millisecond)
+		if connection.SocketTimeout != 0 {
+			mongoConnOptions.SetSocketTimeout(time.Duration(connection.SocketTimeout) * time.Millisecond)
 		}
 
 		// Sets timeout
-		if c.Connection.Timeout != 0 {
-			mongoConnOptions.SetTimeout(time.Duration(c.Connection.Timeout) * time.Millisecond)
+		if connection.Timeout != 0 {
+			mongoConnOptions.SetTimeout(time.Duration(connection.Timeout) * time.Millisecond)
 		}
 
 		// Sets read concern majority
-		if c.Connection.ReadConcernWithMajority {
+		if connection.ReadConcernWithMajority {
 			majorityLevel := readconcern.Majority().GetLevel()
 			rc := readconcern.New(readconcern.Level(majorityLevel))
 			mongoConnOptions.SetReadConcern(rc)
 		}
 
 		// Sets read secondary preferred
-		if c.Connection.ReadSecondaryPreferred {
-			rp := readpref.SecondaryPreferred()
+		if connection.ReadSecondaryPreferred {
+			var rpOpts []readpref.Option
+			if connection.MaxStaleness != 0 {
+				rpOpts = append(rpOpts, readpref.WithMaxStaleness(time.Duration(connection.MaxStaleness)*time.Second))
+			}
+			rp := readpref.SecondaryPreferred(rpOpts...)
 			mongoConnOptions.SetReadPreference(rp)
 		}
 
 		// Sets write concern with majority
-		if c.Connection.WriteConcernWithMajority {
+		if connection.WriteConcernWithMajority {
 			wc := writeconcern.New(writeconcern.WMajority())
 
 			// Sets write conecern timeout
-			if c.Connection.WriteConcernTimeout != 0 {
-				wc.WithOptions(writeconcern.WTimeout(time.Duration(c.Connection.WriteConcernTimeout) * time.Millisecond))
+			if connection.WriteConcernTimeout != 0 {
+				wc.WithOptions(writeconcern.WTimeout(time.Duration(connection.WriteConcernTimeout) * time.Millisecond))
 			}
 			mongoConnOptions.SetWriteConcern(wc)
 		}
 	}
 
+	// Returns
+	return mongoConnOptions, nil
+}
+
+// Connect
+func (c *Config) connect() (dbClient *Client, err error) {
+	// Builds options
+	mongoConnOptions, err := c.clientOptions(c.Connection)
+	if err != nil {
+		return nil, err
+	}
+
+	// Monitors commands
+	dbClient = &Client{}
+	dbClient.stats = newStatsCollector()
+	monitors := []*event.CommandMonitor{dbClient.stats.monitor(), dbClient.cacheMonitor()}
+	if c.OperationLog != nil {
+		dbClient.operations = newOperationLog(c.OperationLog)
+		monitors = append(monitors, dbClient.operations.monitor())
+	}
+	if c.TenantMetering {
+		dbClient.tenantMeter = newTenantMeter()
+		monitors = append(monitors, dbClient.tenantMeter.monitor())
+	}
+	dbClient.commandMonitor = combineMonitors(monitors...)
+	mongoConnOptions.SetMonitor(dbClient.commandMonitor)
+
+	// Checks connection warm up
+	var warmer *poolWarmer
+	if c.Connection != nil && c.Connection.WarmUpConnections && c.Connection.MinPoolSize != 0 {
+		warmer = newPoolWarmer()
+		mongoConnOptions.SetPoolMonitor(warmer.monitor())
+	}
+
+	// Dials
+	client, err := dial(mongoConnOptions)
+	if err != nil {
+		return nil, err
+	}
+
+	// Warms up connections
+	if warmer != nil {
+		timeout := 10 * time.Second
+		if c.Connection.WarmUpTimeout != 0 {
+			timeout = time.Duration(c.Connection.WarmUpTimeout) * time.Millisecond
+		}
+		warmCtx, warmCancel := context.WithTimeout(context.Background(), timeout)
+		defer warmCancel()
+		err = warmer.warmUp(warmCtx, client, mongoConnOptions.ReadPreference, int(c.Connection.MinPoolSize))
+		if err != nil {
+			client.Disconnect(context.Background())
+			return nil, err
+		}
+	}
+
+	// Detects capabilities
+	capCtx, capCancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer capCancel()
+	dbClient.capabilities, err = detectCapabilities(capCtx, client)
+	if err != nil {
+		client.Disconnect(context.Background())
+		return nil, err
+	}
+
+	// Sets client connection
+	dbClient.client = client
+	dbClient.database = client.Database(c.Database)
+	dbClient.fetchParallelism = c.FetchParallelism
+	dbClient.defaultIDGenerator = c.IDGenerator
+	dbClient.readPref = mongoConnOptions.ReadPreference
+	dbClient.panicHandler = c.PanicHandler
+	dbClient.gridFS = c.GridFS
+	dbClient.registry = mongoConnOptions.Registry
+	dbClient.limits = c.ResultLimits
+	if c.Aggregation != nil {
+		dbClient.aggregateGuard = &aggregateGuard{config: c.Aggregation}
+	}
+	if c.StrictQueries != nil {
+		dbClient.queryGuard = newQueryGuard(c.StrictQueries)
+	}
+
+	// Sets read distribution
+	if c.ReadDistribution != nil && len(c.ReadDistribution.Nodes) != 0 {
+		var maxStaleness time.Duration
+		if c.Connection != nil {
+			maxStaleness = time.Duration(c.Connection.MaxStaleness) * time.Second
+		}
+		dbClient.readBalancer, err = newReadBalancer(c.ReadDistribution, maxStaleness)
+		if err != nil {
+			client.Disconnect(context.Background())
+			return nil, err
+		}
+	}
+
+	// Connects partitions
+	err = dbClient.connectPartitions(c)
+	if err != nil {
+		client.Disconnect(context.Background())
+		return nil, err
+	}
+
+	// Starts journal replay
+	if c.Journal != nil {
+		dbClient.journal, err = newJournal(c.Journal)
+		if err != nil {
+			dbClient.disconnectPartitions()
+			client.Disconnect(context.Background())
+			return nil, err
+		}
+		go dbClient.replayJournal()
+	}
+
+	// Returns
+	return dbClient, nil
+}
+
+// dial connects a new client and checks the primary is reachable
+func dial(mongoConnOptions *options.ClientOptions) (client *mongo.Client, err error) {
 	// Gets new mongodb client
-	client, err := mongo.NewClient(mongoConnOptions)
+	client, err = mongo.NewClient(mongoConnOptions)
 	if err != nil {
 		return nil, err
 	}
 
 	// Connect client with timeout
-	ctx, _ := context.WithTimeout(context.Background(), 10*time.Second)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
 	err = client.Connect(ctx)
 	if err != nil {
 		return nil, errors.New("client connection failed " + err.Error())
@@ -158,13 +307,29 @@ func (c *Config) connect() (dbClient *Client, err error) {
 	// Ping
 	err = client.Ping(ctx, readpref.Primary())
 	if err != nil {
+		client.Disconnect(context.Background())
 		return nil, errors.New("client ping failed ->" + err.Error())
 	}
 
-	// Sets client connection
-	dbClient = &Client{}
-	dbClient.database = client.Database(c.Database)
-
 	// Returns
-	return dbClient, nil
+	return client, nil
+}
+
+// Close stops background work of the client and disconnects it
+func (c *Client) Close(ctx context.Context) (err error) {
+	// Stops journal replay
+	if c.journal != nil {
+		c.journal.stopReplay()
+	}
+
+	// Disconnects partitions
+	for _, p := range c.partitions {
+		err = p.Client().Disconnect(ctx)
+		if err != nil {
+			return err
+		}
+	}
+
+	// Disconnects
+	return c.client.Disconnect(ctx)
 }
@@ -0,0 +1,245 @@
+package mongodb
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// Collection storing the acknowledged resume token of every consumer
+const resumeTokenCollection = "mongo_lib_resume_tokens"
+
+// ConsumerOptions ...
+type ConsumerOptions struct {
+	Watch           *WatchOptions                   // Options of the underlying stream, ResumeAfter is overridden by the stored token
+	MaxRedeliveries int                             // Times a nacked event is redelivered before it is given up. (default is 3)
+	RedeliveryDelay int                             // In milliseconds, Delay before a nacked event is redelivered. (default is 1 second)
+	OnGiveUp        func(delivery *Delivery)        // Called with an event nacked more than MaxRedeliveries times, it is then treated as acknowledged
+	OnAck           func(token bson.Raw, err error) // Called after the resume token was persisted, err is the persistence failure if any
+	TokenStore      ResumeTokenStore                // Stores the acknowledged resume token under the consumer name (default is the mongo_lib_resume_tokens collection)
+}
+
+// Delivery is a change event delivered by a Consumer, it must be acknowledged with Ack or Nack
+type Delivery struct {
+	Event    bson.Raw // Change event
+	Label    string   // Label of the collection of the event
+	Token    bson.Raw // Resume token after the event
+	Attempt  int      // Delivery attempt, 1 for the first delivery
+	seq      int64
+	acked    bool
+	retryAt  time.Time
+	consumer *Consumer
+}
+
+// Consumer delivers change events at least once
+// The resume token is persisted only once every earlier event was acknowledged, so a restarted consumer redelivers unacknowledged events
+type Consumer struct {
+	client  *Client
+	name    string
+	opts    *ConsumerOptions
+	stream  *ChangeStream
+	store   ResumeTokenStore
+	mu      sync.Mutex
+	seq     int64
+	pending []*Delivery // Unacknowledged deliveries in stream order
+	retries []*Delivery // Nacked deliveries waiting for redelivery
+	persist sync.Mutex
+	stored  int64 // Sequence of the last persisted token
+}
+
+// NewConsumer starts a named consumer of the collections, resuming after its last acknowledged event
+func (c *Client) NewConsumer(ctx context.Context, name string, collections []string, opts *ConsumerOptions) (consumer *Consumer, err error) {
+	if name == "" {
+		return nil, errors.New("consumer name is required")
+	}
+	if opts == nil {
+		opts = &ConsumerOptions{}
+	}
+	err = c.requireCapability("NewConsumer", CapabilityChangeStreams)
+	if err != nil {
+		return nil, err
+	}
+
+	// Loads resume token
+	watchOpts := WatchOptions{}
+	if opts.Watch != nil {
+		watchOpts = *opts.Watch
+	}
+	store := opts.TokenStore
+	if store == nil {
+		store = c.NewMongoResumeTokenStore(resumeTokenCollection)
+	}
+	token, err := store.Load(ctx, name)
+	if err != nil {
+		return nil, errors.New("loading resume token failed -> " + err.Error())
+	}
+	if token != nil {
+		watchOpts.ResumeAfter = token
+		watchOpts.StartAtOperationTime = nil
+	}
+	watchOpts.TokenStore, watchOpts.TokenName = nil, ""
+
+	// Opens stream
+	stream, err := c.WatchCollections(ctx, collections, &watchOpts)
+	if err != nil {
+		return nil, err
+	}
+
+	// Returns
+	return &Consumer{client: c, name: name, opts: opts, stream: stream, store: store}, nil
+}
+
+// Next returns the next event to process, a due redelivery first
+func (cs *Consumer) Next(ctx context.Context) (delivery *Delivery, err error) {
+	for {
+		// Redelivers
+		cs.mu.Lock()
+		if len(cs.retries) != 0 && !time.Now().Before(cs.retries[0].retryAt) {
+			delivery = cs.retries[0]
+			cs.retries = cs.retries[1:]
+			delivery.Attempt++
+			cs.mu.Unlock()
+			return delivery, nil
+		}
+		waitRetry := len(cs.retries) != 0
+		cs.mu.Unlock()
+
+		// Reads stream, without blocking while a redelivery is waiting
+		var ok bool
+		if waitRetry {
+			ok = cs.stream.TryNext(ctx)
+		} else {
+			ok = cs.stream.Next(ctx)
+		}
+		if !ok {
+			if err := cs.stream.Err(); err != nil {
+				return nil, err
+			}
+			if ctx.Err() != nil {
+				return nil, ctx.Err()
+			}
+			if waitRetry {
+				select {
+				case <-ctx.Done():
+					return nil, ctx.Err()
+				case <-time.After(10 * time.Millisecond):
+				}
+			}
+			continue
+		}
+
+		// Tracks delivery
+		cs.mu.Lock()
+		cs.seq++
+		delivery = &Delivery{
+			Event:    append(bson.Raw(nil), cs.stream.Current()...),
+			Label:    cs.stream.Label(),
+			Token:    append(bson.Raw(nil), cs.stream.ResumeToken()...),
+			Attempt:  1,
+			seq:      cs.seq,
+			consumer: cs,
+		}
+		cs.pending = append(cs.pending, delivery)
+		cs.mu.Unlock()
+		return delivery, nil
+	}
+}
+
+// Ack acknowledges the event, the resume token advances past every leading acknowledged event
+func (d *Delivery) Ack(ctx context.Context) error {
+	return d.consumer.ack(ctx, d)
+}
+
+// Nack rejects the event, it is redelivered after the redelivery delay until MaxRedeliveries is reached
+func (d *Delivery) Nack(ctx context.Context) error {
+	cs := d.consumer
+	maxRedeliveries := cs.opts.MaxRedeliveries
+	if maxRedeliveries <= 0 {
+		maxRedeliveries = 3
+	}
+
+	// Gives up
+	if d.Attempt > maxRedeliveries {
+		if cs.opts.OnGiveUp != nil {
+			safeCall(cs.client.panicHandler, "ConsumerOptions.OnGiveUp", func() { cs.opts.OnGiveUp(d) })
+		}
+		return cs.ack(ctx, d)
+	}
+
+	// Schedules redelivery
+	delay := time.Second
+	if cs.opts.RedeliveryDelay != 0 {
+		delay = time.Duration(cs.opts.RedeliveryDelay) * time.Millisecond
+	}
+	cs.mu.Lock()
+	d.retryAt = time.Now().Add(delay)
+	cs.retries = append(cs.retries, d)
+	cs.mu.Unlock()
+	return nil
+}
+
+// ack ...
+func (cs *Consumer) ack(ctx context.Context, d *Delivery) (err error) {
+	// Advances over leading acknowledged events
+	cs.mu.Lock()
+	d.acked = true
+	var token bson.Raw
+	var seq int64
+	for len(cs.pending) != 0 && cs.pending[0].acked {
+		token, seq = cs.pending[0].Token, cs.pending[0].seq
+		cs.pending = cs.pending[1:]
+	}
+	cs.mu.Unlock()
+	if token == nil {
+		return nil
+	}
+
+	// Persists token, unless a later one was persisted concurrently
+	cs.persist.Lock()
+	defer cs.persist.Unlock()
+	if seq <= cs.stored {
+		return nil
+	}
+	err = cs.store.Save(ctx, cs.name, token)
+	if err == nil {
+		cs.stored = seq
+	}
+	if cs.opts.OnAck != nil {
+		safeCall(cs.client.panicHandler, "ConsumerOptions.OnAck", func() { cs.opts.OnAck(token, err) })
+	}
+
+	// Returns
+	return err
+}
+
+// Run delivers events to the handler until the context is done or the stream fails
+// Events are acknowledged when the handler succeeds and nacked when it fails or panics, a panic is reported as a *PanicError to Config.PanicHandler
+func (cs *Consumer) Run(ctx context.Context, handler func(ctx context.Context, delivery *Delivery) error) (err error) {
+	for {
+		// Gets event
+		delivery, err := cs.Next(ctx)
+		if err != nil {
+			return err
+		}
+
+		// Handles
+		var handlerErr error
+		panicErr := safeCall(cs.client.panicHandler, "Consumer.Run handler", func() { handlerErr = handler(ctx, delivery) })
+		if panicErr != nil || handlerErr != nil {
+			err = delivery.Nack(ctx)
+		} else {
+			err = delivery.Ack(ctx)
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// Close ...
+func (cs *Consumer) Close(ctx context.Context) error {
+	return cs.stream.Close(ctx)
+}
@@ -0,0 +1,160 @@
+package mongodb
+
+import (
+	"context"
+	"errors"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// Query cost verdicts
+const (
+	CostIndexed        = "indexed"         // Every predicate and the sort are served by indexes
+	CostPartial        = "partial"         // Indexes narrow the documents, the rest of the predicates or the sort are applied to the fetched ones
+	CostCollectionScan = "collection scan" // Every document of the collection is examined
+)
+
+// QueryCost is the plan the server would run a query with
+type QueryCost struct {
+	Verdict               string   // CostIndexed, CostPartial or CostCollectionScan
+	Indexes               []string // Indexes of the winning plan
+	Stages                []string // Stages of the winning plan, parents first, e.g. ["FETCH", "IXSCAN"]
+	EstimatedDocsExamined int64    // Upper bound of the documents examined, see EstimateQueryCost
+}
+
+// EstimateQueryCost explains the find in queryPlanner mode, without running it, and returns the verdict of the winning plan
+// Documents examined are estimated from the collection metadata for collection scans and with index only counts on the indexed fields of the filter for index plans
+// e.g. to refuse unindexed queries in production: if cost.Verdict == CostCollectionScan { return ErrUnindexed }
+func (c *Client) EstimateQueryCost(ctx context.Context, collection string, filter interface{}, opts ...*options.FindOptions) (cost *QueryCost, err error) {
+	query := bson.D{}
+	if filter != nil {
+		query, err = toDoc(filter)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// Builds explained find
+	findOpts := options.MergeFindOptions(append([]*options.FindOptions{c.findOptions(collection)}, opts...)...)
+	find := bson.D{{Key: "find", Value: collection}, {Key: "filter", Value: query}}
+	if findOpts.Sort != nil {
+		find = append(find, bson.E{Key: "sort", Value: findOpts.Sort})
+	}
+	if findOpts.Projection != nil {
+		find = append(find, bson.E{Key: "projection", Value: findOpts.Projection})
+	}
+	if findOpts.Hint != nil {
+		find = append(find, bson.E{Key: "hint", Value: findOpts.Hint})
+	}
+	if findOpts.Skip != nil {
+		find = append(find, bson.E{Key: "skip", Value: *findOpts.Skip})
+	}
+	if findOpts.Limit != nil {
+		find = append(find, bson.E{Key: "limit", Value: *findOpts.Limit})
+	}
+	if findOpts.Collation != nil {
+		find = append(find, bson.E{Key: "collation", Value: findOpts.Collation})
+	}
+
+	// Hits DB
+	coll := c.collection(ctx, collection)
+	var explain bson.D
+	err = coll.Database().RunCommand(ctx, bson.D{{Key: "explain", Value: find}, {Key: "verbosity", Value: "queryPlanner"}}).Decode(&explain)
+	if err != nil {
+		return nil, errors.New("explaining query -> " + err.Error())
+	}
+	plan, ok := getPath(explain, []string{"queryPlanner", "winningPlan"})
+	if !ok {
+		return nil, errors.New("explain has no winning plan")
+	}
+
+	// Walks plan
+	cost = &QueryCost{Verdict: CostIndexed}
+	var scans []bson.D
+	idLookup := false
+	walkPlan(plan, func(stage bson.D) {
+		name, _ := getPath(stage, []string{"stage"})
+		s, _ := name.(string)
+		cost.Stages = append(cost.Stages, s)
+		switch s {
+		case "COLLSCAN":
+			cost.Verdict = CostCollectionScan
+		case "SORT":
+			if cost.Verdict == CostIndexed {
+				cost.Verdict = CostPartial
+			}
+		case "FETCH":
+			if _, ok := getPath(stage, []string{"filter"}); ok && cost.Verdict == CostIndexed {
+				cost.Verdict = CostPartial
+			}
+		}
+		if index, ok := getPath(stage, []string{"indexName"}); ok {
+			name, _ := index.(string)
+			cost.Indexes = append(cost.Indexes, name)
+			scans = append(scans, stage)
+		}
+		if s == "IDHACK" {
+			cost.Indexes = append(cost.Indexes, "_id_")
+			idLookup = true
+		}
+	})
+
+	// Estimates documents examined
+	total, err := coll.EstimatedDocumentCount(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if cost.Verdict == CostCollectionScan {
+		cost.EstimatedDocsExamined = total
+		return cost, nil
+	}
+	if idLookup {
+		cost.EstimatedDocsExamined = 1
+	}
+	for _, scan := range scans {
+		index, _ := getPath(scan, []string{"indexName"})
+		keys, _ := getPath(scan, []string{"keyPattern"})
+		keyPattern, _ := keys.(bson.D)
+		indexed := bson.D{}
+		for _, e := range query {
+			for _, key := range keyPattern {
+				if e.Key == key.Key {
+					indexed = append(indexed, e)
+					break
+				}
+			}
+		}
+		count, err := coll.CountDocuments(ctx, indexed, options.Count().SetHint(index))
+		if err != nil {
+			return nil, errors.New("estimating documents examined -> " + err.Error())
+		}
+		cost.EstimatedDocsExamined += count
+	}
+	if cost.EstimatedDocsExamined > total {
+		cost.EstimatedDocsExamined = total
+	}
+
+	// Returns
+	return cost, nil
+}
+
+// walkPlan calls fn with the stages of an explained plan, parents first
+// Sharded plans list a plan per shard and slot based plans nest the plan under queryPlan
+func walkPlan(plan interface{}, fn func(stage bson.D)) {
+	switch p := plan.(type) {
+	case bson.D:
+		if _, ok := getPath(p, []string{"stage"}); ok {
+			fn(p)
+		}
+		for _, key := range []string{"queryPlan", "winningPlan", "inputStage", "inputStages", "shards"} {
+			if child, ok := getPath(p, []string{key}); ok {
+				walkPlan(child, fn)
+			}
+		}
+	case bson.A:
+		for _, child := range p {
+			walkPlan(child, fn)
+		}
+	}
+}
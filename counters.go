@@ -0,0 +1,186 @@
+package mongodb
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"strconv"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// Resolutions of counter series
+const (
+	CounterHourly = "hourly"
+	CounterDaily  = "daily"
+)
+
+// Default counter fields
+const (
+	defaultCounterKeyField = "key"
+	counterDayField        = "day"
+	counterTotalField      = "total"
+	counterHoursField      = "hours"
+)
+
+// CounterOptions describes the daily counter documents, {key, day, total: {field: n}, hours: {"0".."23": {field: n}}}
+// A unique index on key and day keeps concurrent first increments from creating two documents for a day
+type CounterOptions struct {
+	KeyField string         // Field identifying the counted entity, e.g. page or campaign id. (default is "key")
+	Location *time.Location // Time zone in which days start. (default is UTC)
+}
+
+// CounterPoint is a value of a counter series
+type CounterPoint struct {
+	Time  time.Time // Start of the hour or day
+	Value int64
+}
+
+// withDefaults ...
+func (o *CounterOptions) withDefaults() *CounterOptions {
+	opts := CounterOptions{}
+	if o != nil {
+		opts = *o
+	}
+	if opts.KeyField == "" {
+		opts.KeyField = defaultCounterKeyField
+	}
+	if opts.Location == nil {
+		opts.Location = time.UTC
+	}
+	return &opts
+}
+
+// dayStart ...
+func (o *CounterOptions) dayStart(t time.Time) time.Time {
+	t = t.In(o.Location)
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, o.Location)
+}
+
+// IncrementCounters adds the increments to the counters of the key for the day and hour of at, in a single $inc
+// The daily document is created by the first increment of the day
+func (c *Client) IncrementCounters(ctx context.Context, collection string, key interface{}, at time.Time, increments map[string]int64, opts *CounterOptions) (err error) {
+	opts = opts.withDefaults()
+	if len(increments) == 0 {
+		return errors.New("no counter to increment")
+	}
+
+	// Checks tenant quota
+	err = c.checkQuota(ctx, true)
+	if err != nil {
+		return err
+	}
+
+	// Matches the daily document
+	query := bson.D{
+		{Key: opts.KeyField, Value: key},
+		{Key: counterDayField, Value: opts.dayStart(at)},
+	}
+
+	// Increments day and hour
+	hour := strconv.Itoa(at.In(opts.Location).Hour())
+	inc := bson.D{}
+	for _, field := range sortedCounterFields(increments) {
+		inc = append(inc,
+			bson.E{Key: counterTotalField + "." + field, Value: increments[field]},
+			bson.E{Key: counterHoursField + "." + hour + "." + field, Value: increments[field]},
+		)
+	}
+
+	// Hits DB
+	_, err = c.collection(ctx, collection).UpdateOne(ctx, query, bson.D{{Key: "$inc", Value: inc}}, options.Update().SetUpsert(true))
+	if err != nil {
+		return err
+	}
+
+	// Returns
+	return nil
+}
+
+// ReadCounterSeries returns the counter field of the key for every hour or day in [from, to), zero when nothing was counted
+// Resolution is CounterHourly or CounterDaily
+func (c *Client) ReadCounterSeries(ctx context.Context, collection string, key interface{}, field string, from time.Time, to time.Time, resolution string, opts *CounterOptions) (res []CounterPoint, err error) {
+	opts = opts.withDefaults()
+	if resolution != CounterHourly && resolution != CounterDaily {
+		return nil, errors.New("unknown counter resolution " + resolution)
+	}
+
+	// Builds query
+	query := bson.D{
+		{Key: opts.KeyField, Value: key},
+		{Key: counterDayField, Value: bson.D{
+			{Key: "$gte", Value: opts.dayStart(from)},
+			{Key: "$lt", Value: to},
+		}},
+	}
+	projection := bson.D{{Key: counterDayField, Value: 1}}
+	if resolution == CounterDaily {
+		projection = append(projection, bson.E{Key: counterTotalField + "." + field, Value: 1})
+	} else {
+		projection = append(projection, bson.E{Key: counterHoursField, Value: 1})
+	}
+
+	// Hits DB
+	coll, done := c.readCollection(ctx, collection)
+	defer func() { done(err) }()
+	cursor, err := coll.Find(ctx, query, options.Find().SetProjection(projection))
+	if err != nil {
+		return nil, err
+	}
+
+	// Close connection at the last
+	defer cursor.Close(ctx)
+
+	// Binds cursor response
+	var docs []struct {
+		Day   time.Time                   `bson:"day"`
+		Total map[string]int64            `bson:"total"`
+		Hours map[string]map[string]int64 `bson:"hours"`
+	}
+	err = cursor.All(ctx, &docs)
+	if err != nil {
+		return nil, err
+	}
+	byDay := make(map[int64]int, len(docs))
+	for i, doc := range docs {
+		byDay[doc.Day.Unix()] = i
+	}
+
+	// Assembles series
+	for t := opts.dayStart(from); t.Before(to); {
+		day := opts.dayStart(t)
+		i, found := byDay[day.Unix()]
+		if resolution == CounterDaily {
+			point := CounterPoint{Time: t}
+			if found {
+				point.Value = docs[i].Total[field]
+			}
+			res = append(res, point)
+			t = t.AddDate(0, 0, 1)
+			continue
+		}
+		if !t.Before(from.Truncate(time.Hour)) {
+			point := CounterPoint{Time: t}
+			if found {
+				point.Value = docs[i].Hours[strconv.Itoa(t.Hour())][field]
+			}
+			res = append(res, point)
+		}
+		t = t.Add(time.Hour)
+	}
+
+	// Returns
+	return res, nil
+}
+
+// sortedCounterFields returns the counter names in a stable order
+func sortedCounterFields(increments map[string]int64) []string {
+	fields := make([]string, 0, len(increments))
+	for field := range increments {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+	return fields
+}
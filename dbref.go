@@ -0,0 +1,207 @@
+package mongodb
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// DBRef is a legacy database reference as written by older ODMs: {"$ref": collection, "$id": id, "$db": database}
+type DBRef struct {
+	Ref string      `bson:"$ref"`          // Referenced collection
+	ID  interface{} `bson:"$id"`           // Referenced document _id
+	DB  string      `bson:"$db,omitempty"` // Referenced database, the client database when empty
+}
+
+// ParseDBRef returns the reference held by a decoded value, ok is false when the value is not a DBRef
+func ParseDBRef(value interface{}) (ref DBRef, ok bool) {
+	// Reads fields
+	var fields bson.M
+	switch v := value.(type) {
+	case DBRef:
+		return v, true
+	case *DBRef:
+		return *v, v != nil
+	case bson.M:
+		fields = v
+	case bson.D:
+		fields = v.Map()
+	default:
+		return DBRef{}, false
+	}
+
+	// Validates
+	collection, ok := fields["$ref"].(string)
+	if !ok {
+		return DBRef{}, false
+	}
+	id, ok := fields["$id"]
+	if !ok {
+		return DBRef{}, false
+	}
+	ref = DBRef{Ref: collection, ID: id}
+	ref.DB, _ = fields["$db"].(string)
+
+	// Returns
+	return ref, true
+}
+
+// ResolveDBRefs fetches the referenced documents, using a single query per referenced collection
+// Results are aligned with refs, nil when the referenced document does not exist
+func (c *Client) ResolveDBRefs(ctx context.Context, refs []DBRef) (res []interface{}, err error) {
+	// Groups ids per collection
+	type target struct{ db, collection string }
+	groups := map[target][]interface{}{}
+	for _, ref := range refs {
+		t := target{db: ref.DB, collection: ref.Ref}
+		groups[t] = append(groups[t], ref.ID)
+	}
+
+	// Hits DB
+	found := map[target]map[string]interface{}{}
+	for t, ids := range groups {
+		own := t.db == "" || t.db == c.database.Name()
+		var docs []bson.D
+		if own {
+			// Reads like Read, the collection rules only belong to the client database
+			coll, done := c.readCollection(ctx, t.collection)
+			docs, err = findByIDs(ctx, coll, ids, c.findOptions(t.collection))
+			done(err)
+		} else {
+			docs, err = findByIDs(ctx, c.client.Database(t.db).Collection(t.collection), ids, options.Find())
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		// Applies read rules of the collection
+		if own {
+			values := make([]interface{}, len(docs))
+			for i, doc := range docs {
+				values[i] = doc
+			}
+			err = c.verifyChecksums(t.collection, values...)
+			if err != nil {
+				return nil, err
+			}
+			err = c.decompressResults(t.collection, values...)
+			if err != nil {
+				return nil, err
+			}
+		}
+		found[t] = map[string]interface{}{}
+		for _, doc := range docs {
+			key, err := idKey(doc.Map()["_id"])
+			if err != nil {
+				return nil, err
+			}
+			found[t][key] = doc
+		}
+	}
+
+	// Aligns results
+	res = make([]interface{}, len(refs))
+	for i, ref := range refs {
+		key, err := idKey(ref.ID)
+		if err != nil {
+			return nil, err
+		}
+		if doc, ok := found[target{db: ref.DB, collection: ref.Ref}][key]; ok {
+			res[i] = doc
+		}
+	}
+
+	// Returns
+	return res, nil
+}
+
+// ResolveDBRefFields replaces DBRef values of the given top level fields by the referenced documents
+// Documents are the bson.D or bson.M values returned by reads, arrays of DBRefs are resolved element wise
+// References to missing documents are replaced by nil
+func (c *Client) ResolveDBRefFields(ctx context.Context, docs []interface{}, fields ...string) (err error) {
+	// Collects references with a setter for their location
+	var refs []DBRef
+	var setters []func(value interface{})
+	collect := func(value interface{}, set func(value interface{})) {
+		if ref, ok := ParseDBRef(value); ok {
+			refs = append(refs, ref)
+			setters = append(setters, set)
+		}
+	}
+	for _, doc := range docs {
+		for _, field := range fields {
+			field := field
+			switch d := doc.(type) {
+			case bson.D:
+				for i := range d {
+					if d[i].Key != field {
+						continue
+					}
+					e := &d[i]
+					collectValue(e.Value, func(v interface{}) { e.Value = v }, collect)
+				}
+			case bson.M:
+				if value, ok := d[field]; ok {
+					collectValue(value, func(v interface{}) { d[field] = v }, collect)
+				}
+			}
+		}
+	}
+	if len(refs) == 0 {
+		return nil
+	}
+
+	// Resolves
+	resolved, err := c.ResolveDBRefs(ctx, refs)
+	if err != nil {
+		return err
+	}
+	for i, set := range setters {
+		set(resolved[i])
+	}
+
+	// Returns
+	return nil
+}
+
+// collectValue collects a DBRef value or the DBRef elements of an array
+func collectValue(value interface{}, set func(value interface{}), collect func(value interface{}, set func(value interface{}))) {
+	arr, ok := value.(bson.A)
+	if !ok {
+		collect(value, set)
+		return
+	}
+	for i := range arr {
+		i := i
+		collect(arr[i], func(v interface{}) { arr[i] = v })
+	}
+}
+
+// idKey returns a comparable key of an _id value
+// Numbers are compared by value like the server does
+func idKey(id interface{}) (key string, err error) {
+	if n, ok := toFloat(id); ok {
+		id = n
+	}
+	t, data, err := bson.MarshalValue(id)
+	if err != nil {
+		return "", err
+	}
+	return string(rune(t)) + string(data), nil
+}
+
+// findByIDs reads the documents of the collection having the ids
+func findByIDs(ctx context.Context, coll *mongo.Collection, ids []interface{}, opts *options.FindOptions) (docs []bson.D, err error) {
+	// Hits DB
+	cursor, err := coll.Find(ctx, bson.D{{Key: "_id", Value: bson.D{{Key: "$in", Value: ids}}}}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	// Returns
+	err = cursor.All(ctx, &docs)
+	return docs, err
+}
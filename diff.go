@@ -0,0 +1,112 @@
+package mongodb
+
+import (
+	"reflect"
+	"strconv"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// Kinds of document changes
+const (
+	ChangeAdded    = "added"
+	ChangeRemoved  = "removed"
+	ChangeModified = "modified"
+)
+
+// Change is a changed path between two versions of a document
+type Change struct {
+	Path string      // Dotted path, array elements by index, e.g. "items.2.qty"
+	Kind string      // ChangeAdded, ChangeRemoved or ChangeModified
+	Old  interface{} // Value before, nil when added
+	New  interface{} // Value after, nil when removed
+}
+
+// Diff returns the changed paths between two versions of a document (struct, map, bson.D, bson.Raw)
+// Embedded documents and arrays are compared element by element, numbers are compared by value
+func Diff(before interface{}, after interface{}) (changes []Change, err error) {
+	// Converts
+	old, err := toDoc(before)
+	if err != nil {
+		return nil, err
+	}
+	updated, err := toDoc(after)
+	if err != nil {
+		return nil, err
+	}
+
+	// Compares
+	changes = diffDocs("", old, updated, changes)
+
+	// Returns
+	return changes, nil
+}
+
+// diffDocs compares fields in the order of the old document, then the added fields
+func diffDocs(path string, old bson.D, updated bson.D, changes []Change) []Change {
+	newValues := make(map[string]interface{}, len(updated))
+	for _, e := range updated {
+		newValues[e.Key] = e.Value
+	}
+	oldKeys := make(map[string]bool, len(old))
+	for _, e := range old {
+		oldKeys[e.Key] = true
+		field := joinPath(path, e.Key)
+		value, ok := newValues[e.Key]
+		if !ok {
+			changes = append(changes, Change{Path: field, Kind: ChangeRemoved, Old: e.Value})
+			continue
+		}
+		changes = diffValues(field, e.Value, value, changes)
+	}
+	for _, e := range updated {
+		if !oldKeys[e.Key] {
+			changes = append(changes, Change{Path: joinPath(path, e.Key), Kind: ChangeAdded, New: e.Value})
+		}
+	}
+	return changes
+}
+
+// diffValues ...
+func diffValues(path string, old interface{}, updated interface{}, changes []Change) []Change {
+	switch o := old.(type) {
+	case bson.D:
+		if u, ok := updated.(bson.D); ok {
+			return diffDocs(path, o, u, changes)
+		}
+	case bson.A:
+		if u, ok := updated.(bson.A); ok {
+			return diffArrays(path, o, u, changes)
+		}
+	}
+	if !equalValues(old, updated) {
+		changes = append(changes, Change{Path: path, Kind: ChangeModified, Old: old, New: updated})
+	}
+	return changes
+}
+
+// diffArrays compares elements by index, extra elements are added or removed
+func diffArrays(path string, old bson.A, updated bson.A, changes []Change) []Change {
+	for i := 0; i < len(old) || i < len(updated); i++ {
+		field := joinPath(path, strconv.Itoa(i))
+		switch {
+		case i >= len(updated):
+			changes = append(changes, Change{Path: field, Kind: ChangeRemoved, Old: old[i]})
+		case i >= len(old):
+			changes = append(changes, Change{Path: field, Kind: ChangeAdded, New: updated[i]})
+		default:
+			changes = diffValues(field, old[i], updated[i], changes)
+		}
+	}
+	return changes
+}
+
+// equalValues compares numbers by value and other values deeply
+func equalValues(a interface{}, b interface{}) bool {
+	x, aNumber := toFloat(a)
+	y, bNumber := toFloat(b)
+	if aNumber && bNumber {
+		return x == y
+	}
+	return reflect.DeepEqual(a, b)
+}
@@ -0,0 +1,265 @@
+package mongodb
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"sync"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// Default dispatcher settings
+const (
+	defaultDispatchRetries    = 5
+	defaultDispatchRetryDelay = 100 * time.Millisecond
+	defaultDispatchMaxDelay   = 30 * time.Second
+	defaultReopenDelay        = time.Second
+)
+
+// ChangeHandler handles a change event of a Dispatcher
+type ChangeHandler func(ctx context.Context, event *ChangeEvent[bson.M]) error
+
+// DispatcherOptions ...
+type DispatcherOptions struct {
+	Watch         *WatchOptions                                                  // Options of the underlying stream, e.g. FullDocument for the documents of update events
+	TokenStore    ResumeTokenStore                                               // Saves the token of every handled event under Name, a restarted dispatcher resumes after it (default is nil, starts from now)
+	Name          string                                                         // Name of the dispatcher in the token store, required with TokenStore
+	MaxRetries    int                                                            // Times a failing handler is retried before the event is given up. (default is 5)
+	RetryDelay    int                                                            // In milliseconds, Delay before the first retry, doubled on every retry. (default is 100 milliseconds)
+	MaxRetryDelay int                                                            // In milliseconds, Upper bound of the retry delay. (default is 30 seconds)
+	ReopenDelay   int                                                            // In milliseconds, Delay before reopening a failed stream. (default is 1 second)
+	OnError       func(collection string, event *ChangeEvent[bson.M], err error) // Called with handler failures given up and stream failures (nil event), the dispatcher carries on (default is nil)
+}
+
+// Dispatcher runs per collection and operation handlers of change events in a managed goroutine
+// Failing handlers are retried with exponential backoff, invalidated and failed streams are reopened after the last handled event
+type Dispatcher struct {
+	client   *Client
+	opts     *DispatcherOptions
+	handlers map[string]map[string]ChangeHandler // Handlers by collection and operation type
+	mu       sync.Mutex
+	cancel   context.CancelFunc
+	done     chan struct{}
+	token    bson.Raw // Token of the last handled event
+}
+
+// NewDispatcher returns a dispatcher, register handlers then Start it
+func (c *Client) NewDispatcher(opts *DispatcherOptions) *Dispatcher {
+	if opts == nil {
+		opts = &DispatcherOptions{}
+	}
+	return &Dispatcher{client: c, opts: opts, handlers: map[string]map[string]ChangeHandler{}}
+}
+
+// On registers the handler of an operation type of the collection, e.g. OpReplace or OpDrop
+func (d *Dispatcher) On(collection string, operationType string, handler ChangeHandler) *Dispatcher {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.handlers[collection] == nil {
+		d.handlers[collection] = map[string]ChangeHandler{}
+	}
+	d.handlers[collection][operationType] = handler
+	return d
+}
+
+// OnInsert ...
+func (d *Dispatcher) OnInsert(collection string, handler ChangeHandler) *Dispatcher {
+	return d.On(collection, OpInsert, handler)
+}
+
+// OnUpdate registers the handler of update and replace events of the collection
+func (d *Dispatcher) OnUpdate(collection string, handler ChangeHandler) *Dispatcher {
+	d.On(collection, OpUpdate, handler)
+	return d.On(collection, OpReplace, handler)
+}
+
+// OnDelete ...
+func (d *Dispatcher) OnDelete(collection string, handler ChangeHandler) *Dispatcher {
+	return d.On(collection, OpDelete, handler)
+}
+
+// Start loads the stored resume token and dispatches events in the background until Stop
+func (d *Dispatcher) Start(ctx context.Context) (err error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.done != nil {
+		return errors.New("dispatcher already started")
+	}
+	if len(d.handlers) == 0 {
+		return errors.New("no change handler registered")
+	}
+
+	// Loads resume token
+	if d.opts.TokenStore != nil {
+		if d.opts.Name == "" {
+			return errors.New("dispatcher name is required with a token store")
+		}
+		d.token, err = d.opts.TokenStore.Load(ctx, d.opts.Name)
+		if err != nil {
+			return errors.New("loading resume token failed -> " + err.Error())
+		}
+	}
+
+	// Runs
+	runCtx, cancel := context.WithCancel(context.Background())
+	d.cancel, d.done = cancel, make(chan struct{})
+	go d.run(runCtx)
+
+	// Returns
+	return nil
+}
+
+// Stop stops dispatching and waits for the running handler to return
+func (d *Dispatcher) Stop() {
+	d.mu.Lock()
+	cancel, done := d.cancel, d.done
+	d.mu.Unlock()
+	if cancel == nil {
+		return
+	}
+	cancel()
+	<-done
+}
+
+// run opens the stream and dispatches its events, reopening it when it fails or is invalidated
+func (d *Dispatcher) run(ctx context.Context) {
+	defer close(d.done)
+
+	// Lists collections
+	d.mu.Lock()
+	collections := make([]string, 0, len(d.handlers))
+	for collection := range d.handlers {
+		collections = append(collections, collection)
+	}
+	d.mu.Unlock()
+	sort.Strings(collections)
+
+	reopenDelay := defaultReopenDelay
+	if d.opts.ReopenDelay != 0 {
+		reopenDelay = time.Duration(d.opts.ReopenDelay) * time.Millisecond
+	}
+	for ctx.Err() == nil {
+		err := d.stream(ctx, collections)
+		if ctx.Err() != nil {
+			return
+		}
+		if err != nil {
+			d.reportError("", nil, err)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(reopenDelay):
+		}
+	}
+}
+
+// stream dispatches the events of a stream opened after the last handled event, until it fails or is invalidated
+func (d *Dispatcher) stream(ctx context.Context, collections []string) (err error) {
+	// Opens stream
+	watchOpts := WatchOptions{}
+	if d.opts.Watch != nil {
+		watchOpts = *d.opts.Watch
+	}
+	watchOpts.TokenStore, watchOpts.TokenName = nil, ""
+	if d.token != nil {
+		watchOpts.ResumeAfter, watchOpts.StartAfter, watchOpts.StartAtOperationTime = nil, d.token, nil
+	}
+	stream, err := d.client.WatchCollections(ctx, collections, &watchOpts)
+	if err != nil {
+		return err
+	}
+
+	// Close connection at the last
+	defer stream.Close(context.Background())
+
+	// Dispatches
+	for stream.Next(ctx) {
+		event, err := DecodeChangeEvent[bson.M](stream.Current())
+		if err != nil {
+			return errors.New("decoding change event -> " + err.Error())
+		}
+		if event.OperationType == OpInvalidate {
+			d.advance(ctx, event.ResumeToken)
+			return nil
+		}
+		d.dispatch(ctx, event)
+		if ctx.Err() != nil {
+			return nil
+		}
+		d.advance(ctx, event.ResumeToken)
+	}
+
+	// Returns
+	return stream.Err()
+}
+
+// dispatch runs the handler of the event, retrying with exponential backoff
+func (d *Dispatcher) dispatch(ctx context.Context, event *ChangeEvent[bson.M]) {
+	d.mu.Lock()
+	handler := d.handlers[event.Namespace.Collection][event.OperationType]
+	d.mu.Unlock()
+	if handler == nil {
+		return
+	}
+
+	// Sets backoff
+	maxRetries := d.opts.MaxRetries
+	if maxRetries <= 0 {
+		maxRetries = defaultDispatchRetries
+	}
+	delay := defaultDispatchRetryDelay
+	if d.opts.RetryDelay != 0 {
+		delay = time.Duration(d.opts.RetryDelay) * time.Millisecond
+	}
+	maxDelay := defaultDispatchMaxDelay
+	if d.opts.MaxRetryDelay != 0 {
+		maxDelay = time.Duration(d.opts.MaxRetryDelay) * time.Millisecond
+	}
+
+	// Runs
+	var err error
+	for attempt := 0; ; attempt++ {
+		var handlerErr error
+		panicErr := safeCall(d.client.panicHandler, "Dispatcher handler", func() { handlerErr = handler(ctx, event) })
+		err = panicErr
+		if err == nil {
+			err = handlerErr
+		}
+		if err == nil || attempt >= maxRetries {
+			break
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(delay):
+		}
+		delay *= 2
+		if delay > maxDelay {
+			delay = maxDelay
+		}
+	}
+	if err != nil {
+		d.reportError(event.Namespace.Collection, event, err)
+	}
+}
+
+// advance records the token of the handled event
+func (d *Dispatcher) advance(ctx context.Context, token bson.Raw) {
+	d.token = append(bson.Raw(nil), token...)
+	if d.opts.TokenStore != nil {
+		err := d.opts.TokenStore.Save(ctx, d.opts.Name, token)
+		if err != nil {
+			d.reportError("", nil, errors.New("saving resume token failed -> "+err.Error()))
+		}
+	}
+}
+
+// reportError ...
+func (d *Dispatcher) reportError(collection string, event *ChangeEvent[bson.M], err error) {
+	if d.opts.OnError != nil {
+		safeCall(d.client.panicHandler, "DispatcherOptions.OnError", func() { d.opts.OnError(collection, event, err) })
+	}
+}
@@ -0,0 +1,53 @@
+package mongodb
+
+import (
+	"context"
+	"errors"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// Distinct returns the distinct values of the field among the documents matching the filter, e.g. to fill filter dropdowns
+// Array fields contribute each of their elements
+func (c *Client) Distinct(ctx context.Context, collection string, field string, filter interface{}, opts ...*options.DistinctOptions) (res []interface{}, err error) {
+	if filter == nil {
+		filter = bson.D{}
+	}
+
+	// Hits DB
+	coll, done := c.readCollection(ctx, collection)
+	defer func() { done(err) }()
+	res, err = coll.Distinct(ctx, field, filter, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	// Returns
+	return res, nil
+}
+
+// DistinctOf is Distinct decoding the values into T, e.g. DistinctOf[string](ctx, client, "users", "country", nil)
+func DistinctOf[T any](ctx context.Context, c *Client, collection string, field string, filter interface{}, opts ...*options.DistinctOptions) (res []T, err error) {
+	// Hits DB
+	values, err := c.Distinct(ctx, collection, field, filter, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	// Decodes
+	res = make([]T, len(values))
+	for i, value := range values {
+		t, data, err := bson.MarshalValue(value)
+		if err != nil {
+			return nil, err
+		}
+		err = bson.RawValue{Type: t, Value: data}.Unmarshal(&res[i])
+		if err != nil {
+			return nil, errors.New("decoding distinct value of " + field + " -> " + err.Error())
+		}
+	}
+
+	// Returns
+	return res, nil
+}
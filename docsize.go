@@ -0,0 +1,183 @@
+package mongodb
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"sync"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// Default document size sampler settings
+const (
+	defaultDocumentSample         = 1000
+	defaultDocumentSampleInterval = 5 * time.Minute
+)
+
+// MetricsSink receives the gauges of the client, e.g. an adapter over Prometheus, StatsD or OpenTelemetry
+type MetricsSink interface {
+	Gauge(name string, value float64, tags map[string]string)
+}
+
+// DocumentSizeStats are the sizes of a random sample of the documents of a collection, sizes are in bytes
+type DocumentSizeStats struct {
+	Collection string
+	Documents  int     // Sampled documents
+	MeanBytes  float64 // BSON size of the documents
+	P50Bytes   int64
+	P95Bytes   int64
+	P99Bytes   int64
+	MaxBytes   int64
+	MeanFields float64 // Top level fields of the documents
+	MaxFields  int64
+	SampledAt  time.Time
+}
+
+// DocumentSizeSamplerOptions ...
+type DocumentSizeSamplerOptions struct {
+	Collections []string                           // Sampled collections (default is every collection of the database)
+	SampleSize  int                                // Documents sampled per collection and round. (default is 1000)
+	Interval    int                                // In milliseconds, Time between sampling rounds. (default is 5 minutes)
+	Sink        MetricsSink                        // Receives mongodb.document.bytes.{mean,p50,p95,p99,max} and mongodb.document.fields.{mean,max} tagged by collection
+	OnSample    func(stats *DocumentSizeStats)     // Called with the stats of every sampled collection (default is nil)
+	OnError     func(collection string, err error) // Called when sampling a collection fails, the sampler carries on (default is nil)
+}
+
+// SampleDocumentSizes measures the BSON size and top level field count of a random sample of the documents, requires server 4.4+
+func (c *Client) SampleDocumentSizes(ctx context.Context, collection string, sampleSize int) (stats *DocumentSizeStats, err error) {
+	if sampleSize <= 0 {
+		sampleSize = defaultDocumentSample
+	}
+
+	// Hits DB, the sample is bounded by its size rather than the result limits
+	pipeline := mongo.Pipeline{
+		{{Key: "$sample", Value: bson.D{{Key: "size", Value: sampleSize}}}},
+		{{Key: "$project", Value: bson.D{
+			{Key: "_id", Value: 0},
+			{Key: "bytes", Value: bson.D{{Key: "$bsonSize", Value: "$$ROOT"}}},
+			{Key: "fields", Value: bson.D{{Key: "$size", Value: bson.D{{Key: "$objectToArray", Value: "$$ROOT"}}}}},
+		}}},
+	}
+	var docs []struct {
+		Bytes  int64 `bson:"bytes"`
+		Fields int64 `bson:"fields"`
+	}
+	err = c.Aggregate(WithResultLimits(ctx, nil), collection, pipeline, &docs, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	// Computes
+	stats = &DocumentSizeStats{Collection: collection, Documents: len(docs), SampledAt: time.Now()}
+	if len(docs) == 0 {
+		return stats, nil
+	}
+	sizes := make([]int64, len(docs))
+	var totalBytes, totalFields int64
+	for i, doc := range docs {
+		sizes[i] = doc.Bytes
+		totalBytes += doc.Bytes
+		totalFields += doc.Fields
+		if doc.Fields > stats.MaxFields {
+			stats.MaxFields = doc.Fields
+		}
+	}
+	sort.Slice(sizes, func(i, j int) bool { return sizes[i] < sizes[j] })
+	percentile := func(p float64) int64 {
+		return sizes[int(p*float64(len(sizes)-1))]
+	}
+	stats.MeanBytes = float64(totalBytes) / float64(len(docs))
+	stats.P50Bytes = percentile(0.50)
+	stats.P95Bytes = percentile(0.95)
+	stats.P99Bytes = percentile(0.99)
+	stats.MaxBytes = sizes[len(sizes)-1]
+	stats.MeanFields = float64(totalFields) / float64(len(docs))
+
+	// Returns
+	return stats, nil
+}
+
+// StartDocumentSizeSampler samples document sizes of the collections periodically until stop is called, to catch unbounded document growth early
+func (c *Client) StartDocumentSizeSampler(opts *DocumentSizeSamplerOptions) (stop func(), err error) {
+	if opts == nil || (opts.Sink == nil && opts.OnSample == nil) {
+		return nil, errors.New("document size sampler requires a sink or an OnSample callback")
+	}
+	interval := defaultDocumentSampleInterval
+	if opts.Interval != 0 {
+		interval = time.Duration(opts.Interval) * time.Millisecond
+	}
+
+	// Runs rounds
+	stopCh, done := make(chan struct{}), make(chan struct{})
+	go func() {
+		defer close(done)
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			ctx, cancel := context.WithTimeout(context.Background(), interval)
+			c.sampleDocumentSizes(ctx, opts)
+			cancel()
+
+			select {
+			case <-stopCh:
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+
+	// Returns, stop may be called several times
+	var once sync.Once
+	return func() {
+		once.Do(func() { close(stopCh) })
+		<-done
+	}, nil
+}
+
+// sampleDocumentSizes runs a sampling round over the collections
+func (c *Client) sampleDocumentSizes(ctx context.Context, opts *DocumentSizeSamplerOptions) {
+	// Lists collections
+	collections := opts.Collections
+	if len(collections) == 0 {
+		var err error
+		collections, err = c.database.ListCollectionNames(ctx, bson.D{{Key: "type", Value: "collection"}})
+		if err != nil {
+			c.reportSampleError(opts, "", err)
+			return
+		}
+	}
+
+	// Samples
+	for _, collection := range collections {
+		stats, err := c.SampleDocumentSizes(ctx, collection, opts.SampleSize)
+		if err != nil {
+			c.reportSampleError(opts, collection, err)
+			continue
+		}
+		if opts.Sink != nil && stats.Documents != 0 {
+			tags := map[string]string{"collection": collection}
+			safeCall(c.panicHandler, "MetricsSink.Gauge", func() {
+				opts.Sink.Gauge("mongodb.document.bytes.mean", stats.MeanBytes, tags)
+				opts.Sink.Gauge("mongodb.document.bytes.p50", float64(stats.P50Bytes), tags)
+				opts.Sink.Gauge("mongodb.document.bytes.p95", float64(stats.P95Bytes), tags)
+				opts.Sink.Gauge("mongodb.document.bytes.p99", float64(stats.P99Bytes), tags)
+				opts.Sink.Gauge("mongodb.document.bytes.max", float64(stats.MaxBytes), tags)
+				opts.Sink.Gauge("mongodb.document.fields.mean", stats.MeanFields, tags)
+				opts.Sink.Gauge("mongodb.document.fields.max", float64(stats.MaxFields), tags)
+			})
+		}
+		if opts.OnSample != nil {
+			safeCall(c.panicHandler, "DocumentSizeSamplerOptions.OnSample", func() { opts.OnSample(stats) })
+		}
+	}
+}
+
+// reportSampleError ...
+func (c *Client) reportSampleError(opts *DocumentSizeSamplerOptions, collection string, err error) {
+	if opts.OnError != nil {
+		safeCall(c.panicHandler, "DocumentSizeSamplerOptions.OnError", func() { opts.OnError(collection, err) })
+	}
+}
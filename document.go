@@ -0,0 +1,79 @@
+package mongodb
+
+import (
+	"sort"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// toMap converts any document value (struct, map, bson.D, bson.Raw) into a bson.M
+// Embedded documents are decoded as bson.M and arrays as bson.A
+func toMap(document interface{}) (doc bson.M, err error) {
+	// Encodes
+	raw, err := bson.Marshal(document)
+	if err != nil {
+		return nil, err
+	}
+
+	// Decodes
+	err = bson.Unmarshal(raw, &doc)
+	if err != nil {
+		return nil, err
+	}
+
+	// Returns
+	return doc, nil
+}
+
+// sortedKeys returns the keys of the document in a stable order
+func sortedKeys(doc bson.M) []string {
+	keys := make([]string, 0, len(doc))
+	for key := range doc {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// toDoc converts any document value into an ordered bson.D
+// Embedded documents are decoded as bson.D and arrays as bson.A
+func toDoc(document interface{}) (doc bson.D, err error) {
+	// Encodes
+	raw, err := bson.Marshal(document)
+	if err != nil {
+		return nil, err
+	}
+
+	// Decodes
+	err = bson.Unmarshal(raw, &doc)
+	if err != nil {
+		return nil, err
+	}
+
+	// Returns
+	return doc, nil
+}
+
+// withID converts the document into a bson.D having an _id, generated by newID when missing, a new ObjectID when newID is nil
+func withID(document interface{}, newID func() interface{}) (doc bson.D, err error) {
+	// Converts
+	doc, err = toDoc(document)
+	if err != nil {
+		return nil, err
+	}
+
+	// Generates id
+	for _, e := range doc {
+		if e.Key == "_id" {
+			return doc, nil
+		}
+	}
+	if newID == nil {
+		newID = func() interface{} { return primitive.NewObjectID() }
+	}
+	doc = append(bson.D{{Key: "_id", Value: newID()}}, doc...)
+
+	// Returns
+	return doc, nil
+}
@@ -0,0 +1,80 @@
+package mongodb
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// Index and keys of a duplicate key error message, e.g. `index: email_1 dup key: { email: "a@b.c" }`
+var duplicateMessage = regexp.MustCompile(`index: (\S+) dup key: \{ ?(.*?) ?\}$`)
+
+// DuplicateError is returned by writes violating a unique index, so callers can answer e.g. "email already exists"
+type DuplicateError struct {
+	Index  string      // Name of the violated index
+	Field  string      // First field of the index, e.g. "email"
+	Value  interface{} // Value of the first field
+	Fields bson.D      // Every field of the index with its value, for compound indexes
+	Err    error       // Underlying driver error
+}
+
+// Error ...
+func (e *DuplicateError) Error() string {
+	return fmt.Sprintf("duplicate %s %v -> index %s", e.Field, e.Value, e.Index)
+}
+
+// Unwrap ...
+func (e *DuplicateError) Unwrap() error {
+	return e.Err
+}
+
+// duplicateError converts a duplicate key error into a *DuplicateError, other errors are returned as is
+// Servers 4.2+ report the key values, older ones only have them in the message
+func duplicateError(err error) error {
+	// Reads server error
+	var message string
+	var raw bson.Raw
+	var writeErr mongo.WriteException
+	var cmdErr mongo.CommandError
+	switch {
+	case errors.As(err, &writeErr) && len(writeErr.WriteErrors) != 0 && duplicateKeyCodes[writeErr.WriteErrors[0].Code]:
+		message, raw = writeErr.WriteErrors[0].Message, writeErr.WriteErrors[0].Raw
+	case errors.As(err, &cmdErr) && duplicateKeyCodes[int(cmdErr.Code)]:
+		message, raw = cmdErr.Message, cmdErr.Raw
+	default:
+		return err
+	}
+	dup := &DuplicateError{Err: err}
+
+	// Reads index & keys from the message
+	match := duplicateMessage.FindStringSubmatch(message)
+	if match != nil {
+		dup.Index = match[1]
+		for _, pair := range strings.Split(match[2], ", ") {
+			field, value, found := strings.Cut(pair, ": ")
+			if found {
+				dup.Fields = append(dup.Fields, bson.E{Key: field, Value: strings.Trim(value, `"`)})
+			}
+		}
+	}
+
+	// Prefers the reported key values
+	if raw != nil {
+		var reported struct {
+			KeyValue bson.D `bson:"keyValue"`
+		}
+		if bson.Unmarshal(raw, &reported) == nil && len(reported.KeyValue) != 0 {
+			dup.Fields = reported.KeyValue
+		}
+	}
+
+	// Returns
+	if len(dup.Fields) != 0 {
+		dup.Field, dup.Value = dup.Fields[0].Key, dup.Fields[0].Value
+	}
+	return dup
+}
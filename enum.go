@@ -0,0 +1,242 @@
+package mongodb
+
+import (
+	"fmt"
+	"strings"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// EnumSet is a closed set of values a field may hold, implemented by *Enum
+type EnumSet interface {
+	Name() string                    // Name of the enum type, used in errors
+	Contains(value interface{}) bool // Reports whether a value, as decoded from bson, belongs to the set
+}
+
+// EnumError is returned when a write sets an enum field to a value outside of its set
+type EnumError struct {
+	Collection string
+	Field      string // Dotted path of the field
+	Enum       string
+	Value      interface{}
+}
+
+// Error ...
+func (e *EnumError) Error() string {
+	if e.Collection == "" {
+		return fmt.Sprintf("%v is not a valid %s", e.Value, e.Enum)
+	}
+	return fmt.Sprintf("field %s of collection %s: %v is not a valid %s", e.Field, e.Collection, e.Value, e.Enum)
+}
+
+// Enum is the set of values of a Go enum like type stored as strings or numbers, e.g.
+// var OrderStatuses = NewEnum("OrderStatus", Pending, Paid, Shipped)
+type Enum[T comparable] struct {
+	name   string
+	values []T
+	keys   map[string]bool // Keys of the bson encoded values, numbers are compared by value
+}
+
+// NewEnum ...
+func NewEnum[T comparable](name string, values ...T) *Enum[T] {
+	e := &Enum[T]{name: name, values: values, keys: make(map[string]bool, len(values))}
+	for _, v := range values {
+		if key, err := enumKey(v); err == nil {
+			e.keys[key] = true
+		}
+	}
+	return e
+}
+
+// Name ...
+func (e *Enum[T]) Name() string {
+	return e.name
+}
+
+// Values returns the values of the enum in declaration order
+func (e *Enum[T]) Values() []T {
+	return append([]T(nil), e.values...)
+}
+
+// Valid reports whether the value is one of the enum values
+func (e *Enum[T]) Valid(value T) bool {
+	for _, v := range e.values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// Contains ...
+func (e *Enum[T]) Contains(value interface{}) bool {
+	key, err := enumKey(value)
+	return err == nil && e.keys[key]
+}
+
+// enumKey returns a comparable key of a value as stored, named types are keyed like their underlying value
+func enumKey(value interface{}) (key string, err error) {
+	t, data, err := bson.MarshalValue(value)
+	if err != nil {
+		return "", err
+	}
+	var decoded interface{}
+	err = bson.RawValue{Type: t, Value: data}.Unmarshal(&decoded)
+	if err != nil {
+		return "", err
+	}
+	return idKey(decoded)
+}
+
+// In returns a {field: {$in: values}} filter, every enum value when none are given
+// Values outside of the enum return an *EnumError instead of a filter that can never match
+func (e *Enum[T]) In(field string, values ...T) (filter bson.D, err error) {
+	if len(values) == 0 {
+		values = e.values
+	}
+	in := make(bson.A, 0, len(values))
+	for _, v := range values {
+		if !e.Valid(v) {
+			return nil, &EnumError{Field: field, Enum: e.name, Value: v}
+		}
+		in = append(in, v)
+	}
+	return bson.D{{Key: field, Value: bson.D{{Key: "$in", Value: in}}}}, nil
+}
+
+// NotIn returns a {field: {$nin: values}} filter
+func (e *Enum[T]) NotIn(field string, values ...T) (filter bson.D, err error) {
+	filter, err = e.In(field, values...)
+	if err != nil {
+		return nil, err
+	}
+	filter[0].Value.(bson.D)[0].Key = "$nin"
+	return filter, nil
+}
+
+// SetEnumField validates the values written to the field of the collection against the enum, nil removes the check
+// Inserts, replacements and $set, $setOnInsert, $push and $addToSet updates are checked, arrays element by element
+// A missing or null field is accepted
+func (c *Client) SetEnumField(collection string, field string, enum EnumSet) {
+	c.configure(collection, func(s *collectionSettings) {
+		// Copies enums so previous snapshots are not modified
+		enums := make(map[string]EnumSet, len(s.enums)+1)
+		for path, e := range s.enums {
+			enums[path] = e
+		}
+		if enum == nil {
+			delete(enums, field)
+		} else {
+			enums[field] = enum
+		}
+		s.enums = enums
+	})
+}
+
+// validateEnums checks the enum fields of a document to insert or replace
+func validateEnums(collection string, enums map[string]EnumSet, document interface{}) (err error) {
+	doc, err := toDoc(document)
+	if err != nil {
+		return err
+	}
+	for field, enum := range enums {
+		if value, ok := getPath(doc, strings.Split(field, ".")); ok {
+			err = checkEnum(collection, field, enum, value)
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// validateEnumUpdate checks the enum fields written by an update document
+func validateEnumUpdate(collection string, enums map[string]EnumSet, update interface{}) (err error) {
+	// Pipeline updates can not be checked on client side
+	if isPipeline(update) {
+		return nil
+	}
+
+	// Converts
+	doc, err := toDoc(update)
+	if err != nil {
+		return err
+	}
+
+	// Checks replacement
+	if len(doc) == 0 || !strings.HasPrefix(doc[0].Key, "$") {
+		return validateEnums(collection, enums, doc)
+	}
+
+	// Validates operators
+	for _, e := range doc {
+		fields, ok := e.Value.(bson.D)
+		if !ok {
+			continue
+		}
+		switch e.Key {
+		case "$set", "$setOnInsert":
+			for _, f := range fields {
+				for field, enum := range enums {
+					switch {
+					case f.Key == field:
+						err = checkEnum(collection, field, enum, f.Value)
+					case strings.HasPrefix(field, f.Key+"."):
+						// Whole embedded document holding the field
+						sub, isDoc := f.Value.(bson.D)
+						if !isDoc {
+							continue
+						}
+						if value, ok := getPath(sub, strings.Split(strings.TrimPrefix(field, f.Key+"."), ".")); ok {
+							err = checkEnum(collection, field, enum, value)
+						}
+					}
+					if err != nil {
+						return err
+					}
+				}
+			}
+		case "$push", "$addToSet":
+			for _, f := range fields {
+				enum, ok := enums[f.Key]
+				if !ok {
+					continue
+				}
+				value := f.Value
+				if modifiers, isDoc := value.(bson.D); isDoc {
+					for _, m := range modifiers {
+						if m.Key == "$each" {
+							value = m.Value
+						}
+					}
+				}
+				err = checkEnum(collection, f.Key, enum, value)
+				if err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	// Returns
+	return nil
+}
+
+// checkEnum checks a value, or every element of an array value
+func checkEnum(collection string, field string, enum EnumSet, value interface{}) error {
+	switch v := value.(type) {
+	case nil:
+		return nil
+	case bson.A:
+		for _, item := range v {
+			if err := checkEnum(collection, field, enum, item); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	if !enum.Contains(value) {
+		return &EnumError{Collection: collection, Field: field, Enum: enum.Name(), Value: value}
+	}
+	return nil
+}
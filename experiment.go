@@ -0,0 +1,161 @@
+package mongodb
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// Number of hash buckets entities are spread over, weights are distributed in these buckets
+const ExperimentBuckets = 10000
+
+// Experiment splits entities into weighted variants by a deterministic hash of their id
+type Experiment struct {
+	Name     string
+	Salt     string    // Changes the hash, to reshuffle entities between experiments of the same name. (default is empty)
+	Variants []Variant // Variants in order, a variant owns a share of the buckets proportional to its weight
+}
+
+// Variant ...
+type Variant struct {
+	Name   string
+	Weight int
+}
+
+// Assignment is the persisted variant of an entity in an experiment
+type Assignment struct {
+	Experiment string    `bson:"experiment"`
+	EntityID   string    `bson:"entity"`
+	Variant    string    `bson:"variant"`
+	Bucket     int       `bson:"bucket"`
+	AssignedAt time.Time `bson:"assignedAt"`
+}
+
+// Bucket returns the bucket of the entity in [0, ExperimentBuckets), the same for every call and process
+func (e *Experiment) Bucket(entityID string) int {
+	sum := sha256.Sum256([]byte(e.Name + "\x00" + e.Salt + "\x00" + entityID))
+	return int(binary.BigEndian.Uint64(sum[:8]) % ExperimentBuckets)
+}
+
+// Variant returns the variant owning the bucket of the entity
+func (e *Experiment) Variant(entityID string) (variant string, bucket int, err error) {
+	// Sums weights
+	total := 0
+	for _, v := range e.Variants {
+		if v.Weight < 0 {
+			return "", 0, errors.New("negative weight of variant " + v.Name)
+		}
+		total += v.Weight
+	}
+	if total == 0 {
+		return "", 0, errors.New("experiment " + e.Name + " has no weighted variant")
+	}
+
+	// Picks variant
+	bucket = e.Bucket(entityID)
+	point := bucket * total / ExperimentBuckets
+	for _, v := range e.Variants {
+		if point < v.Weight {
+			return v.Name, bucket, nil
+		}
+		point -= v.Weight
+	}
+
+	// Returns
+	return e.Variants[len(e.Variants)-1].Name, bucket, nil
+}
+
+// EnsureAssignmentIndexes creates the unique index on experiment and entity of the assignments collection, and the index listing the entities of a variant
+func (c *Client) EnsureAssignmentIndexes(ctx context.Context, collection string) (err error) {
+	_, err = c.CreateIndexes(ctx, collection, []*IndexModel{
+		{Keys: bson.D{{Key: "experiment", Value: 1}, {Key: "entity", Value: 1}}, Unique: true},
+		{Keys: bson.D{{Key: "experiment", Value: 1}, {Key: "variant", Value: 1}}},
+	})
+	return err
+}
+
+// Assign returns the assignment of the entity, persisting it on first call
+// A persisted assignment is sticky, it is kept when the weights of the experiment change later
+func (c *Client) Assign(ctx context.Context, collection string, experiment *Experiment, entityID string) (assignment *Assignment, err error) {
+	// Computes variant
+	variant, bucket, err := experiment.Variant(entityID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Hits DB, inserting unless assigned already
+	query := bson.D{{Key: "experiment", Value: experiment.Name}, {Key: "entity", Value: entityID}}
+	update := bson.D{{Key: "$setOnInsert", Value: bson.D{
+		{Key: "variant", Value: variant},
+		{Key: "bucket", Value: bucket},
+		{Key: "assignedAt", Value: time.Now().UTC()},
+	}}}
+	opts := options.FindOneAndUpdate().SetUpsert(true).SetReturnDocument(options.After)
+	coll := c.collection(ctx, collection)
+	assignment = &Assignment{}
+	err = coll.FindOneAndUpdate(ctx, query, update, opts).Decode(assignment)
+	if mongo.IsDuplicateKeyError(err) {
+		// A concurrent call inserted it first
+		err = coll.FindOne(ctx, query).Decode(assignment)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// Returns
+	return assignment, nil
+}
+
+// AssignmentOf returns the persisted assignment of the entity, nil when it was never assigned
+func (c *Client) AssignmentOf(ctx context.Context, collection string, experiment string, entityID string) (assignment *Assignment, err error) {
+	// Hits DB
+	coll, done := c.readCollection(ctx, collection)
+	defer func() { done(err) }()
+	assignment = &Assignment{}
+	err = coll.FindOne(ctx, bson.D{{Key: "experiment", Value: experiment}, {Key: "entity", Value: entityID}}).Decode(assignment)
+	if err != nil {
+		// Handles no document found
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	// Returns
+	return assignment, nil
+}
+
+// Assignments returns the persisted assignments of a variant of the experiment, every variant when variant is empty
+func (c *Client) Assignments(ctx context.Context, collection string, experiment string, variant string) (res []Assignment, err error) {
+	query := bson.D{{Key: "experiment", Value: experiment}}
+	if variant != "" {
+		query = append(query, bson.E{Key: "variant", Value: variant})
+	}
+
+	// Hits DB
+	coll, done := c.readCollection(ctx, collection)
+	defer func() { done(err) }()
+	cursor, err := coll.Find(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	// Close connection at the last
+	defer cursor.Close(ctx)
+
+	// Binds cursor response
+	err = cursor.All(ctx, &res)
+	if err != nil {
+		return nil, err
+	}
+
+	// Returns
+	return res, nil
+}
@@ -0,0 +1,113 @@
+package mongodb
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"io"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// ExportWriterFactory opens the destination of a collection export, e.g. a file or an S3 upload
+// The writer is closed once the collection is exported
+type ExportWriterFactory func(collection string) (io.WriteCloser, error)
+
+// ExportOptions ...
+type ExportOptions struct {
+	Canonical bool  // Writes canonical extended JSON keeping every BSON type, e.g. {"$numberInt": "1"}. (default is false, relaxed JSON)
+	BatchSize int32 // Documents per server batch. (default is server default)
+}
+
+// ExportResult ...
+type ExportResult struct {
+	Documents    map[string]int64    // Exported documents by collection
+	SnapshotTime primitive.Timestamp // Cluster time of the exported view
+}
+
+// ExportSnapshot exports a consistent view of the collections as extended JSON lines, one writer per collection
+// Every collection is read at the same point in time with snapshot reads, requires a replica set or sharded cluster 5.0+
+// The export must complete within the server snapshot history window, 5 minutes by default
+func (c *Client) ExportSnapshot(ctx context.Context, collections []string, writers ExportWriterFactory, opts *ExportOptions) (res *ExportResult, err error) {
+	if opts == nil {
+		opts = &ExportOptions{}
+	}
+	if len(collections) == 0 {
+		return nil, errors.New("no collection to export")
+	}
+
+	// Starts snapshot session
+	session, err := c.client.StartSession(options.Session().SetSnapshot(true))
+	if err != nil {
+		return nil, err
+	}
+	defer session.EndSession(ctx)
+	sessCtx := mongo.NewSessionContext(ctx, session)
+
+	// Exports
+	res = &ExportResult{Documents: map[string]int64{}}
+	for _, collection := range collections {
+		res.Documents[collection], err = c.exportCollection(sessCtx, collection, writers, opts)
+		if err != nil {
+			return res, errors.New("exporting " + collection + " -> " + err.Error())
+		}
+	}
+	if t := session.OperationTime(); t != nil {
+		res.SnapshotTime = *t
+	}
+
+	// Returns
+	return res, nil
+}
+
+// exportCollection writes the documents of the collection to its writer
+func (c *Client) exportCollection(ctx context.Context, collection string, writers ExportWriterFactory, opts *ExportOptions) (count int64, err error) {
+	// Opens writer
+	w, err := writers(collection)
+	if err != nil {
+		return 0, err
+	}
+	defer func() {
+		closeErr := w.Close()
+		if err == nil {
+			err = closeErr
+		}
+	}()
+	buf := bufio.NewWriter(w)
+
+	// Hits DB
+	findOpts := options.Find()
+	if opts.BatchSize != 0 {
+		findOpts.SetBatchSize(opts.BatchSize)
+	}
+	cursor, err := c.collection(ctx, collection).Find(ctx, bson.D{}, findOpts)
+	if err != nil {
+		return 0, err
+	}
+
+	// Close connection at the last
+	defer cursor.Close(ctx)
+
+	// Writes documents
+	for cursor.Next(ctx) {
+		line, err := bson.MarshalExtJSON(cursor.Current, opts.Canonical, false)
+		if err != nil {
+			return count, err
+		}
+		_, err = buf.Write(append(line, '\n'))
+		if err != nil {
+			return count, err
+		}
+		count++
+	}
+	err = cursor.Err()
+	if err != nil {
+		return count, err
+	}
+
+	// Returns
+	return count, buf.Flush()
+}
@@ -0,0 +1,179 @@
+package mongodb
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/bsontype"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// Expr is an aggregation expression evaluated server side against each document, e.g. Field("spent")
+// It marshals to its expression so it can also be used in update pipelines and $project stages
+type Expr struct {
+	value interface{}
+}
+
+// BoolExpr is an expression evaluating to a boolean, the only kind accepted as a write condition
+type BoolExpr struct {
+	value interface{}
+}
+
+// Field references the value of a dotted field path of the document
+func Field(path string) Expr {
+	return Expr{value: "$" + strings.TrimPrefix(path, "$")}
+}
+
+// Value is a constant, never interpreted as a field path or operator
+func Value(value interface{}) Expr {
+	return Expr{value: bson.D{{Key: "$literal", Value: value}}}
+}
+
+// Add ...
+func (e Expr) Add(others ...Expr) Expr {
+	return Expr{value: bson.D{{Key: "$add", Value: exprArgs(e, others)}}}
+}
+
+// Subtract ...
+func (e Expr) Subtract(other Expr) Expr {
+	return Expr{value: bson.D{{Key: "$subtract", Value: bson.A{e.value, other.value}}}}
+}
+
+// Multiply ...
+func (e Expr) Multiply(others ...Expr) Expr {
+	return Expr{value: bson.D{{Key: "$multiply", Value: exprArgs(e, others)}}}
+}
+
+// Divide ...
+func (e Expr) Divide(other Expr) Expr {
+	return Expr{value: bson.D{{Key: "$divide", Value: bson.A{e.value, other.value}}}}
+}
+
+// IfNull returns the fallback when the expression is null or missing, e.g. Field("budget").IfNull(Value(0))
+func (e Expr) IfNull(fallback Expr) Expr {
+	return Expr{value: bson.D{{Key: "$ifNull", Value: bson.A{e.value, fallback.value}}}}
+}
+
+// Size is the length of an array expression
+func (e Expr) Size() Expr {
+	return Expr{value: bson.D{{Key: "$size", Value: e.value}}}
+}
+
+// Eq ...
+func (e Expr) Eq(other Expr) BoolExpr {
+	return e.compare("$eq", other)
+}
+
+// Ne ...
+func (e Expr) Ne(other Expr) BoolExpr {
+	return e.compare("$ne", other)
+}
+
+// Gt ...
+func (e Expr) Gt(other Expr) BoolExpr {
+	return e.compare("$gt", other)
+}
+
+// Gte ...
+func (e Expr) Gte(other Expr) BoolExpr {
+	return e.compare("$gte", other)
+}
+
+// Lt ...
+func (e Expr) Lt(other Expr) BoolExpr {
+	return e.compare("$lt", other)
+}
+
+// Lte ...
+func (e Expr) Lte(other Expr) BoolExpr {
+	return e.compare("$lte", other)
+}
+
+// compare ...
+func (e Expr) compare(operator string, other Expr) BoolExpr {
+	return BoolExpr{value: bson.D{{Key: operator, Value: bson.A{e.value, other.value}}}}
+}
+
+// MarshalBSONValue ...
+func (e Expr) MarshalBSONValue() (bsontype.Type, []byte, error) {
+	return bson.MarshalValue(e.value)
+}
+
+// And is true when every condition is
+func And(conditions ...BoolExpr) BoolExpr {
+	return BoolExpr{value: bson.D{{Key: "$and", Value: boolArgs(conditions)}}}
+}
+
+// Or is true when any condition is
+func Or(conditions ...BoolExpr) BoolExpr {
+	return BoolExpr{value: bson.D{{Key: "$or", Value: boolArgs(conditions)}}}
+}
+
+// Not ...
+func Not(condition BoolExpr) BoolExpr {
+	return BoolExpr{value: bson.D{{Key: "$not", Value: bson.A{condition.value}}}}
+}
+
+// Filter returns the {$expr: condition} query document, it can be merged with regular filters with MergeFilters
+func (b BoolExpr) Filter() bson.D {
+	return bson.D{{Key: "$expr", Value: b.value}}
+}
+
+// MarshalBSONValue ...
+func (b BoolExpr) MarshalBSONValue() (bsontype.Type, []byte, error) {
+	return bson.MarshalValue(b.value)
+}
+
+// UpdateWhere applies the update to every document matching the query and the condition, e.g. flag campaigns where spent > budget
+// A nil query matches every document the condition holds for
+func (c *Client) UpdateWhere(ctx context.Context, collection string, query interface{}, condition BoolExpr, fields interface{}, opts ...*options.UpdateOptions) (matched int64, modified int64, err error) {
+	// Builds filter
+	filter, err := exprFilter(query, condition)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	// Returns
+	return c.UpdateMany(ctx, collection, filter, fields, opts...)
+}
+
+// DeleteWhere deletes every document matching the query and the condition, e.g. Field("spent").Gt(Field("budget"))
+// A nil query matches every document the condition holds for
+func (c *Client) DeleteWhere(ctx context.Context, collection string, query interface{}, condition BoolExpr, opts ...*options.DeleteOptions) (count int64, err error) {
+	// Builds filter
+	filter, err := exprFilter(query, condition)
+	if err != nil {
+		return 0, err
+	}
+
+	// Returns
+	return c.DeleteMany(ctx, collection, filter, opts...)
+}
+
+// exprFilter ...
+func exprFilter(query interface{}, condition BoolExpr) (filter bson.D, err error) {
+	if condition.value == nil {
+		return nil, errors.New("empty write condition")
+	}
+	return MergeFilters(query, condition.Filter())
+}
+
+// exprArgs ...
+func exprArgs(first Expr, others []Expr) bson.A {
+	args := bson.A{first.value}
+	for _, other := range others {
+		args = append(args, other.value)
+	}
+	return args
+}
+
+// boolArgs ...
+func boolArgs(conditions []BoolExpr) bson.A {
+	args := make(bson.A, len(conditions))
+	for i, condition := range conditions {
+		args[i] = condition.value
+	}
+	return args
+}
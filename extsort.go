@@ -0,0 +1,298 @@
+package mongodb
+
+import (
+	"bytes"
+	"container/heap"
+	"context"
+	"errors"
+	"strconv"
+	"strings"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/bsontype"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// Default number of documents sorted by the server per chunk of a chunked merge
+const defaultSortChunkSize = 10000
+
+// Sort order of bson types, as compared by the server
+var sortTypeOrder = map[bsontype.Type]int{
+	bsontype.MinKey: 0, bsontype.Type(0): 1, bsontype.Null: 1, bsontype.Undefined: 1,
+	bsontype.Double: 2, bsontype.Int32: 2, bsontype.Int64: 2, bsontype.Decimal128: 2,
+	bsontype.String: 3, bsontype.Symbol: 3, bsontype.EmbeddedDocument: 4, bsontype.Array: 5,
+	bsontype.Binary: 6, bsontype.ObjectID: 7, bsontype.Boolean: 8, bsontype.DateTime: 9,
+	bsontype.Timestamp: 10, bsontype.Regex: 11, bsontype.MaxKey: 12,
+}
+
+// SortOptions ...
+type SortOptions struct {
+	Projection interface{} // Projection of the documents, it must keep the sort fields
+	ChunkSize  int         // Documents sorted by the server per chunk when falling back to a chunked merge. (default is 10000)
+}
+
+// ReadSorted streams the documents matching the query in sort order to fn, for large sorted exports
+// A sort exceeding the server memory limit is retried as an aggregation allowed to use disk, and when that is refused too,
+// the documents are split into _id ranges along the _id index, each range is sorted by the server and the ranges are merged on client side
+// The merge compares values in the server order but strings by bytes, without collation, and arrays as a whole
+// Chunk cursors are opened without the idle cursor timeout as most of them wait for the others during the merge,
+// each holds its next batch on the server until it is exhausted or ReadSorted returns
+func (c *Client) ReadSorted(ctx context.Context, collection string, query interface{}, sort bson.D, opts *SortOptions, fn func(doc bson.Raw) error) (err error) {
+	if opts == nil {
+		opts = &SortOptions{}
+	}
+	if query == nil {
+		query = bson.D{}
+	}
+	sort = withTiebreaker(sort)
+	if sort == nil {
+		sort = bson.D{{Key: "_id", Value: 1}}
+	}
+
+	// Hits DB
+	coll, done := c.readCollection(ctx, collection)
+	defer func() { done(err) }()
+	cursor, err := coll.Find(ctx, query, options.Find().SetSort(sort).SetProjection(opts.Projection))
+	if err == nil {
+		return streamCursor(ctx, cursor, fn)
+	}
+	if !isMemoryLimit(err) {
+		return err
+	}
+
+	// Retries with disk use
+	pipeline := mongo.Pipeline{{{Key: "$match", Value: query}}, {{Key: "$sort", Value: sort}}}
+	if opts.Projection != nil {
+		pipeline = append(pipeline, bson.D{{Key: "$project", Value: opts.Projection}})
+	}
+	cursor, err = c.aggregate(ctx, collection, pipeline, options.Aggregate().SetAllowDiskUse(true))
+	if err == nil {
+		return streamCursor(ctx, cursor, fn)
+	}
+	var memErr *MemoryLimitError
+	if !errors.As(err, &memErr) && !strings.Contains(err.Error(), "allowDiskUse") {
+		return err
+	}
+
+	// Falls back to a chunked merge
+	return mergeSortedChunks(ctx, coll, query, sort, opts, fn)
+}
+
+// streamCursor passes every document of the cursor to fn, then closes it
+func streamCursor(ctx context.Context, cursor *mongo.Cursor, fn func(doc bson.Raw) error) (err error) {
+	// Close connection at the last
+	defer cursor.Close(ctx)
+
+	for cursor.Next(ctx) {
+		err = fn(cursor.Current)
+		if err != nil {
+			return err
+		}
+	}
+	return cursor.Err()
+}
+
+// mergeSortedChunks sorts _id ranges of at most ChunkSize documents on the server and merges them
+func mergeSortedChunks(ctx context.Context, coll *mongo.Collection, query interface{}, sort bson.D, opts *SortOptions, fn func(doc bson.Raw) error) (err error) {
+	chunkSize := opts.ChunkSize
+	if chunkSize <= 0 {
+		chunkSize = defaultSortChunkSize
+	}
+
+	// Reads chunk boundaries along the _id index, skipping a chunk of documents on the server per boundary
+	var bounds []bson.RawValue
+	boundOpts := options.FindOne().SetSort(bson.D{{Key: "_id", Value: 1}}).SetProjection(bson.D{{Key: "_id", Value: 1}})
+	boundFilter := query
+	for {
+		doc, err := coll.FindOne(ctx, boundFilter, boundOpts).DecodeBytes()
+		if err == mongo.ErrNoDocuments {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		bounds = append(bounds, doc.Lookup("_id"))
+		boundFilter = bson.D{{Key: "$and", Value: bson.A{query, bson.D{{Key: "_id", Value: bson.D{{Key: "$gte", Value: bounds[len(bounds)-1]}}}}}}}
+		boundOpts.SetSkip(int64(chunkSize))
+	}
+
+	// Opens a sorted cursor per chunk
+	merge := &sortMerge{sort: sort}
+	defer func() {
+		for _, chunk := range merge.chunks {
+			chunk.Close(ctx)
+		}
+	}()
+	for i, bound := range bounds {
+		idRange := bson.D{{Key: "$gte", Value: bound}}
+		if i+1 < len(bounds) {
+			idRange = append(idRange, bson.E{Key: "$lt", Value: bounds[i+1]})
+		}
+		filter := bson.D{{Key: "$and", Value: bson.A{query, bson.D{{Key: "_id", Value: idRange}}}}}
+		chunk, err := coll.Find(ctx, filter, options.Find().SetSort(sort).SetProjection(opts.Projection).SetNoCursorTimeout(true))
+		if err != nil {
+			return err
+		}
+		if !chunk.Next(ctx) {
+			err = chunk.Err()
+			chunk.Close(ctx)
+			if err != nil {
+				return err
+			}
+			continue
+		}
+		merge.chunks = append(merge.chunks, chunk)
+	}
+
+	// Merges
+	heap.Init(merge)
+	for merge.Len() > 0 {
+		chunk := merge.chunks[0]
+		err = fn(chunk.Current)
+		if err != nil {
+			return err
+		}
+		if chunk.Next(ctx) {
+			heap.Fix(merge, 0)
+			continue
+		}
+		if err = chunk.Err(); err != nil {
+			return err
+		}
+		chunk.Close(ctx)
+		heap.Pop(merge)
+	}
+
+	// Returns
+	return nil
+}
+
+// sortMerge is a heap of chunk cursors ordered by their current document
+type sortMerge struct {
+	sort   bson.D
+	chunks []*mongo.Cursor
+}
+
+// Len ...
+func (m *sortMerge) Len() int { return len(m.chunks) }
+
+// Less ...
+func (m *sortMerge) Less(i, j int) bool {
+	a, b := m.chunks[i].Current, m.chunks[j].Current
+	for _, e := range m.sort {
+		parts := strings.Split(e.Key, ".")
+		cmp := compareRawValues(lookupRaw(a, parts), lookupRaw(b, parts)) * sortDirection(e.Value)
+		if cmp != 0 {
+			return cmp < 0
+		}
+	}
+	return false
+}
+
+// Swap ...
+func (m *sortMerge) Swap(i, j int) { m.chunks[i], m.chunks[j] = m.chunks[j], m.chunks[i] }
+
+// Push ...
+func (m *sortMerge) Push(x interface{}) { m.chunks = append(m.chunks, x.(*mongo.Cursor)) }
+
+// Pop ...
+func (m *sortMerge) Pop() interface{} {
+	last := m.chunks[len(m.chunks)-1]
+	m.chunks = m.chunks[:len(m.chunks)-1]
+	return last
+}
+
+// lookupRaw returns the value at the path, a zero value when missing
+func lookupRaw(doc bson.Raw, parts []string) bson.RawValue {
+	value, err := doc.LookupErr(parts...)
+	if err != nil {
+		return bson.RawValue{}
+	}
+	return value
+}
+
+// compareRawValues compares two values in the server sort order, returns -1, 0 or 1
+func compareRawValues(a, b bson.RawValue) int {
+	// Compares types
+	orderA, orderB := sortTypeOrder[a.Type], sortTypeOrder[b.Type]
+	if orderA != orderB {
+		return compareInts(int64(orderA), int64(orderB))
+	}
+
+	// Compares values
+	switch orderA {
+	case 1:
+		return 0
+	case 2:
+		x, y := rawNumber(a), rawNumber(b)
+		switch {
+		case x < y:
+			return -1
+		case x > y:
+			return 1
+		}
+		return 0
+	case 3:
+		x, _ := a.StringValueOK()
+		y, _ := b.StringValueOK()
+		return strings.Compare(x, y)
+	case 6:
+		subA, x := a.Binary()
+		subB, y := b.Binary()
+		if len(x) != len(y) {
+			return compareInts(int64(len(x)), int64(len(y)))
+		}
+		if subA != subB {
+			return compareInts(int64(subA), int64(subB))
+		}
+		return bytes.Compare(x, y)
+	case 7:
+		x, y := a.ObjectID(), b.ObjectID()
+		return bytes.Compare(x[:], y[:])
+	case 8:
+		x, y := a.Boolean(), b.Boolean()
+		if x == y {
+			return 0
+		}
+		if !x {
+			return -1
+		}
+		return 1
+	case 9:
+		return compareInts(a.DateTime(), b.DateTime())
+	case 10:
+		tA, iA := a.Timestamp()
+		tB, iB := b.Timestamp()
+		if tA != tB {
+			return compareInts(int64(tA), int64(tB))
+		}
+		return compareInts(int64(iA), int64(iB))
+	}
+	return bytes.Compare(a.Value, b.Value)
+}
+
+// rawNumber ...
+func rawNumber(value bson.RawValue) float64 {
+	switch value.Type {
+	case bsontype.Int32:
+		return float64(value.Int32())
+	case bsontype.Int64:
+		return float64(value.Int64())
+	case bsontype.Decimal128:
+		f, _ := strconv.ParseFloat(value.Decimal128().String(), 64)
+		return f
+	}
+	return value.Double()
+}
+
+// compareInts ...
+func compareInts(a, b int64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
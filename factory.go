@@ -0,0 +1,235 @@
+package mongodb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"strings"
+	"sync"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// Word lists of the faker style generators
+var (
+	fakeFirstNames = []string{"Aarav", "Ana", "Chen", "David", "Emma", "Fatima", "Hiro", "Isabel", "James", "Kofi", "Lena", "Maria", "Noah", "Olga", "Priya", "Sofia", "Tomas", "Yusuf", "Zara", "Wei"}
+	fakeLastNames  = []string{"Garcia", "Ivanova", "Kim", "Kumar", "Martin", "Mensah", "Muller", "Nguyen", "Okafor", "Rossi", "Sato", "Sharma", "Silva", "Smith", "Tan", "Wang"}
+	fakeWords      = []string{"alpha", "amber", "bright", "cloud", "delta", "ember", "field", "golden", "harbor", "island", "jade", "lunar", "maple", "north", "ocean", "prime", "quiet", "river", "silver", "stone", "swift", "urban", "vivid", "willow"}
+	fakeDomains    = []string{"example.com", "example.org", "example.net", "mail.test"}
+)
+
+// FieldGenerator generates a field value, n is the position of the document in the generated batch
+type FieldGenerator func(r *rand.Rand, n int) interface{}
+
+// Template describes the fields of generated documents
+// Field values are constants, FieldGenerators or nested *Templates
+type Template struct {
+	fields bson.D
+}
+
+// NewTemplate ...
+func NewTemplate() *Template {
+	return &Template{}
+}
+
+// Field sets the value of a field, fields are generated in the order they were set
+func (t *Template) Field(name string, value interface{}) *Template {
+	for i, e := range t.fields {
+		if e.Key == name {
+			t.fields[i].Value = value
+			return t
+		}
+	}
+	t.fields = append(t.fields, bson.E{Key: name, Value: value})
+	return t
+}
+
+// build ...
+func (t *Template) build(r *rand.Rand, n int) bson.D {
+	doc := make(bson.D, 0, len(t.fields))
+	for _, e := range t.fields {
+		doc = append(doc, bson.E{Key: e.Key, Value: generateValue(e.Value, r, n)})
+	}
+	return doc
+}
+
+// generateValue ...
+func generateValue(value interface{}, r *rand.Rand, n int) interface{} {
+	switch v := value.(type) {
+	case FieldGenerator:
+		return v(r, n)
+	case func(r *rand.Rand, n int) interface{}:
+		return v(r, n)
+	case *Template:
+		return v.build(r, n)
+	}
+	return value
+}
+
+// Factory generates documents from named templates, seeded so runs are reproducible
+type Factory struct {
+	mu        sync.Mutex
+	rand      *rand.Rand
+	templates map[string]*Template
+}
+
+// NewFactory ...
+func NewFactory(seed int64) *Factory {
+	return &Factory{rand: rand.New(rand.NewSource(seed)), templates: map[string]*Template{}}
+}
+
+// Define registers a template under a name
+func (f *Factory) Define(name string, template *Template) *Factory {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.templates[name] = template
+	return f
+}
+
+// Build generates a document of the template, overrides replace or add fields by dotted path and may be FieldGenerators
+func (f *Factory) Build(name string, overrides map[string]interface{}) (doc bson.D, err error) {
+	docs, err := f.BuildMany(name, 1, overrides)
+	if err != nil {
+		return nil, err
+	}
+	return docs[0].(bson.D), nil
+}
+
+// BuildMany generates count documents of the template
+func (f *Factory) BuildMany(name string, count int, overrides map[string]interface{}) (docs []interface{}, err error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	// Gets template
+	template, ok := f.templates[name]
+	if !ok {
+		return nil, errors.New("unknown template " + name)
+	}
+
+	// Generates
+	docs = make([]interface{}, count)
+	for n := 0; n < count; n++ {
+		doc := template.build(f.rand, n)
+		for _, path := range sortedKeys(overrides) {
+			doc = setPath(doc, strings.Split(path, "."), generateValue(overrides[path], f.rand, n))
+		}
+		docs[n] = doc
+	}
+
+	// Returns
+	return docs, nil
+}
+
+// Seed generates count documents of the template and inserts them into the collection in batches
+func (f *Factory) Seed(ctx context.Context, client *Client, collection string, name string, count int, overrides map[string]interface{}) (inserted int, err error) {
+	// Generates
+	docs, err := f.BuildMany(name, count, overrides)
+	if err != nil {
+		return 0, err
+	}
+
+	// Hits DB
+	return client.InsertMany(ctx, collection, docs, nil)
+}
+
+// Sequence generates "<prefix><n>", e.g. Sequence("user-") gives user-0, user-1...
+func Sequence(prefix string) FieldGenerator {
+	return func(r *rand.Rand, n int) interface{} {
+		return fmt.Sprintf("%s%d", prefix, n)
+	}
+}
+
+// OneOf picks one of the values
+func OneOf(values ...interface{}) FieldGenerator {
+	return func(r *rand.Rand, n int) interface{} {
+		return values[r.Intn(len(values))]
+	}
+}
+
+// IntBetween generates an int64 in [min, max]
+func IntBetween(min, max int64) FieldGenerator {
+	return func(r *rand.Rand, n int) interface{} {
+		return min + r.Int63n(max-min+1)
+	}
+}
+
+// FloatBetween generates a float64 in [min, max)
+func FloatBetween(min, max float64) FieldGenerator {
+	return func(r *rand.Rand, n int) interface{} {
+		return min + r.Float64()*(max-min)
+	}
+}
+
+// TimeBetween generates a UTC time in [from, to), truncated to milliseconds as stored by mongodb
+func TimeBetween(from, to time.Time) FieldGenerator {
+	return func(r *rand.Rand, n int) interface{} {
+		return from.Add(time.Duration(r.Int63n(int64(to.Sub(from))))).UTC().Truncate(time.Millisecond)
+	}
+}
+
+// Bool generates true with the given probability
+func Bool(probability float64) FieldGenerator {
+	return func(r *rand.Rand, n int) interface{} {
+		return r.Float64() < probability
+	}
+}
+
+// FirstName ...
+func FirstName() FieldGenerator {
+	return OneOf(toInterfaces(fakeFirstNames)...)
+}
+
+// LastName ...
+func LastName() FieldGenerator {
+	return OneOf(toInterfaces(fakeLastNames)...)
+}
+
+// FullName ...
+func FullName() FieldGenerator {
+	return func(r *rand.Rand, n int) interface{} {
+		return fakeFirstNames[r.Intn(len(fakeFirstNames))] + " " + fakeLastNames[r.Intn(len(fakeLastNames))]
+	}
+}
+
+// Email generates an address unique within the batch
+func Email() FieldGenerator {
+	return func(r *rand.Rand, n int) interface{} {
+		first := strings.ToLower(fakeFirstNames[r.Intn(len(fakeFirstNames))])
+		last := strings.ToLower(fakeLastNames[r.Intn(len(fakeLastNames))])
+		return fmt.Sprintf("%s.%s%d@%s", first, last, n, fakeDomains[r.Intn(len(fakeDomains))])
+	}
+}
+
+// Words generates count space separated words
+func Words(count int) FieldGenerator {
+	return func(r *rand.Rand, n int) interface{} {
+		words := make([]string, count)
+		for i := range words {
+			words[i] = fakeWords[r.Intn(len(fakeWords))]
+		}
+		return strings.Join(words, " ")
+	}
+}
+
+// ArrayOf generates between min and max elements with the generator
+func ArrayOf(min, max int, generator FieldGenerator) FieldGenerator {
+	return func(r *rand.Rand, n int) interface{} {
+		values := make(bson.A, min+r.Intn(max-min+1))
+		for i := range values {
+			values[i] = generator(r, n)
+		}
+		return values
+	}
+}
+
+// toInterfaces ...
+func toInterfaces(values []string) []interface{} {
+	res := make([]interface{}, len(values))
+	for i, v := range values {
+		res[i] = v
+	}
+	return res
+}
@@ -0,0 +1,111 @@
+package mongodb
+
+import (
+	"context"
+	"errors"
+	"sync"
+)
+
+// Default number of concurrent FetchMany queries
+const defaultFetchParallelism = 4
+
+// FetchRequest is a single query of FetchMany, a find or an aggregation when Pipeline is set
+type FetchRequest struct {
+	Name       string      // Key of the request results
+	Collection string      // Collection to query
+	Query      interface{} // Find filter, ignored for aggregations
+	Projection interface{} // Find projection, ignored for aggregations
+	Pipeline   interface{} // Aggregation pipeline
+}
+
+// FetchMany runs the requests concurrently and returns their results keyed by request name
+// At most Config.FetchParallelism requests run at once, the first failure cancels the remaining ones
+func (c *Client) FetchMany(ctx context.Context, requests []FetchRequest) (res map[string][]interface{}, err error) {
+	// Validates names
+	res = make(map[string][]interface{}, len(requests))
+	for _, r := range requests {
+		if _, ok := res[r.Name]; ok {
+			return nil, errors.New("duplicate fetch request name " + r.Name)
+		}
+		res[r.Name] = nil
+	}
+
+	// Gets parallelism
+	parallelism := c.fetchParallelism
+	if parallelism <= 0 {
+		parallelism = defaultFetchParallelism
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	// Runs requests
+	var (
+		wg       sync.WaitGroup
+		mu       sync.Mutex
+		firstErr error
+		slots    = make(chan struct{}, parallelism)
+	)
+	for _, r := range requests {
+		r := r
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			// Waits for a slot
+			select {
+			case slots <- struct{}{}:
+				defer func() { <-slots }()
+			case <-ctx.Done():
+				return
+			}
+
+			// Hits DB
+			docs, err := c.fetch(ctx, r)
+
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				if firstErr == nil {
+					firstErr = errors.New("fetch request " + r.Name + " failed -> " + err.Error())
+					cancel()
+				}
+				return
+			}
+			res[r.Name] = docs
+		}()
+	}
+	wg.Wait()
+	if firstErr != nil {
+		return nil, firstErr
+	}
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	// Returns
+	return res, nil
+}
+
+// fetch runs a single request
+func (c *Client) fetch(ctx context.Context, r FetchRequest) (res []interface{}, err error) {
+	// Runs aggregation
+	if r.Pipeline != nil {
+		cursor, err := c.aggregate(ctx, r.Collection, r.Pipeline, nil)
+		if err != nil {
+			return nil, err
+		}
+		defer cursor.Close(ctx)
+		err = cursor.All(ctx, &res)
+		if err != nil {
+			return nil, aggregateError(r.Collection, err)
+		}
+		return res, nil
+	}
+
+	// Runs find
+	if r.Projection != nil {
+		return c.ReadWithProjection(ctx, r.Collection, r.Query, r.Projection)
+	}
+	return c.Read(ctx, r.Collection, r.Query)
+}
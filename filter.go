@@ -0,0 +1,99 @@
+package mongodb
+
+import (
+	"fmt"
+	"strings"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// FilterConflictError is returned when merged filters constrain _id to different values
+// Such a merge could never match a document, it is almost always a bug in a layer stacking filters
+type FilterConflictError struct {
+	Field  string
+	Values []interface{}
+}
+
+// Error ...
+func (e *FilterConflictError) Error() string {
+	return fmt.Sprintf("conflicting filters on field %s -> %v", e.Field, e.Values)
+}
+
+// MergeFilters combines filter documents with $and semantics
+// Nil and empty filters are skipped, a single filter is returned as is
+// Direct equality constraints ({_id: value} or {_id: {$eq: value}}) on _id with different values return a FilterConflictError
+// Other fields are not checked, different equalities still match an array holding both values
+func MergeFilters(filters ...interface{}) (merged bson.D, err error) {
+	// Converts
+	docs := make([]bson.D, 0, len(filters))
+	for _, filter := range filters {
+		if filter == nil {
+			continue
+		}
+		doc, err := toDoc(filter)
+		if err != nil {
+			return nil, err
+		}
+		if len(doc) != 0 {
+			docs = append(docs, doc)
+		}
+	}
+
+	// Detects conflicts
+	equals := map[string]interface{}{}
+	keys := map[string]string{}
+	for _, doc := range docs {
+		for _, e := range doc {
+			if e.Key != "_id" {
+				continue
+			}
+			value, ok := equalityValue(e)
+			if !ok {
+				continue
+			}
+			key, err := idKey(value)
+			if err != nil {
+				return nil, err
+			}
+			if previous, ok := keys[e.Key]; ok && previous != key {
+				return nil, &FilterConflictError{Field: e.Key, Values: []interface{}{equals[e.Key], value}}
+			}
+			keys[e.Key] = key
+			equals[e.Key] = value
+		}
+	}
+
+	// Merges
+	switch len(docs) {
+	case 0:
+		return bson.D{}, nil
+	case 1:
+		return docs[0], nil
+	}
+	clauses := make(bson.A, 0, len(docs))
+	for _, doc := range docs {
+		clauses = append(clauses, doc)
+	}
+
+	// Returns
+	return bson.D{{Key: "$and", Value: clauses}}, nil
+}
+
+// equalityValue returns the value a filter element directly constrains its field to, a regular expression value is a pattern match
+func equalityValue(e bson.E) (value interface{}, ok bool) {
+	if strings.HasPrefix(e.Key, "$") {
+		return nil, false
+	}
+	if _, isRegex := e.Value.(primitive.Regex); isRegex {
+		return nil, false
+	}
+	doc, isDoc := e.Value.(bson.D)
+	if !isDoc || len(doc) == 0 || !strings.HasPrefix(doc[0].Key, "$") {
+		return e.Value, true
+	}
+	if len(doc) == 1 && doc[0].Key == "$eq" {
+		return doc[0].Value, true
+	}
+	return nil, false
+}
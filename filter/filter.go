@@ -0,0 +1,125 @@
+// Package filter builds query filters from typed helpers composing into bson.D, e.g.
+// filter.And(filter.Eq("status", "paid"), filter.Gt("total", 100), filter.In("tags", "a", "b"))
+// Values are always wrapped in an operator so they are never read as operators themselves
+package filter
+
+import (
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// Eq matches documents whose field equals the value
+func Eq(field string, value interface{}) bson.D {
+	return condition(field, "$eq", value)
+}
+
+// Ne matches documents whose field differs from the value or is missing
+func Ne(field string, value interface{}) bson.D {
+	return condition(field, "$ne", value)
+}
+
+// In matches documents whose field equals one of the values, e.g. In("status", statuses...)
+func In[T any](field string, values ...T) bson.D {
+	return condition(field, "$in", list(values))
+}
+
+// Nin matches documents whose field equals none of the values or is missing
+func Nin[T any](field string, values ...T) bson.D {
+	return condition(field, "$nin", list(values))
+}
+
+// Gt ...
+func Gt(field string, value interface{}) bson.D {
+	return condition(field, "$gt", value)
+}
+
+// Gte ...
+func Gte(field string, value interface{}) bson.D {
+	return condition(field, "$gte", value)
+}
+
+// Lt ...
+func Lt(field string, value interface{}) bson.D {
+	return condition(field, "$lt", value)
+}
+
+// Lte ...
+func Lte(field string, value interface{}) bson.D {
+	return condition(field, "$lte", value)
+}
+
+// Exists matches documents having the field when exists is true, else documents missing it
+func Exists(field string, exists bool) bson.D {
+	return condition(field, "$exists", exists)
+}
+
+// Regex matches documents whose string field matches the pattern, options are regex flags such as "i" (default is none)
+func Regex(field string, pattern string, options string) bson.D {
+	operators := bson.D{{Key: "$regex", Value: pattern}}
+	if options != "" {
+		operators = append(operators, bson.E{Key: "$options", Value: options})
+	}
+	return bson.D{{Key: field, Value: operators}}
+}
+
+// ElemMatch matches documents whose array field has an element matching every filter, e.g.
+// ElemMatch("items", Eq("sku", "A1"), Gte("qty", 2)) for arrays of documents
+func ElemMatch(field string, filters ...bson.D) bson.D {
+	conditions := bson.D{}
+	for _, f := range filters {
+		conditions = append(conditions, f...)
+	}
+	return condition(field, "$elemMatch", conditions)
+}
+
+// And matches documents matching every filter, a single filter is returned as is and none matches every document
+func And(filters ...bson.D) bson.D {
+	switch len(filters) {
+	case 0:
+		return bson.D{}
+	case 1:
+		return filters[0]
+	}
+	return bson.D{{Key: "$and", Value: clauses(filters)}}
+}
+
+// Or matches documents matching any filter, a single filter is returned as is and none matches no document
+func Or(filters ...bson.D) bson.D {
+	switch len(filters) {
+	case 0:
+		return bson.D{{Key: "$expr", Value: false}}
+	case 1:
+		return filters[0]
+	}
+	return bson.D{{Key: "$or", Value: clauses(filters)}}
+}
+
+// Nor matches documents matching none of the filters
+func Nor(filters ...bson.D) bson.D {
+	if len(filters) == 0 {
+		return bson.D{}
+	}
+	return bson.D{{Key: "$nor", Value: clauses(filters)}}
+}
+
+// condition ...
+func condition(field string, operator string, value interface{}) bson.D {
+	return bson.D{{Key: field, Value: bson.D{{Key: operator, Value: value}}}}
+}
+
+// list converts values to an array, no values are an empty array so $in matches nothing instead of failing
+func list[T any](values []T) bson.A {
+	res := make(bson.A, len(values))
+	for i, v := range values {
+		res[i] = v
+	}
+	return res
+}
+
+// clauses ...
+func clauses(filters []bson.D) bson.A {
+	res := make(bson.A, len(filters))
+	for i, f := range filters {
+		res[i] = f
+	}
+	return res
+}
@@ -0,0 +1,305 @@
+package mongodb
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// Default loader settings
+const (
+	defaultLoaderWait     = time.Millisecond
+	defaultLoaderMaxBatch = 100
+)
+
+// LoaderOptions ...
+type LoaderOptions struct {
+	Wait       int         // In milliseconds, How long a batch collects ids before it is queried. (default is 1 millisecond)
+	MaxBatch   int         // Ids queried at once, a full batch is queried without waiting. (default is 100)
+	Projection interface{} // Projection of the loaded documents, e.g. ProjectionFromFields of the requested fields (default is nil, whole documents)
+}
+
+// Loader batches the loads by id of concurrent GraphQL resolvers into single $in queries and caches the results
+// Create one per request so the cache never outlives it
+type Loader struct {
+	client     *Client
+	ctx        context.Context
+	collection string
+	opts       *LoaderOptions
+	mu         sync.Mutex
+	pending    *loaderBatch
+	results    map[string]*loaderBatch // Batches by id key, cached for the life of the loader
+}
+
+// loaderBatch is a set of ids queried together
+type loaderBatch struct {
+	ids  []interface{}
+	keys map[string]bool
+	docs map[string]interface{} // Documents by id key
+	err  error
+	done chan struct{}
+}
+
+// NewLoader returns a loader of the documents of the collection, batches are queried with the context of the request
+func (c *Client) NewLoader(ctx context.Context, collection string, opts *LoaderOptions) *Loader {
+	if opts == nil {
+		opts = &LoaderOptions{}
+	}
+	return &Loader{client: c, ctx: ctx, collection: collection, opts: opts, results: map[string]*loaderBatch{}}
+}
+
+// Load returns the document of the id, nil when none has it
+func (l *Loader) Load(ctx context.Context, id interface{}) (doc interface{}, err error) {
+	key, err := idKey(id)
+	if err != nil {
+		return nil, err
+	}
+
+	// Joins batch
+	l.mu.Lock()
+	batch, ok := l.results[key]
+	if !ok {
+		batch = l.pending
+		if batch == nil {
+			batch = &loaderBatch{keys: map[string]bool{}, done: make(chan struct{})}
+			l.pending = batch
+			wait := defaultLoaderWait
+			if l.opts.Wait != 0 {
+				wait = time.Duration(l.opts.Wait) * time.Millisecond
+			}
+			time.AfterFunc(wait, func() { l.dispatch(batch) })
+		}
+		batch.ids = append(batch.ids, id)
+		batch.keys[key] = true
+		l.results[key] = batch
+		maxBatch := l.opts.MaxBatch
+		if maxBatch <= 0 {
+			maxBatch = defaultLoaderMaxBatch
+		}
+		if len(batch.ids) >= maxBatch {
+			l.pending = nil
+			go l.dispatch(batch)
+		}
+	}
+	l.mu.Unlock()
+
+	// Waits
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case <-batch.done:
+	}
+
+	// Returns
+	if batch.err != nil {
+		return nil, batch.err
+	}
+	return batch.docs[key], nil
+}
+
+// LoadMany returns the documents of the ids in their order, nil for ids no document has
+func (l *Loader) LoadMany(ctx context.Context, ids []interface{}) (docs []interface{}, err error) {
+	docs = make([]interface{}, len(ids))
+	errs := make([]error, len(ids))
+	var wg sync.WaitGroup
+	for i, id := range ids {
+		wg.Add(1)
+		go func(i int, id interface{}) {
+			defer wg.Done()
+			docs[i], errs[i] = l.Load(ctx, id)
+		}(i, id)
+	}
+	wg.Wait()
+	for _, err = range errs {
+		if err != nil {
+			return nil, err
+		}
+	}
+	return docs, nil
+}
+
+// Clear removes the id from the cache, e.g. after a mutation updated its document
+func (l *Loader) Clear(id interface{}) {
+	key, err := idKey(id)
+	if err != nil {
+		return
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if batch, ok := l.results[key]; ok && batch != l.pending {
+		delete(l.results, key)
+	}
+}
+
+// dispatch queries the ids of the batch once
+func (l *Loader) dispatch(batch *loaderBatch) {
+	l.mu.Lock()
+	if batch.docs != nil || batch.err != nil {
+		l.mu.Unlock()
+		return
+	}
+	if l.pending == batch {
+		l.pending = nil
+	}
+	batch.docs = map[string]interface{}{}
+	l.mu.Unlock()
+
+	// Hits DB
+	var res []interface{}
+	var err error
+	query := bson.D{{Key: "_id", Value: bson.D{{Key: "$in", Value: batch.ids}}}}
+	if l.opts.Projection != nil {
+		res, err = l.client.ReadWithProjection(l.ctx, l.collection, query, l.opts.Projection)
+	} else {
+		res, err = l.client.Read(l.ctx, l.collection, query)
+	}
+
+	// Indexes documents by id
+	for _, doc := range res {
+		d, ok := doc.(bson.D)
+		if !ok {
+			continue
+		}
+		id, _ := getPath(d, []string{"_id"})
+		key, keyErr := idKey(id)
+		if keyErr == nil && batch.keys[key] {
+			batch.docs[key] = doc
+		}
+	}
+	batch.err = err
+	close(batch.done)
+}
+
+// PageInfo is the page information of a Relay connection
+type PageInfo struct {
+	HasNextPage     bool   `json:"hasNextPage"`
+	HasPreviousPage bool   `json:"hasPreviousPage"`
+	StartCursor     string `json:"startCursor"`
+	EndCursor       string `json:"endCursor"`
+}
+
+// RelayEdge is a document of a Relay connection with its cursor
+type RelayEdge struct {
+	Cursor string      `json:"cursor"`
+	Node   interface{} `json:"node"`
+}
+
+// RelayConnection is a page of documents shaped as a Relay connection
+type RelayConnection struct {
+	Edges    []*RelayEdge `json:"edges"`
+	PageInfo PageInfo     `json:"pageInfo"`
+}
+
+// ConnectionArgs are the forward pagination arguments of a Relay connection field
+type ConnectionArgs struct {
+	First      int64       // Page size, required
+	After      string      // Cursor of the edge the page starts after (default is empty, first page)
+	Filter     interface{} // Filter of the connection (default is nil, every document)
+	Sort       bson.D      // Sort of the connection, an _id tiebreaker is appended. (default is _id ascending)
+	Projection interface{} // Projection of the nodes, it must keep the sort fields (default is nil, whole documents)
+}
+
+// ReadConnection returns a page of the documents of the collection as a Relay connection, paginated with keyset cursors
+// HasPreviousPage is set when the page starts after a cursor, backward pagination is not supported
+func (c *Client) ReadConnection(ctx context.Context, collection string, args *ConnectionArgs) (conn *RelayConnection, err error) {
+	if args.First <= 0 {
+		return nil, errors.New("connection first must be positive")
+	}
+
+	// Builds query
+	sortFields := args.Sort
+	if len(sortFields) == 0 {
+		sortFields = bson.D{{Key: "_id", Value: 1}}
+	}
+	sortFields = withTiebreaker(sortFields)
+	filter, err := MergeFilters(args.Filter)
+	if err != nil {
+		return nil, err
+	}
+	if args.After != "" {
+		position, err := cursorFilter(sortFields, args.After)
+		if err != nil {
+			return nil, errors.New("invalid connection cursor -> " + err.Error())
+		}
+		filter, err = MergeFilters(filter, position)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if filter == nil {
+		filter = bson.D{}
+	}
+
+	// Hits DB, one more document tells whether a next page exists, the page is bounded by its limit rather than the result limits
+	findOpts := options.Find().SetSort(sortFields).SetLimit(args.First + 1)
+	if args.Projection != nil {
+		findOpts.SetProjection(args.Projection)
+	}
+	res, err := c.Read(WithResultLimits(ctx, nil), collection, filter, findOpts)
+	if err != nil {
+		return nil, err
+	}
+
+	// Builds edges
+	conn = &RelayConnection{Edges: []*RelayEdge{}}
+	conn.PageInfo.HasPreviousPage = args.After != ""
+	if int64(len(res)) > args.First {
+		res = res[:args.First]
+		conn.PageInfo.HasNextPage = true
+	}
+	for _, doc := range res {
+		cursor, err := keysetCursor(sortFields, doc)
+		if err != nil {
+			return nil, err
+		}
+		conn.Edges = append(conn.Edges, &RelayEdge{Cursor: cursor, Node: doc})
+	}
+	if len(conn.Edges) != 0 {
+		conn.PageInfo.StartCursor = conn.Edges[0].Cursor
+		conn.PageInfo.EndCursor = conn.Edges[len(conn.Edges)-1].Cursor
+	}
+
+	// Returns
+	return conn, nil
+}
+
+// ProjectionFromFields derives the projection of the requested GraphQL fields, e.g. the dotted paths collected from the resolver info
+// fieldMap maps GraphQL field names to document paths where they differ, e.g. {"id": "_id"}
+// Paths under a requested parent are dropped as the parent already includes them
+func ProjectionFromFields(fields []string, fieldMap map[string]string) (projection bson.D) {
+	// Maps paths
+	paths := make([]string, 0, len(fields))
+	for _, field := range fields {
+		if path, ok := fieldMap[field]; ok {
+			field = path
+		}
+		paths = append(paths, field)
+	}
+	sort.Strings(paths)
+
+	// Drops covered paths, parents sort before their children
+	kept := map[string]bool{}
+	projection = bson.D{}
+	for _, path := range paths {
+		parts := strings.Split(path, ".")
+		covered := false
+		for i := 1; i <= len(parts); i++ {
+			if kept[strings.Join(parts[:i], ".")] {
+				covered = true
+				break
+			}
+		}
+		if covered {
+			continue
+		}
+		kept[path] = true
+		projection = append(projection, bson.E{Key: path, Value: 1})
+	}
+	return projection
+}
@@ -0,0 +1,240 @@
+package mongodb
+
+import (
+	"context"
+	"io"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/gridfs"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// GridFSConfig sets the bucket of the GridFS APIs
+type GridFSConfig struct {
+	BucketName string // Prefix of the files and chunks collections. (default is "fs")
+	ChunkSize  int32  // In bytes, Size of the chunks files are split into. (default is 255 KiB)
+}
+
+// GridFSFile describes a stored file
+type GridFSFile struct {
+	ID         interface{} `bson:"_id"`
+	Name       string      `bson:"filename"`
+	Length     int64       `bson:"length"`
+	ChunkSize  int32       `bson:"chunkSize"`
+	UploadDate time.Time   `bson:"uploadDate"`
+	Metadata   bson.M      `bson:"metadata,omitempty"`
+}
+
+// UploadFile stores the content of the source as a file of the configured bucket, with no size limit
+// metadata is stored along the file and can be filtered on with ListFiles, e.g. bson.M{"report": "monthly"}
+func (c *Client) UploadFile(ctx context.Context, filename string, source io.Reader, metadata interface{}) (id primitive.ObjectID, err error) {
+	// Gets bucket
+	bucket, err := c.gridFSBucket(ctx)
+	if err != nil {
+		return id, err
+	}
+	uploadOpts := options.GridFSUpload()
+	if metadata != nil {
+		uploadOpts.SetMetadata(metadata)
+	}
+
+	// Hits DB
+	id, err = bucket.UploadFromStream(filename, source, uploadOpts)
+	if err != nil {
+		return id, err
+	}
+
+	// Returns
+	return id, nil
+}
+
+// DownloadFile writes the content of the file to the destination and returns its size
+// gridfs.ErrFileNotFound is returned when no file has the id
+func (c *Client) DownloadFile(ctx context.Context, id interface{}, destination io.Writer) (size int64, err error) {
+	// Gets bucket
+	bucket, err := c.gridFSBucket(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	// Hits DB
+	size, err = bucket.DownloadToStream(id, destination)
+	if err != nil {
+		return size, err
+	}
+
+	// Returns
+	return size, nil
+}
+
+// DeleteFile deletes the file and its chunks
+// gridfs.ErrFileNotFound is returned when no file has the id
+func (c *Client) DeleteFile(ctx context.Context, id interface{}) (err error) {
+	// Gets bucket
+	bucket, err := c.gridFSBucket(ctx)
+	if err != nil {
+		return err
+	}
+
+	// Hits DB
+	return bucket.DeleteContext(ctx, id)
+}
+
+// ListFiles returns the files of the bucket matching the filter on the files collection, e.g. bson.M{"metadata.report": "monthly"}
+func (c *Client) ListFiles(ctx context.Context, filter interface{}) (files []*GridFSFile, err error) {
+	// Gets bucket
+	bucket, err := c.gridFSBucket(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if filter == nil {
+		filter = bson.M{}
+	}
+
+	// Hits DB
+	cursor, err := bucket.FindContext(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	// Binds cursor response
+	files = []*GridFSFile{}
+	err = cursor.All(ctx, &files)
+	if err != nil {
+		return nil, err
+	}
+
+	// Returns
+	return files, nil
+}
+
+// gridFSBucket returns a bucket of the configured database, buckets hold buffers and deadlines so one is created per call
+// The driver bounds GridFS uploads and downloads with deadlines, the deadline of the context is applied
+func (c *Client) gridFSBucket(ctx context.Context) (bucket *gridfs.Bucket, err error) {
+	// Sets options
+	bucketOpts := options.GridFSBucket()
+	if c.gridFS != nil {
+		if c.gridFS.BucketName != "" {
+			bucketOpts.SetName(c.gridFS.BucketName)
+		}
+		if c.gridFS.ChunkSize != 0 {
+			bucketOpts.SetChunkSizeBytes(c.gridFS.ChunkSize)
+		}
+	}
+
+	// Creates bucket
+	bucket, err = gridfs.NewBucket(c.database, bucketOpts)
+	if err != nil {
+		return nil, err
+	}
+	if deadline, ok := ctx.Deadline(); ok {
+		bucket.SetReadDeadline(deadline)
+		bucket.SetWriteDeadline(deadline)
+	}
+
+	// Returns
+	return bucket, nil
+}
+
+// GridFSUploadStream writes a file chunk by chunk, the file is stored once the stream is closed
+type GridFSUploadStream struct {
+	ID     primitive.ObjectID // Id of the file being written
+	stream *gridfs.UploadStream
+}
+
+// Write ...
+func (s *GridFSUploadStream) Write(p []byte) (n int, err error) {
+	return s.stream.Write(p)
+}
+
+// Close writes the last chunk and stores the file
+func (s *GridFSUploadStream) Close() (err error) {
+	return s.stream.Close()
+}
+
+// Abort deletes the chunks written so far instead of storing the file, e.g. when the request body fails midway
+func (s *GridFSUploadStream) Abort() (err error) {
+	return s.stream.Abort()
+}
+
+// GridFSDownloadStream reads a file chunk by chunk
+type GridFSDownloadStream struct {
+	File   *GridFSFile // Stored file, e.g. its Length for a Content-Length header
+	stream *gridfs.DownloadStream
+}
+
+// Read ...
+func (s *GridFSDownloadStream) Read(p []byte) (n int, err error) {
+	return s.stream.Read(p)
+}
+
+// Close ...
+func (s *GridFSDownloadStream) Close() (err error) {
+	return s.stream.Close()
+}
+
+// OpenUploadStream returns a writer of a new file of the configured bucket, only one chunk is held in memory
+// e.g. io.Copy(stream, r.Body) stores an HTTP request body of any size, Abort the stream when the copy fails
+func (c *Client) OpenUploadStream(ctx context.Context, filename string, metadata interface{}) (stream *GridFSUploadStream, err error) {
+	// Gets bucket
+	bucket, err := c.gridFSBucket(ctx)
+	if err != nil {
+		return nil, err
+	}
+	uploadOpts := options.GridFSUpload()
+	if metadata != nil {
+		uploadOpts.SetMetadata(metadata)
+	}
+
+	// Opens stream
+	id := primitive.NewObjectID()
+	upload, err := bucket.OpenUploadStreamWithID(id, filename, uploadOpts)
+	if err != nil {
+		return nil, err
+	}
+	if deadline, ok := ctx.Deadline(); ok {
+		upload.SetWriteDeadline(deadline)
+	}
+
+	// Returns
+	return &GridFSUploadStream{ID: id, stream: upload}, nil
+}
+
+// OpenDownloadStream returns a reader of the file, chunks are fetched as they are read
+// gridfs.ErrFileNotFound is returned when no file has the id
+func (c *Client) OpenDownloadStream(ctx context.Context, id interface{}) (stream *GridFSDownloadStream, err error) {
+	// Gets bucket
+	bucket, err := c.gridFSBucket(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// Opens stream
+	download, err := bucket.OpenDownloadStream(id)
+	if err != nil {
+		return nil, err
+	}
+	if deadline, ok := ctx.Deadline(); ok {
+		download.SetReadDeadline(deadline)
+	}
+	file := download.GetFile()
+	stream = &GridFSDownloadStream{stream: download, File: &GridFSFile{
+		ID:         file.ID,
+		Name:       file.Name,
+		Length:     file.Length,
+		ChunkSize:  file.ChunkSize,
+		UploadDate: file.UploadDate,
+	}}
+	if len(file.Metadata) != 0 {
+		err = bson.Unmarshal(file.Metadata, &stream.File.Metadata)
+		if err != nil {
+			download.Close()
+			return nil, err
+		}
+	}
+
+	// Returns
+	return stream, nil
+}
@@ -0,0 +1,239 @@
+package mongodb
+
+import (
+	"crypto/rand"
+	"encoding/binary"
+	"errors"
+	"sync"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Crockford base32 alphabet of ULIDs
+const ulidAlphabet = "0123456789ABCDEFGHJKMNPQRSTVWXYZ"
+
+// Snowflake layout: 41 bits of milliseconds since the epoch, 10 bits of node, 12 bits of sequence
+const (
+	snowflakeNodeBits     = 10
+	snowflakeSequenceBits = 12
+	snowflakeMaxNode      = 1<<snowflakeNodeBits - 1
+	snowflakeMaxSequence  = 1<<snowflakeSequenceBits - 1
+)
+
+// SnowflakeEpoch is the start of snowflake id time, 2020-01-01 UTC
+var SnowflakeEpoch = time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+
+// IDGenerator generates the _id of inserted documents which have none
+// Set one for all collections with Config.IDGenerator or per collection with SetIDGenerator
+type IDGenerator interface {
+	NewID() interface{}
+}
+
+// SetIDGenerator sets the _id generator of the collection, overriding Config.IDGenerator, nil restores the default
+func (c *Client) SetIDGenerator(collection string, generator IDGenerator) {
+	c.configure(collection, func(s *collectionSettings) { s.idGenerator = generator })
+}
+
+// idGenerator returns the generator of the collection, nil for driver generated ObjectIDs
+func (c *Client) idGenerator(s *collectionSettings) IDGenerator {
+	if s != nil && s.idGenerator != nil {
+		return s.idGenerator
+	}
+	return c.defaultIDGenerator
+}
+
+// ObjectIDGenerator generates MongoDB ObjectIDs, the driver default
+type ObjectIDGenerator struct{}
+
+// NewID ...
+func (ObjectIDGenerator) NewID() interface{} {
+	return primitive.NewObjectID()
+}
+
+// UUIDv7Generator generates time ordered RFC 9562 version 7 UUIDs stored as binary subtype 4
+// IDs generated in the same millisecond stay ordered using a counter
+type UUIDv7Generator struct {
+	mu      sync.Mutex
+	lastMs  int64
+	counter uint16
+}
+
+// NewID ...
+func (g *UUIDv7Generator) NewID() interface{} {
+	var id [16]byte
+	randomBytes(id[6:])
+
+	// Orders ids of the same millisecond
+	g.mu.Lock()
+	ms := time.Now().UnixNano() / int64(time.Millisecond)
+	if ms <= g.lastMs {
+		ms = g.lastMs
+		g.counter++
+		if g.counter > 0x0fff {
+			ms++
+			g.counter = 0
+		}
+	} else {
+		g.counter = binary.BigEndian.Uint16(id[6:8]) & 0x07ff
+	}
+	g.lastMs = ms
+	counter := g.counter
+	g.mu.Unlock()
+
+	// Sets timestamp, version & variant
+	putUint48(id[:6], uint64(ms))
+	binary.BigEndian.PutUint16(id[6:8], 0x7000|counter)
+	id[8] = id[8]&0x3f | 0x80
+
+	// Returns
+	return primitive.Binary{Subtype: 0x04, Data: id[:]}
+}
+
+// ULIDGenerator generates lexicographically sortable ULID strings
+// IDs generated in the same millisecond stay ordered by incrementing the random part
+type ULIDGenerator struct {
+	mu      sync.Mutex
+	lastMs  int64
+	entropy [10]byte
+}
+
+// NewID ...
+func (g *ULIDGenerator) NewID() interface{} {
+	var id [16]byte
+
+	g.mu.Lock()
+	ms := time.Now().UnixNano() / int64(time.Millisecond)
+	if ms <= g.lastMs {
+		ms = g.lastMs
+		incrementBytes(g.entropy[:])
+	} else {
+		randomBytes(g.entropy[:])
+	}
+	g.lastMs = ms
+	copy(id[6:], g.entropy[:])
+	g.mu.Unlock()
+	putUint48(id[:6], uint64(ms))
+
+	// Returns
+	return encodeULID(id)
+}
+
+// SnowflakeGenerator generates time ordered int64 ids unique across up to 1024 nodes
+type SnowflakeGenerator struct {
+	node     int64
+	mu       sync.Mutex
+	lastMs   int64
+	sequence int64
+}
+
+// NewSnowflakeGenerator returns a generator for the node, every process generating ids needs a distinct node in [0, 1023]
+func NewSnowflakeGenerator(node int64) (generator *SnowflakeGenerator, err error) {
+	if node < 0 || node > snowflakeMaxNode {
+		return nil, errors.New("snowflake node must be in [0, 1023]")
+	}
+	return &SnowflakeGenerator{node: node}, nil
+}
+
+// NewID ...
+func (g *SnowflakeGenerator) NewID() interface{} {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	ms := time.Since(SnowflakeEpoch).Milliseconds()
+	if ms <= g.lastMs {
+		ms = g.lastMs
+		g.sequence++
+		if g.sequence > snowflakeMaxSequence {
+			// Borrows the next millisecond once the sequence is exhausted
+			ms++
+			g.sequence = 0
+		}
+	} else {
+		g.sequence = 0
+	}
+	g.lastMs = ms
+
+	// Returns
+	return ms<<(snowflakeNodeBits+snowflakeSequenceBits) | g.node<<snowflakeSequenceBits | g.sequence
+}
+
+// encodeULID encodes 128 bits as 26 Crockford base32 characters
+func encodeULID(id [16]byte) string {
+	out := make([]byte, 26)
+	// 130 bits of output, the two leading bits are zero
+	var acc uint64
+	bits := 2
+	pos := 0
+	for _, b := range id {
+		acc = acc<<8 | uint64(b)
+		bits += 8
+		for bits >= 5 {
+			bits -= 5
+			out[pos] = ulidAlphabet[(acc>>uint(bits))&0x1f]
+			pos++
+		}
+	}
+	return string(out)
+}
+
+// decodeULID decodes a ULID string into its 128 bits
+func decodeULID(s string) (id [16]byte, err error) {
+	if len(s) != 26 {
+		return id, errors.New("ulid must be 26 characters")
+	}
+	var acc uint64
+	bits := -2
+	pos := 0
+	for i := 0; i < len(s); i++ {
+		v := indexULID(s[i])
+		if v < 0 {
+			return id, errors.New("invalid ulid character " + string(s[i]))
+		}
+		acc = acc<<5 | uint64(v)
+		bits += 5
+		if bits >= 8 {
+			bits -= 8
+			id[pos] = byte(acc >> uint(bits))
+			pos++
+		}
+	}
+	return id, nil
+}
+
+// indexULID ...
+func indexULID(c byte) int {
+	if c >= 'a' && c <= 'z' {
+		c -= 'a' - 'A'
+	}
+	for i := 0; i < len(ulidAlphabet); i++ {
+		if ulidAlphabet[i] == c {
+			return i
+		}
+	}
+	return -1
+}
+
+// putUint48 ...
+func putUint48(b []byte, v uint64) {
+	for i := 5; i >= 0; i-- {
+		b[i] = byte(v)
+		v >>= 8
+	}
+}
+
+// incrementBytes increments a big endian number in place
+func incrementBytes(b []byte) {
+	for i := len(b) - 1; i >= 0; i-- {
+		b[i]++
+		if b[i] != 0 {
+			return
+		}
+	}
+}
+
+// randomBytes ...
+func randomBytes(b []byte) {
+	// crypto/rand never fails on supported platforms
+	rand.Read(b)
+}
@@ -0,0 +1,86 @@
+package mongodb
+
+import (
+	"encoding/binary"
+	"errors"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// TimeOrderedIDGenerator is an IDGenerator whose ids sort by generation time
+// Such ids allow time range scans served by the _id index alone
+type TimeOrderedIDGenerator interface {
+	IDGenerator
+	MinID(t time.Time) interface{} // Smallest id which can be generated at t
+}
+
+// MinID ...
+// ObjectIDs have a precision of a second
+func (ObjectIDGenerator) MinID(t time.Time) interface{} {
+	var id primitive.ObjectID
+	binary.BigEndian.PutUint32(id[:4], uint32(t.Unix()))
+	return id
+}
+
+// MinID ...
+func (g *UUIDv7Generator) MinID(t time.Time) interface{} {
+	var id [16]byte
+	putUint48(id[:6], uint64(t.UnixNano()/int64(time.Millisecond)))
+	id[6] = 0x70
+	id[8] = 0x80
+	return primitive.Binary{Subtype: 0x04, Data: id[:]}
+}
+
+// MinID ...
+func (g *ULIDGenerator) MinID(t time.Time) interface{} {
+	var id [16]byte
+	putUint48(id[:6], uint64(t.UnixNano()/int64(time.Millisecond)))
+	return encodeULID(id)
+}
+
+// MinID ...
+func (g *SnowflakeGenerator) MinID(t time.Time) interface{} {
+	ms := t.Sub(SnowflakeEpoch).Milliseconds()
+	if ms < 0 {
+		ms = 0
+	}
+	return ms << (snowflakeNodeBits + snowflakeSequenceBits)
+}
+
+// IDsBetween returns an _id filter matching the ids generated by the generator in [from, to)
+func IDsBetween(generator TimeOrderedIDGenerator, from, to time.Time) bson.D {
+	return bson.D{{Key: "_id", Value: bson.D{
+		{Key: "$gte", Value: generator.MinID(from)},
+		{Key: "$lt", Value: generator.MinID(to)},
+	}}}
+}
+
+// IDsBetween returns an _id filter matching the documents of the collection inserted in [from, to)
+// It uses the id generator of the collection, ObjectIDs when none is set
+func (c *Client) IDsBetween(collection string, from, to time.Time) (filter bson.D, err error) {
+	// Gets generator
+	var generator IDGenerator = ObjectIDGenerator{}
+	if g := c.idGenerator(c.settings(collection)); g != nil {
+		generator = g
+	}
+	ordered, ok := generator.(TimeOrderedIDGenerator)
+	if !ok {
+		return nil, errors.New("id generator of collection " + collection + " is not time ordered")
+	}
+
+	// Returns
+	return IDsBetween(ordered, from, to), nil
+}
+
+// ULIDTime returns the generation time of a ULID
+func ULIDTime(ulid string) (t time.Time, err error) {
+	id, err := decodeULID(ulid)
+	if err != nil {
+		return t, err
+	}
+	var b [8]byte
+	copy(b[2:], id[:6])
+	return time.Unix(0, int64(binary.BigEndian.Uint64(b[:]))*int64(time.Millisecond)), nil
+}
@@ -0,0 +1,281 @@
+package mongodb
+
+import (
+	"bufio"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"io"
+	"strings"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// Default documents per import batch
+const defaultImportBatchSize = 1000
+
+// ImportOptions ...
+type ImportOptions struct {
+	Format    string                                        // FormatCSV or FormatJSONL
+	Mapping   map[string]string                             // Destination dotted path by column or top level field, e.g. {"city": "address.city"}, unmapped fields keep their name (default is nil)
+	Transform func(row bson.D) (doc interface{}, err error) // Converts a mapped row into the inserted document, e.g. parsing CSV strings, an error rejects the row (default is nil, the row as is)
+	BatchSize int                                           // Documents per insert. (default is 1000)
+	Comma     rune                                          // CSV field delimiter. (default is ',')
+	Report    io.Writer                                     // Receives one JSON line {line, row, error} per rejected row (default is nil)
+}
+
+// ImportResult ...
+type ImportResult struct {
+	Inserted int64
+	Rejected int64
+}
+
+// ImportRejection is a line of the import report
+type ImportRejection struct {
+	Line  int64  `json:"line"`  // Line of the row in the input, 1 for the first line
+	Row   string `json:"row"`   // Row as read
+	Error string `json:"error"` // Reason of the rejection
+}
+
+// importRow is a row waiting for its batch
+type importRow struct {
+	line int64
+	text string
+	doc  interface{}
+}
+
+// Import inserts the rows of a CSV file, whose first line names the columns, or of extended JSON lines in batches
+// Rows are mapped, transformed and validated against the collection rules, rejected rows are reported and skipped
+// err is only set when the input cannot be read or a batch fails as a whole
+func (c *Client) Import(ctx context.Context, collection string, r io.Reader, opts *ImportOptions) (res *ImportResult, err error) {
+	if opts == nil {
+		opts = &ImportOptions{}
+	}
+	batchSize := opts.BatchSize
+	if batchSize <= 0 {
+		batchSize = defaultImportBatchSize
+	}
+	var read func() (line int64, text string, row bson.D, err error)
+	switch opts.Format {
+	case FormatCSV:
+		read, err = csvRows(r, opts.Comma)
+		if err != nil {
+			return nil, err
+		}
+	case FormatJSONL:
+		read = jsonlRows(r)
+	default:
+		return nil, errors.New("unknown import format " + opts.Format)
+	}
+
+	// Reads rows
+	res = &ImportResult{}
+	batch := make([]importRow, 0, batchSize)
+	for {
+		line, text, row, readErr := read()
+		if readErr == io.EOF {
+			break
+		}
+		if readErr != nil {
+			if _, ok := readErr.(*importRowError); !ok {
+				return res, readErr
+			}
+			err = c.reject(opts, res, line, text, readErr)
+			if err != nil {
+				return res, err
+			}
+			continue
+		}
+
+		// Maps, transforms & validates
+		doc, rowErr := c.importDocument(collection, row, opts)
+		if rowErr != nil {
+			err = c.reject(opts, res, line, text, rowErr)
+			if err != nil {
+				return res, err
+			}
+			continue
+		}
+		batch = append(batch, importRow{line: line, text: text, doc: doc})
+
+		// Inserts batch
+		if len(batch) == batchSize {
+			err = c.importBatch(ctx, collection, batch, opts, res)
+			if err != nil {
+				return res, err
+			}
+			batch = batch[:0]
+		}
+	}
+	err = c.importBatch(ctx, collection, batch, opts, res)
+	if err != nil {
+		return res, err
+	}
+
+	// Returns
+	return res, nil
+}
+
+// importDocument maps and transforms a row, then validates it against the collection rules
+func (c *Client) importDocument(collection string, row bson.D, opts *ImportOptions) (doc interface{}, err error) {
+	// Maps
+	mapped := bson.D{}
+	for _, e := range row {
+		path := e.Key
+		if destination, ok := opts.Mapping[e.Key]; ok {
+			path = destination
+		}
+		mapped = setPath(mapped, strings.Split(path, "."), e.Value)
+	}
+
+	// Transforms
+	doc = mapped
+	if opts.Transform != nil {
+		panicErr := safeCall(c.panicHandler, "ImportOptions.Transform", func() { doc, err = opts.Transform(mapped) })
+		if panicErr != nil {
+			return nil, panicErr
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// Validates
+	err = c.settings(collection).validate(collection, doc)
+	if err != nil {
+		return nil, err
+	}
+
+	// Returns
+	return doc, nil
+}
+
+// importBatch inserts the batch, documents the server refuses are rejected
+func (c *Client) importBatch(ctx context.Context, collection string, batch []importRow, opts *ImportOptions, res *ImportResult) (err error) {
+	if len(batch) == 0 {
+		return nil
+	}
+	docs := make([]interface{}, len(batch))
+	for i, row := range batch {
+		docs[i] = row.doc
+	}
+
+	// Hits DB
+	ids, err := c.CreateMany(ctx, collection, docs, false)
+	res.Inserted += int64(len(ids))
+	bulkErr, ok := err.(*BulkError)
+	if err == nil || !ok || len(bulkErr.Failures) == 0 {
+		return err
+	}
+
+	// Rejects failed documents
+	for _, failure := range bulkErr.Failures {
+		if failure.Index < 0 || failure.Index >= len(batch) {
+			continue
+		}
+		row := batch[failure.Index]
+		err = c.reject(opts, res, row.line, row.text, errors.New(failure.Message))
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// reject counts and reports a rejected row
+func (c *Client) reject(opts *ImportOptions, res *ImportResult, line int64, text string, reason error) (err error) {
+	res.Rejected++
+	if opts.Report == nil {
+		return nil
+	}
+	data, err := json.Marshal(&ImportRejection{Line: line, Row: text, Error: reason.Error()})
+	if err != nil {
+		return err
+	}
+	_, err = opts.Report.Write(append(data, '\n'))
+	if err != nil {
+		return errors.New("writing import report -> " + err.Error())
+	}
+	return nil
+}
+
+// importRowError is a row which cannot be parsed, it is rejected without stopping the import
+type importRowError struct {
+	err error
+}
+
+// Error ...
+func (e *importRowError) Error() string {
+	return e.err.Error()
+}
+
+// csvRows reads the header then returns a reader of the rows as documents of string fields
+func csvRows(r io.Reader, comma rune) (read func() (line int64, text string, row bson.D, err error), err error) {
+	cr := csv.NewReader(r)
+	if comma != 0 {
+		cr.Comma = comma
+	}
+	cr.FieldsPerRecord = -1
+	header, err := cr.Read()
+	if err != nil {
+		return nil, errors.New("reading csv header -> " + err.Error())
+	}
+	header = append([]string(nil), header...)
+	return func() (line int64, text string, row bson.D, err error) {
+		record, err := cr.Read()
+		if err != nil {
+			if parseErr, ok := err.(*csv.ParseError); ok {
+				return int64(parseErr.StartLine), "", nil, &importRowError{err: err}
+			}
+			return 0, "", nil, err
+		}
+		startLine, _ := cr.FieldPos(0)
+		line = int64(startLine)
+		text = csvLine(record, cr.Comma)
+		if len(record) != len(header) {
+			return line, text, nil, &importRowError{err: errors.New("row has a different number of fields than the header")}
+		}
+		row = make(bson.D, len(record))
+		for i, value := range record {
+			row[i] = bson.E{Key: header[i], Value: value}
+		}
+		return line, text, row, nil
+	}, nil
+}
+
+// csvLine encodes a record back into its CSV line
+func csvLine(record []string, comma rune) string {
+	var b strings.Builder
+	w := csv.NewWriter(&b)
+	w.Comma = comma
+	w.Write(record)
+	w.Flush()
+	return strings.TrimSuffix(b.String(), "\n")
+}
+
+// jsonlRows returns a reader of the documents of extended JSON lines, blank lines are skipped
+func jsonlRows(r io.Reader) func() (line int64, text string, row bson.D, err error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64<<10), 64<<20) // Extended JSON of the largest documents
+	var n int64
+	return func() (line int64, text string, row bson.D, err error) {
+		for scanner.Scan() {
+			n++
+			text = strings.TrimSpace(scanner.Text())
+			if text == "" {
+				continue
+			}
+			err = bson.UnmarshalExtJSON([]byte(text), false, &row)
+			if err != nil {
+				return n, text, nil, &importRowError{err: err}
+			}
+			return n, text, row, nil
+		}
+		err = scanner.Err()
+		if err != nil {
+			return n, "", nil, err
+		}
+		return n, "", nil, io.EOF
+	}
+}
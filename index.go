@@ -0,0 +1,271 @@
+package mongodb
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// Key of a wildcard index on all fields
+const wildcardKey = "$**"
+
+// IndexModel describes an index of a collection
+type IndexModel struct {
+	Keys               bson.D      // Indexed fields in order, e.g. {email: 1}, {"attributes.$**": 1} for a wildcard index
+	Name               string      // Index name, generated by the server from the keys when empty
+	Unique             bool        // Rejects documents with duplicate key values
+	Sparse             bool        // Only documents having the indexed fields are indexed
+	Hidden             bool        // Index is maintained but not used by the query planner, requires server 4.4+
+	PartialFilter      interface{} // Only documents matching the filter expression are indexed
+	WildcardProjection interface{} // Fields included in or excluded from a "$**" wildcard index
+	Weights            bson.D      // Relevance weight of text index fields, fields default to 1
+	DefaultLanguage    string      // Language of text index stemming & stop words. (default is "english")
+	ExpireAfter        int         // In seconds, Documents are deleted once their indexed date is older, for a TTL index on a single date field. (default is 0, never)
+}
+
+// TextField is a field of a text index with its relevance weight
+type TextField struct {
+	Field  string
+	Weight int32 // Relative significance of a match on the field. (default is 1)
+}
+
+// WildcardIndex returns a wildcard index on every field under the path, the whole document when path is empty
+// e.g. WildcardIndex("attributes") indexes {"attributes.$**": 1}
+func WildcardIndex(path string) *IndexModel {
+	key := wildcardKey
+	if path != "" {
+		key = path + "." + wildcardKey
+	}
+	return &IndexModel{Keys: bson.D{{Key: key, Value: 1}}}
+}
+
+// WildcardIndexProjection returns a wildcard index on the whole document restricted by a projection
+// e.g. {"attributes": 1, "tags": 1} to include fields or {"blob": 0} to exclude them
+func WildcardIndexProjection(projection interface{}) *IndexModel {
+	return &IndexModel{Keys: bson.D{{Key: wildcardKey, Value: 1}}, WildcardProjection: projection}
+}
+
+// TextIndex returns a compound text index over the fields weighted by their relevance
+func TextIndex(fields ...TextField) *IndexModel {
+	m := &IndexModel{}
+	for _, f := range fields {
+		m.Keys = append(m.Keys, bson.E{Key: f.Field, Value: "text"})
+		if f.Weight > 0 {
+			m.Weights = append(m.Weights, bson.E{Key: f.Field, Value: f.Weight})
+		}
+	}
+	return m
+}
+
+// IsWildcard reports whether the index is a wildcard index
+func (m *IndexModel) IsWildcard() bool {
+	for _, k := range m.Keys {
+		if k.Key == wildcardKey || strings.HasSuffix(k.Key, "."+wildcardKey) {
+			return true
+		}
+	}
+	return false
+}
+
+// validate rejects option combinations refused by the server
+func (m *IndexModel) validate() (err error) {
+	if len(m.Keys) == 0 {
+		return errors.New("index has no keys")
+	}
+	if m.Sparse && m.PartialFilter != nil {
+		return errors.New("index can not be both sparse and partial")
+	}
+	if m.IsWildcard() {
+		if m.Unique {
+			return errors.New("wildcard index can not be unique")
+		}
+		if m.Sparse {
+			return errors.New("wildcard index can not be sparse")
+		}
+	}
+	if m.WildcardProjection != nil && (len(m.Keys) != 1 || m.Keys[0].Key != wildcardKey) {
+		return errors.New("wildcard projection requires a single " + wildcardKey + " key")
+	}
+	text := map[string]bool{}
+	for _, k := range m.Keys {
+		if k.Value == "text" {
+			text[k.Key] = true
+		}
+	}
+	for _, w := range m.Weights {
+		if !text[w.Key] {
+			return errors.New("weighted field " + w.Key + " is not a text index key")
+		}
+	}
+	if m.ExpireAfter < 0 {
+		return errors.New("index expiry can not be negative")
+	}
+	if m.ExpireAfter > 0 && (len(m.Keys) != 1 || m.Keys[0].Key == "_id") {
+		return errors.New("TTL index requires a single field other than _id")
+	}
+	return nil
+}
+
+// model converts the index into the driver model
+func (m *IndexModel) model() (model mongo.IndexModel, err error) {
+	// Validates
+	err = m.validate()
+	if err != nil {
+		return mongo.IndexModel{}, err
+	}
+
+	// Sets options
+	opts := options.Index()
+	if m.Name != "" {
+		opts.SetName(m.Name)
+	}
+	if m.Unique {
+		opts.SetUnique(true)
+	}
+	if m.Sparse {
+		opts.SetSparse(true)
+	}
+	if m.Hidden {
+		opts.SetHidden(true)
+	}
+	if m.PartialFilter != nil {
+		opts.SetPartialFilterExpression(m.PartialFilter)
+	}
+	if m.WildcardProjection != nil {
+		opts.SetWildcardProjection(m.WildcardProjection)
+	}
+	if len(m.Weights) != 0 {
+		opts.SetWeights(m.Weights)
+	}
+	if m.DefaultLanguage != "" {
+		opts.SetDefaultLanguage(m.DefaultLanguage)
+	}
+	if m.ExpireAfter > 0 {
+		opts.SetExpireAfterSeconds(int32(m.ExpireAfter))
+	}
+
+	// Returns
+	return mongo.IndexModel{Keys: m.Keys, Options: opts}, nil
+}
+
+// CreateIndex creates the index on the collection and returns its name
+// Creating an index identical to an existing one succeeds, one with the same name or keys but other options fails
+func (c *Client) CreateIndex(ctx context.Context, collection string, index *IndexModel) (name string, err error) {
+	names, err := c.CreateIndexes(ctx, collection, []*IndexModel{index})
+	if err != nil {
+		return "", err
+	}
+	return names[0], nil
+}
+
+// CreateIndexes creates the indexes on the collection in a single command and returns their names
+func (c *Client) CreateIndexes(ctx context.Context, collection string, indexes []*IndexModel) (names []string, err error) {
+	if len(indexes) == 0 {
+		return nil, nil
+	}
+
+	// Converts
+	models := make([]mongo.IndexModel, len(indexes))
+	for i, index := range indexes {
+		models[i], err = index.model()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// Hits DB
+	names, err = c.collection(ctx, collection).Indexes().CreateMany(ctx, models)
+	if err != nil {
+		return nil, err
+	}
+
+	// Returns
+	return names, nil
+}
+
+// ListIndexes returns the indexes of the collection, _id index included
+func (c *Client) ListIndexes(ctx context.Context, collection string) (res []*IndexModel, err error) {
+	// Hits DB
+	cursor, err := c.collection(ctx, collection).Indexes().List(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// Close connection at the last
+	defer cursor.Close(ctx)
+
+	// Binds cursor response
+	for cursor.Next(ctx) {
+		var spec struct {
+			Key                bson.D      `bson:"key"`
+			Name               string      `bson:"name"`
+			Unique             bool        `bson:"unique"`
+			Sparse             bool        `bson:"sparse"`
+			Hidden             bool        `bson:"hidden"`
+			PartialFilter      interface{} `bson:"partialFilterExpression"`
+			WildcardProjection interface{} `bson:"wildcardProjection"`
+			Weights            bson.D      `bson:"weights"`
+			DefaultLanguage    string      `bson:"default_language"`
+			ExpireAfter        *float64    `bson:"expireAfterSeconds"`
+		}
+		err = cursor.Decode(&spec)
+		if err != nil {
+			return nil, err
+		}
+		index := &IndexModel{
+			Keys:               textKeys(spec.Key, spec.Weights),
+			Name:               spec.Name,
+			Unique:             spec.Unique,
+			Sparse:             spec.Sparse,
+			Hidden:             spec.Hidden,
+			PartialFilter:      spec.PartialFilter,
+			WildcardProjection: spec.WildcardProjection,
+			Weights:            spec.Weights,
+			DefaultLanguage:    spec.DefaultLanguage,
+		}
+		if spec.ExpireAfter != nil {
+			index.ExpireAfter = int(*spec.ExpireAfter)
+		}
+		res = append(res, index)
+	}
+	err = cursor.Err()
+	if err != nil {
+		return nil, err
+	}
+
+	// Returns
+	return res, nil
+}
+
+// textKeys restores the text fields of a listed text index, stored by the server as {_fts: "text", _ftsx: 1}
+func textKeys(keys bson.D, weights bson.D) bson.D {
+	res := make(bson.D, 0, len(keys))
+	for _, k := range keys {
+		switch k.Key {
+		case "_fts":
+			for _, w := range weights {
+				res = append(res, bson.E{Key: w.Key, Value: "text"})
+			}
+		case "_ftsx":
+		default:
+			res = append(res, k)
+		}
+	}
+	return res
+}
+
+// DropIndex drops the index of the collection by name
+func (c *Client) DropIndex(ctx context.Context, collection string, name string) (err error) {
+	// Hits DB
+	_, err = c.collection(ctx, collection).Indexes().DropOne(ctx, name)
+	if err != nil {
+		return err
+	}
+
+	// Returns
+	return nil
+}
@@ -0,0 +1,245 @@
+package mongodb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// Name of the index every collection has on _id
+const idIndexName = "_id_"
+
+// IndexDiff compares the declared indexes of a collection with those present on the server, matched by keys
+type IndexDiff struct {
+	Collection string
+	Missing    []*IndexModel   // Declared but absent
+	Extra      []*IndexModel   // Present but not declared, e.g. created ad hoc by ops, the _id index excluded
+	Mismatched []IndexMismatch // Present with the declared keys but other options
+}
+
+// IndexMismatch is a declared index present with other options
+type IndexMismatch struct {
+	Declared    *IndexModel
+	Actual      *IndexModel
+	Differences []string // e.g. "unique: declared true, actual false"
+}
+
+// IndexSyncOptions selects the changes SyncIndexes applies
+type IndexSyncOptions struct {
+	DropExtra          bool // Drops indexes present but not declared. (default is false, they are only reported)
+	RecreateMismatched bool // Drops and recreates mismatched indexes, the collection is without them in between. (default is false, they are only reported)
+}
+
+// InSync reports whether the collection has exactly the declared indexes
+func (d *IndexDiff) InSync() bool {
+	return len(d.Missing) == 0 && len(d.Extra) == 0 && len(d.Mismatched) == 0
+}
+
+// DiffIndexes compares the declared indexes with those present on the collection
+func (c *Client) DiffIndexes(ctx context.Context, collection string, declared []*IndexModel) (diff *IndexDiff, err error) {
+	// Lists existing
+	existing, err := c.ListIndexes(ctx, collection)
+	if err != nil {
+		return nil, err
+	}
+	actual := map[string]*IndexModel{}
+	for _, index := range existing {
+		key, err := indexKeysKey(index.Keys)
+		if err != nil {
+			return nil, err
+		}
+		actual[key] = index
+	}
+
+	// Compares declared
+	diff = &IndexDiff{Collection: collection}
+	matched := map[string]bool{}
+	for _, index := range declared {
+		key, err := indexKeysKey(index.Keys)
+		if err != nil {
+			return nil, err
+		}
+		present, ok := actual[key]
+		if !ok {
+			diff.Missing = append(diff.Missing, index)
+			continue
+		}
+		matched[key] = true
+		differences, err := indexDifferences(index, present)
+		if err != nil {
+			return nil, err
+		}
+		if len(differences) != 0 {
+			diff.Mismatched = append(diff.Mismatched, IndexMismatch{Declared: index, Actual: present, Differences: differences})
+		}
+	}
+
+	// Lists extra
+	for _, index := range existing {
+		key, err := indexKeysKey(index.Keys)
+		if err != nil {
+			return nil, err
+		}
+		if !matched[key] && index.Name != idIndexName {
+			diff.Extra = append(diff.Extra, index)
+		}
+	}
+
+	// Returns
+	return diff, nil
+}
+
+// SyncIndexes creates the missing declared indexes and, as selected by opts, drops extra ones and recreates mismatched ones
+// It returns the diff found before applying the changes
+func (c *Client) SyncIndexes(ctx context.Context, collection string, declared []*IndexModel, opts *IndexSyncOptions) (diff *IndexDiff, err error) {
+	if opts == nil {
+		opts = &IndexSyncOptions{}
+	}
+
+	// Diffs
+	diff, err = c.DiffIndexes(ctx, collection, declared)
+	if err != nil {
+		return nil, err
+	}
+
+	// Drops extra, refusing those having the keys of a missing index as the keys were then compared wrongly
+	if opts.DropExtra {
+		missing := map[string]bool{}
+		for _, index := range diff.Missing {
+			key, err := indexKeysKey(index.Keys)
+			if err != nil {
+				return diff, err
+			}
+			missing[key] = true
+		}
+		for _, index := range diff.Extra {
+			key, err := indexKeysKey(index.Keys)
+			if err != nil {
+				return diff, err
+			}
+			if missing[key] {
+				return diff, errors.New("refusing to drop index " + index.Name + " having the keys of the declared index on " + indexKeysName(index.Keys))
+			}
+		}
+		for _, index := range diff.Extra {
+			err = c.DropIndex(ctx, collection, index.Name)
+			if err != nil {
+				return diff, errors.New("dropping extra index " + index.Name + " -> " + err.Error())
+			}
+		}
+	}
+
+	// Recreates mismatched
+	create := append([]*IndexModel(nil), diff.Missing...)
+	if opts.RecreateMismatched {
+		for _, mismatch := range diff.Mismatched {
+			err = c.DropIndex(ctx, collection, mismatch.Actual.Name)
+			if err != nil {
+				return diff, errors.New("dropping mismatched index " + mismatch.Actual.Name + " -> " + err.Error())
+			}
+			create = append(create, mismatch.Declared)
+		}
+	}
+
+	// Creates missing
+	_, err = c.CreateIndexes(ctx, collection, create)
+	if err != nil {
+		return diff, errors.New("creating indexes -> " + err.Error())
+	}
+
+	// Returns
+	return diff, nil
+}
+
+// indexDifferences lists the options of the actual index differing from the declared ones
+// The name only counts when declared, text weights default to 1
+func indexDifferences(declared *IndexModel, actual *IndexModel) (res []string, err error) {
+	differ := func(option string, d interface{}, a interface{}) {
+		res = append(res, fmt.Sprintf("%s: declared %v, actual %v", option, d, a))
+	}
+	if declared.Name != "" && declared.Name != actual.Name {
+		differ("name", declared.Name, actual.Name)
+	}
+	if declared.Unique != actual.Unique {
+		differ("unique", declared.Unique, actual.Unique)
+	}
+	if declared.Sparse != actual.Sparse {
+		differ("sparse", declared.Sparse, actual.Sparse)
+	}
+	if declared.Hidden != actual.Hidden {
+		differ("hidden", declared.Hidden, actual.Hidden)
+	}
+	if declared.ExpireAfter != actual.ExpireAfter {
+		differ("expireAfterSeconds", declared.ExpireAfter, actual.ExpireAfter)
+	}
+	if declared.DefaultLanguage != "" && declared.DefaultLanguage != actual.DefaultLanguage {
+		differ("default_language", declared.DefaultLanguage, actual.DefaultLanguage)
+	}
+	for _, option := range []struct {
+		name     string
+		declared interface{}
+		actual   interface{}
+	}{
+		{"partialFilterExpression", declared.PartialFilter, actual.PartialFilter},
+		{"wildcardProjection", declared.WildcardProjection, actual.WildcardProjection},
+		{"weights", textWeights(declared), textWeights(actual)},
+	} {
+		d, err := indexOptionJSON(option.declared)
+		if err != nil {
+			return nil, err
+		}
+		a, err := indexOptionJSON(option.actual)
+		if err != nil {
+			return nil, err
+		}
+		if d != a {
+			differ(option.name, d, a)
+		}
+	}
+	return res, nil
+}
+
+// textWeights returns the weight of every text field of the index sorted by field, nil unless it is a text index
+func textWeights(index *IndexModel) (weights bson.D) {
+	byField := map[string]float64{}
+	for _, k := range index.Keys {
+		if k.Value == "text" {
+			byField[k.Key] = 1
+		}
+	}
+	if len(byField) == 0 {
+		return nil
+	}
+	for _, w := range index.Weights {
+		if n, ok := toFloat(w.Value); ok {
+			byField[w.Key] = n
+		}
+	}
+	fields := make([]string, 0, len(byField))
+	for field := range byField {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+	for _, field := range fields {
+		weights = append(weights, bson.E{Key: field, Value: byField[field]})
+	}
+	return weights
+}
+
+// indexOptionJSON renders a document option in relaxed extended JSON, so numeric types do not matter
+func indexOptionJSON(option interface{}) (res string, err error) {
+	if option == nil {
+		return "", nil
+	}
+	if weights, ok := option.(bson.D); ok && weights == nil {
+		return "", nil
+	}
+	data, err := bson.MarshalExtJSON(option, false, false)
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
@@ -0,0 +1,20 @@
+package mongodb
+
+import (
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// TestDiffIndexKeysMatchListedIndexes ...
+func TestDiffIndexKeysMatchListedIndexes(t *testing.T) {
+	declared := &IndexModel{Keys: bson.D{{Key: "tenant", Value: 1}, {Key: "email", Value: 1}}, Unique: true}
+	listed := []*IndexModel{{Keys: bson.D{{Key: "tenant", Value: int32(1)}, {Key: "email", Value: 1.0}}, Name: "tenant_1_email_1", Unique: true}}
+	found, err := hasIndex(listed, declared)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !found {
+		t.Error("declared index not found among listed indexes")
+	}
+}
@@ -0,0 +1,86 @@
+package mongodb
+
+import (
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// IndexUsage is the usage of an index on one server since the server started or the index was created
+type IndexUsage struct {
+	Collection string
+	Name       string
+	Keys       bson.D
+	Host       string    // Server the statistics were collected on, usage is tracked per replica set member
+	Accesses   int64     // Number of operations which used the index
+	Since      time.Time // Start of the statistics, server start or index creation
+	Unused     bool      // No operation used the index since Since, the _id index is never reported unused
+}
+
+// IndexUsageStats returns the usage of the collection indexes using $indexStats
+func (c *Client) IndexUsageStats(ctx context.Context, collection string) (res []IndexUsage, err error) {
+	// Hits DB
+	pipeline := mongo.Pipeline{{{Key: "$indexStats", Value: bson.D{}}}}
+	cursor, err := c.collection(ctx, collection).Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+
+	// Close connection at the last
+	defer cursor.Close(ctx)
+
+	// Binds cursor response
+	var stats []struct {
+		Name     string `bson:"name"`
+		Key      bson.D `bson:"key"`
+		Host     string `bson:"host"`
+		Accesses struct {
+			Ops   int64     `bson:"ops"`
+			Since time.Time `bson:"since"`
+		} `bson:"accesses"`
+	}
+	err = cursor.All(ctx, &stats)
+	if err != nil {
+		return nil, err
+	}
+	for _, s := range stats {
+		res = append(res, IndexUsage{
+			Collection: collection,
+			Name:       s.Name,
+			Keys:       s.Key,
+			Host:       s.Host,
+			Accesses:   s.Accesses.Ops,
+			Since:      s.Accesses.Since,
+			Unused:     s.Accesses.Ops == 0 && s.Name != "_id_",
+		})
+	}
+
+	// Returns
+	return res, nil
+}
+
+// IndexUsageReport returns the usage of the indexes of several collections, all collections of the database when none are given
+// Use the Unused flag of the results to find index cleanup candidates
+func (c *Client) IndexUsageReport(ctx context.Context, collections ...string) (res []IndexUsage, err error) {
+	// Lists collections
+	if len(collections) == 0 {
+		collections, err = c.database.ListCollectionNames(ctx, bson.D{{Key: "type", Value: "collection"}})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// Collects
+	for _, collection := range collections {
+		stats, err := c.IndexUsageStats(ctx, collection)
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, stats...)
+	}
+
+	// Returns
+	return res, nil
+}
@@ -0,0 +1,249 @@
+package mongodb
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/bsoncodec"
+)
+
+// Struct tag declaring the indexes of a field
+// Declarations are separated by ";", each is a comma separated list of options:
+//   - unique, sparse, desc, text, ttl=<seconds>
+//   - compound=<name> makes the field a key of the named compound index, keys follow the field order unless order=<n> is given
+//
+// e.g. `mongoIndex:"unique"`, `mongoIndex:"desc,ttl=86400"`, `mongoIndex:"compound=tenant_email,unique"`
+const indexTag = "mongoIndex"
+
+// indexKeyTag is a key of a declared compound index
+type indexKeyTag struct {
+	field string
+	value interface{}
+	order int
+	pos   int
+}
+
+// IndexesOf returns the indexes declared by the mongoIndex tags of a struct, nested structs included with dotted paths
+// Compound indexes are named after their declaration, single field indexes get the server generated name
+func IndexesOf(model interface{}) (indexes []*IndexModel, err error) {
+	t := reflect.TypeOf(model)
+	for t != nil && t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t == nil || t.Kind() != reflect.Struct {
+		return nil, errors.New("index declarations require a struct")
+	}
+
+	// Parses tags
+	compounds := map[string]*IndexModel{}
+	keys := map[string][]indexKeyTag{}
+	var names []string
+	pos := 0
+	err = walkIndexTags(t, "", func(field string, tag string) error {
+		for _, declaration := range strings.Split(tag, ";") {
+			index := &IndexModel{}
+			key := indexKeyTag{field: field, value: 1, order: -1, pos: pos}
+			pos++
+			compound := ""
+			for _, option := range strings.Split(declaration, ",") {
+				option = strings.TrimSpace(option)
+				name, value, _ := strings.Cut(option, "=")
+				switch name {
+				case "":
+				case "unique":
+					index.Unique = true
+				case "sparse":
+					index.Sparse = true
+				case "desc":
+					key.value = -1
+				case "text":
+					key.value = "text"
+				case "ttl":
+					index.ExpireAfter, err = strconv.Atoi(value)
+					if err != nil {
+						return errors.New("invalid ttl of index on " + field + " -> " + err.Error())
+					}
+				case "compound":
+					compound = value
+				case "order":
+					key.order, err = strconv.Atoi(value)
+					if err != nil {
+						return errors.New("invalid order of index on " + field + " -> " + err.Error())
+					}
+				default:
+					return errors.New("unknown option " + option + " of index on " + field)
+				}
+			}
+
+			// Single field index
+			if compound == "" {
+				index.Keys = bson.D{{Key: field, Value: key.value}}
+				indexes = append(indexes, index)
+				continue
+			}
+
+			// Compound index key, options of every key apply to the index
+			existing, ok := compounds[compound]
+			if !ok {
+				existing = &IndexModel{Name: compound}
+				compounds[compound] = existing
+				names = append(names, compound)
+			}
+			existing.Unique = existing.Unique || index.Unique
+			existing.Sparse = existing.Sparse || index.Sparse
+			if index.ExpireAfter != 0 {
+				existing.ExpireAfter = index.ExpireAfter
+			}
+			keys[compound] = append(keys[compound], key)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Orders compound keys
+	for _, name := range names {
+		fields := keys[name]
+		sort.SliceStable(fields, func(i, j int) bool {
+			if fields[i].order != fields[j].order {
+				return fields[j].order < 0 || (fields[i].order >= 0 && fields[i].order < fields[j].order)
+			}
+			return fields[i].pos < fields[j].pos
+		})
+		index := compounds[name]
+		for _, key := range fields {
+			index.Keys = append(index.Keys, bson.E{Key: key.field, Value: key.value})
+		}
+		indexes = append(indexes, index)
+	}
+
+	// Validates
+	for _, index := range indexes {
+		err = index.validate()
+		if err != nil {
+			return nil, errors.New("index on " + indexKeysName(index.Keys) + " -> " + err.Error())
+		}
+	}
+
+	// Returns
+	return indexes, nil
+}
+
+// EnsureIndexes creates the indexes declared by the mongoIndex tags of the model which the collection lacks, e.g. at startup
+// Existing indexes are matched by keys, one with the same keys but other options is left as is
+func (c *Client) EnsureIndexes(ctx context.Context, collection string, model interface{}) (created []string, err error) {
+	// Parses declarations
+	declared, err := IndexesOf(model)
+	if err != nil {
+		return nil, err
+	}
+
+	// Lists existing
+	existing, err := c.ListIndexes(ctx, collection)
+	if err != nil {
+		return nil, err
+	}
+	present := map[string]bool{}
+	for _, index := range existing {
+		key, err := indexKeysKey(index.Keys)
+		if err != nil {
+			return nil, err
+		}
+		present[key] = true
+	}
+
+	// Creates missing
+	var missing []*IndexModel
+	for _, index := range declared {
+		key, err := indexKeysKey(index.Keys)
+		if err != nil {
+			return nil, err
+		}
+		if !present[key] {
+			missing = append(missing, index)
+			present[key] = true
+		}
+	}
+
+	// Returns
+	return c.CreateIndexes(ctx, collection, missing)
+}
+
+// walkIndexTags calls fn with the dotted bson path and tag of every field with a mongoIndex tag
+func walkIndexTags(t reflect.Type, prefix string, fn func(field string, tag string) error) (err error) {
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		if sf.PkgPath != "" && !sf.Anonymous {
+			continue
+		}
+		tags, err := bsoncodec.DefaultStructTagParser(sf)
+		if err != nil || tags.Skip {
+			continue
+		}
+		path := prefix + tags.Name
+		if tag, ok := sf.Tag.Lookup(indexTag); ok {
+			err = fn(path, tag)
+			if err != nil {
+				return err
+			}
+		}
+
+		// Nested structs
+		ft := sf.Type
+		for ft.Kind() == reflect.Ptr {
+			ft = ft.Elem()
+		}
+		if ft.Kind() != reflect.Struct || ft == reflect.TypeOf(time.Time{}) {
+			continue
+		}
+		nested := path + "."
+		if tags.Inline {
+			nested = prefix
+		}
+		err = walkIndexTags(ft, nested, fn)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// indexKeysKey identifies index keys whatever the numeric type of their directions
+// Text fields are a single unordered group, the server lists them by name
+func indexKeysKey(keys bson.D) (key string, err error) {
+	var b strings.Builder
+	var text []string
+	for _, k := range keys {
+		if k.Value == "text" {
+			if text == nil {
+				b.WriteString("\x01text\x00")
+			}
+			text = append(text, k.Key)
+			continue
+		}
+		value, err := idKey(k.Value)
+		if err != nil {
+			return "", err
+		}
+		b.WriteString(k.Key + "\x00" + value + "\x00")
+	}
+	sort.Strings(text)
+	b.WriteString(strings.Join(text, "\x00"))
+	return b.String(), nil
+}
+
+// indexKeysName ...
+func indexKeysName(keys bson.D) string {
+	fields := make([]string, len(keys))
+	for i, k := range keys {
+		fields[i] = k.Key
+	}
+	return strings.Join(fields, ", ")
+}
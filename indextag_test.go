@@ -0,0 +1,51 @@
+package mongodb
+
+import (
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// TestIndexKeysKeyMatchesServerKeys ...
+func TestIndexKeysKeyMatchesServerKeys(t *testing.T) {
+	type user struct {
+		Email   string `bson:"email" mongoIndex:"unique"`
+		Created int64  `bson:"created" mongoIndex:"desc"`
+	}
+	indexes, err := IndexesOf(user{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	declared := []bson.D{indexes[0].Keys, indexes[1].Keys, {{Key: "email", Value: 1}, {Key: "created", Value: -1}}}
+
+	for _, keys := range declared {
+		// Round trips keys as listed by the server, directions are int32 or double
+		raw, err := bson.Marshal(keys)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var listed bson.D
+		err = bson.Unmarshal(raw, &listed)
+		if err != nil {
+			t.Fatal(err)
+		}
+		asDouble := make(bson.D, len(listed))
+		for i, k := range listed {
+			asDouble[i] = bson.E{Key: k.Key, Value: float64(k.Value.(int32))}
+		}
+
+		want, err := indexKeysKey(keys)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, server := range []bson.D{listed, asDouble} {
+			got, err := indexKeysKey(server)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != want {
+				t.Errorf("keys %v listed as %v do not match", keys, server)
+			}
+		}
+	}
+}
@@ -0,0 +1,65 @@
+package mongodb
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// Server error codes of duplicate key violations
+var duplicateKeyCodes = map[int]bool{11000: true, 11001: true, 12582: true}
+
+// InsertManyIgnoreDuplicates inserts the documents unordered, documents violating a unique index are skipped instead of failing
+// It makes at least once pipelines idempotent when documents carry a deterministic _id or unique key
+// Other failures are returned as a *BulkError listing only the non duplicate failures
+func (c *Client) InsertManyIgnoreDuplicates(ctx context.Context, collection string, documents []interface{}) (inserted int64, skipped int64, err error) {
+	if len(documents) == 0 {
+		return 0, 0, nil
+	}
+
+	// Checks tenant quota
+	err = c.checkQuota(ctx, true)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	// Applies collection rules
+	docs := make([]interface{}, len(documents))
+	for i, document := range documents {
+		docs[i], err = c.prepareInsert(collection, document)
+		if err != nil {
+			return 0, 0, err
+		}
+	}
+
+	// Hits DB
+	_, err = c.collection(ctx, collection).InsertMany(ctx, docs, options.InsertMany().SetOrdered(false))
+	if err == nil {
+		c.addTenantStorage(ctx, docs...)
+		return int64(len(docs)), 0, nil
+	}
+
+	// Separates duplicates
+	converted := newBulkError(err, 0)
+	bulkErr, ok := converted.(*BulkError)
+	if !ok {
+		return 0, 0, err
+	}
+	failures := bulkErr.Failures[:0]
+	for _, f := range bulkErr.Failures {
+		if duplicateKeyCodes[f.Code] {
+			skipped++
+			continue
+		}
+		failures = append(failures, f)
+	}
+	c.addTenantStorage(ctx, bulkErr.written(docs, false)...)
+	bulkErr.Failures = failures
+	inserted = int64(len(docs)) - skipped - int64(len(failures))
+	if len(failures) != 0 || bulkErr.WriteConcernError != "" {
+		return inserted, skipped, bulkErr
+	}
+
+	// Returns
+	return inserted, skipped, nil
+}
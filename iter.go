@@ -0,0 +1,49 @@
+package mongodb
+
+import (
+	"context"
+	"iter"
+
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// Find iterates the documents matching the filter decoded into T, fetching them batch by batch
+// e.g. for user, err := range Find[User](ctx, client, "users", filter) { ... }
+// An error ends the iteration, breaking out of the loop closes the server cursor
+func Find[T any](ctx context.Context, c *Client, collection string, filter interface{}, opts ...*options.FindOptions) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		var zero T
+
+		// Opens stream
+		stream, err := c.ReadStream(ctx, collection, filter, opts...)
+		if err != nil {
+			yield(zero, err)
+			return
+		}
+
+		// Close connection at the last
+		defer stream.Close(ctx)
+
+		// Yields documents
+		for stream.Next(ctx) {
+			var doc T
+			err = stream.Decode(&doc)
+			if err != nil {
+				yield(zero, err)
+				return
+			}
+			if !yield(doc, nil) {
+				return
+			}
+		}
+		err = stream.Err()
+		if err != nil {
+			yield(zero, err)
+		}
+	}
+}
+
+// All iterates the documents matching the filter, see Find
+func (t *Collection[T]) All(ctx context.Context, filter interface{}, opts ...*options.FindOptions) iter.Seq2[T, error] {
+	return Find[T](ctx, t.client, t.name, filter, opts...)
+}
@@ -0,0 +1,383 @@
+package mongodb
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"os"
+	"sync"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.mongodb.org/mongo-driver/mongo/readpref"
+	"go.mongodb.org/mongo-driver/x/mongo/driver/topology"
+)
+
+// ErrWriteJournaled is returned by write operations when the server is unreachable and the write was persisted to the local journal
+// The write is replayed in order once the server is reachable again
+var ErrWriteJournaled = errors.New("server unreachable, write journaled for replay")
+
+// Journal operations
+const (
+	journalInsert     = "insert"
+	journalUpdate     = "update"
+	journalUpdateMany = "updateMany"
+	journalDelete     = "delete"
+	journalDeleteMany = "deleteMany"
+
+	// Collection recording idempotency keys of replayed writes
+	journalAppliedCollection = "mongo_lib_journal"
+
+	defaultJournalReplayInterval = 5 * time.Second
+)
+
+// JournalConfig enables the local write ahead journal for intermittent connectivity
+// Writes are replayed once: an update or delete and the record of its idempotency key are committed in a single transaction
+// Without transactions, e.g. on a standalone server, a failure between the two replays the write again, so only idempotent updates ($set, not $inc or $push) are safe to journal there
+type JournalConfig struct {
+	Path           string                                               // File path of the journal, created when missing
+	ReplayInterval int                                                  // In milliseconds, How often the server is checked to replay journaled writes. (default is 5 seconds)
+	OnReplayError  func(collection string, operation string, err error) // Called when a journaled write is rejected by the server, the write is dropped from the journal
+}
+
+// journalEntry is a single journaled write, stored as one extended JSON line
+type journalEntry struct {
+	Key          string        `bson:"key"` // Idempotency key
+	Operation    string        `bson:"op"`
+	Collection   string        `bson:"collection"`
+	Query        interface{}   `bson:"query,omitempty"`
+	Document     interface{}   `bson:"document,omitempty"`
+	Upsert       bool          `bson:"upsert,omitempty"` // Update options
+	ArrayFilters []interface{} `bson:"arrayFilters,omitempty"`
+}
+
+// journal ...
+type journal struct {
+	config  *JournalConfig
+	mu      sync.Mutex
+	pending int
+	stop    chan struct{}
+	done    chan struct{}
+	closing sync.Once
+}
+
+// newJournal opens the journal and counts writes left from a previous run
+func newJournal(config *JournalConfig) (j *journal, err error) {
+	if config.Path == "" {
+		return nil, errors.New("journal path is required")
+	}
+	j = &journal{config: config, stop: make(chan struct{}), done: make(chan struct{})}
+
+	// Counts pending writes
+	entries, err := j.read()
+	if err != nil {
+		return nil, err
+	}
+	j.pending = len(entries)
+
+	// Returns
+	return j, nil
+}
+
+// hasPending reports whether writes are waiting for replay
+// New writes are journaled while it is true to keep the write order
+func (j *journal) hasPending() bool {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	return j.pending != 0
+}
+
+// append persists a write to the journal
+func (j *journal) append(entry *journalEntry) (err error) {
+	// Encodes
+	line, err := bson.MarshalExtJSON(entry, true, false)
+	if err != nil {
+		return err
+	}
+
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	// Writes
+	file, err := os.OpenFile(j.config.Path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0600)
+	if err != nil {
+		return err
+	}
+	_, err = file.Write(append(line, '\n'))
+	if err != nil {
+		file.Close()
+		return err
+	}
+	err = file.Sync()
+	if err != nil {
+		file.Close()
+		return err
+	}
+	err = file.Close()
+	if err != nil {
+		return err
+	}
+	j.pending++
+
+	// Returns
+	return nil
+}
+
+// read returns the journaled writes in order
+func (j *journal) read() (entries []*journalEntry, err error) {
+	// Opens
+	file, err := os.Open(j.config.Path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	defer file.Close()
+
+	// Decodes lines
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 64*1024), 64*1024*1024)
+	for scanner.Scan() {
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+		entry := &journalEntry{}
+		err = bson.UnmarshalExtJSON(scanner.Bytes(), true, entry)
+		if err != nil {
+			return nil, errors.New("corrupted journal entry -> " + err.Error())
+		}
+		entries = append(entries, entry)
+	}
+	err = scanner.Err()
+	if err != nil {
+		return nil, err
+	}
+
+	// Returns
+	return entries, nil
+}
+
+// rewrite atomically replaces the journal with the given writes
+func (j *journal) rewrite(entries []*journalEntry) (err error) {
+	// Removes journal once everything is replayed
+	if len(entries) == 0 {
+		err = os.Remove(j.config.Path)
+		if err != nil && !os.IsNotExist(err) {
+			return err
+		}
+		return nil
+	}
+
+	// Writes remaining entries to a temporary file
+	tmp := j.config.Path + ".tmp"
+	file, err := os.OpenFile(tmp, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0600)
+	if err != nil {
+		return err
+	}
+	writer := bufio.NewWriter(file)
+	for _, entry := range entries {
+		line, err := bson.MarshalExtJSON(entry, true, false)
+		if err != nil {
+			file.Close()
+			return err
+		}
+		writer.Write(append(line, '\n'))
+	}
+	err = writer.Flush()
+	if err == nil {
+		err = file.Sync()
+	}
+	if err != nil {
+		file.Close()
+		return err
+	}
+	err = file.Close()
+	if err != nil {
+		return err
+	}
+
+	// Swaps
+	return os.Rename(tmp, j.config.Path)
+}
+
+// journaling reports whether writes of the context fall back to the journal, never in a transaction which they would escape
+func (c *Client) journaling(ctx context.Context) bool {
+	return c.journal != nil && !inTransaction(ctx)
+}
+
+// journalWrite persists a write which could not reach the server, with the upsert and array filters of its update options
+func (c *Client) journalWrite(operation string, collection string, query interface{}, document interface{}, opts ...*options.UpdateOptions) (err error) {
+	entry := &journalEntry{
+		Key:        primitive.NewObjectID().Hex(),
+		Operation:  operation,
+		Collection: collection,
+		Query:      query,
+		Document:   document,
+	}
+	updateOpts := options.MergeUpdateOptions(opts...)
+	if updateOpts.Upsert != nil {
+		entry.Upsert = *updateOpts.Upsert
+	}
+	if updateOpts.ArrayFilters != nil {
+		entry.ArrayFilters = updateOpts.ArrayFilters.Filters
+	}
+	err = c.journal.append(entry)
+	if err != nil {
+		return errors.New("journaling write failed -> " + err.Error())
+	}
+
+	// Returns
+	return ErrWriteJournaled
+}
+
+// isUnreachable reports whether the error means the server could not be reached
+func isUnreachable(err error) bool {
+	var selectionErr topology.ServerSelectionError
+	return mongo.IsNetworkError(err) || errors.As(err, &selectionErr)
+}
+
+// replayJournal periodically replays journaled writes until the client is closed
+func (c *Client) replayJournal() {
+	defer close(c.journal.done)
+
+	// Gets interval
+	interval := defaultJournalReplayInterval
+	if c.journal.config.ReplayInterval != 0 {
+		interval = time.Duration(c.journal.config.ReplayInterval) * time.Millisecond
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if c.journal.hasPending() {
+			ctx, cancel := context.WithTimeout(context.Background(), interval)
+			if c.client.Ping(ctx, readpref.Primary()) == nil {
+				c.replay(ctx)
+			}
+			cancel()
+		}
+
+		select {
+		case <-c.journal.stop:
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// stopReplay stops the journal replay, once however many times the client is closed
+func (j *journal) stopReplay() {
+	j.closing.Do(func() { close(j.stop) })
+	<-j.done
+}
+
+// replay applies journaled writes in order, stopping at the first unreachable or timeout error
+// The lock is only held to read and rewrite the journal, writes journaled meanwhile are kept after the remaining ones
+func (c *Client) replay(ctx context.Context) {
+	j := c.journal
+
+	// Reads
+	j.mu.Lock()
+	entries, err := j.read()
+	j.mu.Unlock()
+	if err != nil {
+		return
+	}
+
+	// Applies
+	done := 0
+	for _, entry := range entries {
+		err = c.applyJournalEntry(ctx, entry)
+		if isUnreachable(err) || mongo.IsTimeout(err) || ctx.Err() != nil {
+			break
+		}
+		if err != nil && j.config.OnReplayError != nil {
+			entry, replayErr := entry, err
+			safeCall(c.panicHandler, "JournalConfig.OnReplayError", func() { j.config.OnReplayError(entry.Collection, entry.Operation, replayErr) })
+		}
+		done++
+	}
+
+	// Keeps remaining
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	current, err := j.read()
+	if err != nil || len(current) < len(entries) {
+		return
+	}
+	remaining := append(entries[done:], current[len(entries):]...)
+	err = j.rewrite(remaining)
+	if err != nil {
+		return
+	}
+	j.pending = len(remaining)
+}
+
+// applyJournalEntry applies a write once, using the idempotency key to skip writes already applied
+// Inserts are idempotent by their _id, other writes are committed with their key in a transaction when the deployment supports them
+func (c *Client) applyJournalEntry(ctx context.Context, entry *journalEntry) (err error) {
+	if entry.Operation == journalInsert || c.requireCapability("journal replay", CapabilityTransactions) != nil {
+		return c.applyJournalWrite(ctx, entry)
+	}
+	return c.WithTransaction(ctx, func(txCtx context.Context) error {
+		return c.applyJournalWrite(txCtx, entry)
+	})
+}
+
+// applyJournalWrite skips the write when its key is recorded, else applies it and records its key
+func (c *Client) applyJournalWrite(ctx context.Context, entry *journalEntry) (err error) {
+	// Skips applied writes
+	applied := c.collection(ctx, journalAppliedCollection)
+	err = applied.FindOne(ctx, bson.D{{Key: "_id", Value: entry.Key}}).Err()
+	if err == nil {
+		return nil
+	}
+	if err != mongo.ErrNoDocuments {
+		return err
+	}
+
+	// Sets options
+	updateOpts := options.Update()
+	if entry.Upsert {
+		updateOpts.SetUpsert(true)
+	}
+	if len(entry.ArrayFilters) != 0 {
+		updateOpts.SetArrayFilters(options.ArrayFilters{Filters: entry.ArrayFilters})
+	}
+
+	// Hits DB
+	coll := c.collection(ctx, entry.Collection)
+	switch entry.Operation {
+	case journalInsert:
+		_, err = coll.InsertOne(ctx, entry.Document)
+		if mongo.IsDuplicateKeyError(err) {
+			err = nil
+		}
+	case journalUpdate:
+		_, err = coll.UpdateOne(ctx, entry.Query, entry.Document, updateOpts)
+	case journalUpdateMany:
+		_, err = coll.UpdateMany(ctx, entry.Query, entry.Document, updateOpts)
+	case journalDelete:
+		_, err = coll.DeleteOne(ctx, entry.Query)
+	case journalDeleteMany:
+		_, err = coll.DeleteMany(ctx, entry.Query)
+	default:
+		err = errors.New("unknown journal operation " + entry.Operation)
+	}
+	if err != nil {
+		return err
+	}
+
+	// Records key
+	_, err = applied.InsertOne(ctx, bson.D{{Key: "_id", Value: entry.Key}, {Key: "appliedAt", Value: time.Now()}})
+	if mongo.IsDuplicateKeyError(err) {
+		err = nil
+	}
+
+	// Returns
+	return err
+}
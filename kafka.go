@@ -0,0 +1,136 @@
+package mongodb
+
+import (
+	"context"
+	"errors"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// Default topic prefix of the Kafka sink
+const defaultKafkaTopicPrefix = "mongodb."
+
+// KafkaMessage is a record published by the Kafka sink
+type KafkaMessage struct {
+	Topic   string
+	Key     []byte // Document key of the changed document in relaxed extended JSON, e.g. {"_id":{"$oid":"..."}}
+	Value   []byte // Change event in relaxed extended JSON, nil for delete tombstones
+	Headers map[string]string
+}
+
+// KafkaProducer publishes messages synchronously, returning once they are acknowledged by the brokers
+// Adapt a client library in a few lines, e.g. with franz-go:
+//
+//	KafkaProducerFunc(func(ctx context.Context, m *KafkaMessage) error {
+//		return kgoClient.ProduceSync(ctx, &kgo.Record{Topic: m.Topic, Key: m.Key, Value: m.Value}).FirstErr()
+//	})
+//
+// or with sarama, syncProducer.SendMessage(&sarama.ProducerMessage{Topic: m.Topic, Key: sarama.ByteEncoder(m.Key), Value: sarama.ByteEncoder(m.Value)})
+type KafkaProducer interface {
+	Produce(ctx context.Context, message *KafkaMessage) (err error)
+}
+
+// KafkaProducerFunc adapts a function to KafkaProducer
+type KafkaProducerFunc func(ctx context.Context, message *KafkaMessage) error
+
+// Produce ...
+func (f KafkaProducerFunc) Produce(ctx context.Context, message *KafkaMessage) error {
+	return f(ctx, message)
+}
+
+// KafkaSinkOptions ...
+type KafkaSinkOptions struct {
+	TopicPrefix string           // Topic of the events of a collection is the prefix followed by the collection label. (default is "mongodb.")
+	Tombstones  bool             // Publishes a nil value after every delete event, so compacted topics drop the document. (default is false)
+	Consumer    *ConsumerOptions // Options of the underlying consumer, e.g. Watch.FullDocument for the documents of update events
+}
+
+// RunKafkaSink publishes the change events of the collections to Kafka until the context is done, a lightweight CDC pipeline
+// Events are keyed by document key so the events of a document keep their order, and delivered at least once through a named Consumer
+// Failed publications are retried as consumer redeliveries
+func (c *Client) RunKafkaSink(ctx context.Context, name string, collections []string, producer KafkaProducer, opts *KafkaSinkOptions) (err error) {
+	if producer == nil {
+		return errors.New("kafka producer is required")
+	}
+	if opts == nil {
+		opts = &KafkaSinkOptions{}
+	}
+	prefix := opts.TopicPrefix
+	if prefix == "" {
+		prefix = defaultKafkaTopicPrefix
+	}
+
+	// Starts consumer
+	consumer, err := c.NewConsumer(ctx, name, collections, opts.Consumer)
+	if err != nil {
+		return err
+	}
+
+	// Close connection at the last
+	defer consumer.Close(context.Background())
+
+	// Publishes
+	return consumer.Run(ctx, func(ctx context.Context, delivery *Delivery) error {
+		messages, err := kafkaMessages(prefix+delivery.Label, delivery.Event, opts.Tombstones)
+		if err != nil {
+			return err
+		}
+		for _, message := range messages {
+			err = producer.Produce(ctx, message)
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// kafkaMessages converts a change event into its message, followed by a tombstone for deletes when enabled
+func kafkaMessages(topic string, raw bson.Raw, tombstones bool) (messages []*KafkaMessage, err error) {
+	// Decodes
+	event, err := DecodeChangeEvent[bson.M](raw)
+	if err != nil {
+		return nil, err
+	}
+	key, err := bson.MarshalExtJSON(event.DocumentKey, false, false)
+	if err != nil {
+		return nil, err
+	}
+
+	// Encodes event
+	data, err := changeEventJSON(event)
+	if err != nil {
+		return nil, err
+	}
+	headers := map[string]string{"operationType": event.OperationType, "collection": event.Namespace.Collection}
+	messages = append(messages, &KafkaMessage{Topic: topic, Key: key, Value: data, Headers: headers})
+
+	// Adds tombstone
+	if tombstones && event.OperationType == OpDelete {
+		messages = append(messages, &KafkaMessage{Topic: topic, Key: key, Headers: headers})
+	}
+
+	// Returns
+	return messages, nil
+}
+
+// changeEventJSON encodes the change event in relaxed extended JSON, the payload of the change event sinks
+func changeEventJSON(event *ChangeEvent[bson.M]) (data []byte, err error) {
+	value := bson.D{
+		{Key: "operationType", Value: event.OperationType},
+		{Key: "database", Value: event.Namespace.Database},
+		{Key: "collection", Value: event.Namespace.Collection},
+		{Key: "documentKey", Value: event.DocumentKey},
+		{Key: "clusterTime", Value: event.ClusterTime},
+	}
+	if event.FullDocument != nil {
+		value = append(value, bson.E{Key: "fullDocument", Value: *event.FullDocument})
+	}
+	if u := event.UpdateDescription; u != nil {
+		value = append(value, bson.E{Key: "updateDescription", Value: bson.D{
+			{Key: "updatedFields", Value: u.UpdatedFields},
+			{Key: "removedFields", Value: u.RemovedFields},
+		}})
+	}
+	return bson.MarshalExtJSON(value, false, false)
+}
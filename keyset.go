@@ -0,0 +1,95 @@
+package mongodb
+
+import (
+	"context"
+	"errors"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// KeysetOptions ...
+type KeysetOptions struct {
+	Sort       bson.D      // Sort of the feed, an _id tiebreaker is appended. (default is the default sort of the collection, else _id ascending)
+	Limit      int64       // Page size, required
+	Cursor     string      // NextCursor of the previous page (default is empty, first page)
+	Projection interface{} // Projection of the items, it must keep the sort fields (default is nil, whole documents)
+}
+
+// KeysetPage is a page of a keyset paginated read
+type KeysetPage struct {
+	Items      []interface{}
+	NextCursor string // Cursor of the following page, empty on the last page
+}
+
+// ReadKeyset returns a page of the documents matching the filter which starts after the cursor of the previous page
+// Pages are found with a range condition on the sort fields instead of skipping documents, so deep pages cost as much as the first one when the sort is indexed
+func (c *Client) ReadKeyset(ctx context.Context, collection string, filter interface{}, opts *KeysetOptions) (page *KeysetPage, err error) {
+	if opts == nil || opts.Limit <= 0 {
+		return nil, errors.New("keyset limit must be positive")
+	}
+
+	// Builds query
+	sortFields := opts.Sort
+	if len(sortFields) == 0 {
+		if s := c.settings(collection); s != nil {
+			sortFields = s.defaultSort
+		}
+	}
+	if len(sortFields) == 0 {
+		sortFields = bson.D{{Key: "_id", Value: 1}}
+	}
+	sortFields = withTiebreaker(sortFields)
+	query, err := MergeFilters(filter)
+	if err != nil {
+		return nil, err
+	}
+	if opts.Cursor != "" {
+		position, err := cursorFilter(sortFields, opts.Cursor)
+		if err != nil {
+			return nil, errors.New("invalid keyset cursor -> " + err.Error())
+		}
+		query, err = MergeFilters(query, position)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if query == nil {
+		query = bson.D{}
+	}
+
+	// Hits DB, one more document tells whether a next page exists, the page is bounded by its limit rather than the result limits
+	findOpts := options.Find().SetSort(sortFields).SetLimit(opts.Limit + 1)
+	if opts.Projection != nil {
+		findOpts.SetProjection(opts.Projection)
+	}
+	res, err := c.Read(WithResultLimits(ctx, nil), collection, query, findOpts)
+	if err != nil {
+		return nil, err
+	}
+	page = &KeysetPage{Items: res}
+	if page.Items == nil {
+		page.Items = []interface{}{}
+	}
+	if int64(len(res)) <= opts.Limit {
+		return page, nil
+	}
+	page.Items = res[:opts.Limit]
+
+	// Returns
+	page.NextCursor, err = keysetCursor(sortFields, page.Items[len(page.Items)-1])
+	if err != nil {
+		return nil, err
+	}
+	return page, nil
+}
+
+// KeysetAfter returns the filter of the documents following the last seen one in the order of the field with an _id tiebreaker, e.g.
+// KeysetAfter("createdAt", -1, last.CreatedAt, last.ID) for callers keeping the last seen value and id instead of cursors
+// The query must be sorted on the field then _id in the same direction
+func KeysetAfter(field string, direction int, lastValue interface{}, lastID interface{}) bson.D {
+	if field == "_id" {
+		return keysetCondition(bson.D{{Key: "_id", Value: direction}}, bson.A{lastID})
+	}
+	return keysetCondition(withTiebreaker(bson.D{{Key: field, Value: direction}}), bson.A{lastValue, lastID})
+}
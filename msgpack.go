@@ -0,0 +1,244 @@
+package mongodb
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// msgpack timestamp extension type
+const msgpackTimestampExt = -1
+
+var errMsgpackShort = errors.New("unexpected end of data")
+
+// decodeMsgpack decodes one msgpack value into its BSON counterpart and returns the remaining bytes
+// Maps become bson.D keeping key order, arrays bson.A, binaries primitive.Binary and timestamps time.Time
+func decodeMsgpack(data []byte) (value interface{}, rest []byte, err error) {
+	if len(data) == 0 {
+		return nil, nil, errMsgpackShort
+	}
+	b := data[0]
+	data = data[1:]
+
+	switch {
+	// Fixed types
+	case b <= 0x7f:
+		return int32(b), data, nil
+	case b >= 0xe0:
+		return int32(int8(b)), data, nil
+	case b&0xf0 == 0x80:
+		return decodeMsgpackMap(data, int(b&0x0f))
+	case b&0xf0 == 0x90:
+		return decodeMsgpackArray(data, int(b&0x0f))
+	case b&0xe0 == 0xa0:
+		return decodeMsgpackString(data, int(b&0x1f))
+	}
+
+	switch b {
+	case 0xc0:
+		return nil, data, nil
+	case 0xc2:
+		return false, data, nil
+	case 0xc3:
+		return true, data, nil
+
+	// Binary
+	case 0xc4, 0xc5, 0xc6:
+		n, data, err := msgpackLength(data, b-0xc4)
+		if err != nil {
+			return nil, nil, err
+		}
+		if len(data) < n {
+			return nil, nil, errMsgpackShort
+		}
+		return primitive.Binary{Data: append([]byte{}, data[:n]...)}, data[n:], nil
+
+	// Extensions
+	case 0xc7, 0xc8, 0xc9:
+		n, data, err := msgpackLength(data, b-0xc7)
+		if err != nil {
+			return nil, nil, err
+		}
+		return decodeMsgpackExt(data, n)
+	case 0xd4, 0xd5, 0xd6, 0xd7, 0xd8:
+		return decodeMsgpackExt(data, 1<<(b-0xd4))
+
+	// Floats
+	case 0xca:
+		if len(data) < 4 {
+			return nil, nil, errMsgpackShort
+		}
+		return float64(math.Float32frombits(binary.BigEndian.Uint32(data))), data[4:], nil
+	case 0xcb:
+		if len(data) < 8 {
+			return nil, nil, errMsgpackShort
+		}
+		return math.Float64frombits(binary.BigEndian.Uint64(data)), data[8:], nil
+
+	// Unsigned integers
+	case 0xcc, 0xcd, 0xce, 0xcf:
+		size := 1 << (b - 0xcc)
+		if len(data) < size {
+			return nil, nil, errMsgpackShort
+		}
+		u := msgpackUint(data[:size])
+		if u > math.MaxInt64 {
+			return nil, nil, errors.New("unsigned integer overflows int64")
+		}
+		return msgpackInt(int64(u)), data[size:], nil
+
+	// Signed integers
+	case 0xd0, 0xd1, 0xd2, 0xd3:
+		size := 1 << (b - 0xd0)
+		if len(data) < size {
+			return nil, nil, errMsgpackShort
+		}
+		u := msgpackUint(data[:size])
+		var i int64
+		switch size {
+		case 1:
+			i = int64(int8(u))
+		case 2:
+			i = int64(int16(u))
+		case 4:
+			i = int64(int32(u))
+		default:
+			i = int64(u)
+		}
+		return msgpackInt(i), data[size:], nil
+
+	// Strings
+	case 0xd9, 0xda, 0xdb:
+		n, data, err := msgpackLength(data, b-0xd9)
+		if err != nil {
+			return nil, nil, err
+		}
+		return decodeMsgpackString(data, n)
+
+	// Arrays
+	case 0xdc, 0xdd:
+		n, data, err := msgpackLength(data, b-0xdc+1)
+		if err != nil {
+			return nil, nil, err
+		}
+		return decodeMsgpackArray(data, n)
+
+	// Maps
+	case 0xde, 0xdf:
+		n, data, err := msgpackLength(data, b-0xde+1)
+		if err != nil {
+			return nil, nil, err
+		}
+		return decodeMsgpackMap(data, n)
+	}
+
+	return nil, nil, fmt.Errorf("unsupported msgpack type 0x%x", b)
+}
+
+// msgpackLength reads a big endian length of 1, 2 or 4 bytes given by its power of two
+func msgpackLength(data []byte, power byte) (n int, rest []byte, err error) {
+	size := 1 << power
+	if len(data) < size {
+		return 0, nil, errMsgpackShort
+	}
+	return int(msgpackUint(data[:size])), data[size:], nil
+}
+
+// msgpackUint ...
+func msgpackUint(data []byte) (u uint64) {
+	for _, b := range data {
+		u = u<<8 | uint64(b)
+	}
+	return u
+}
+
+// msgpackInt keeps integers as int32 when they fit, like the mongo shell
+func msgpackInt(i int64) interface{} {
+	if i >= math.MinInt32 && i <= math.MaxInt32 {
+		return int32(i)
+	}
+	return i
+}
+
+// decodeMsgpackString ...
+func decodeMsgpackString(data []byte, n int) (value interface{}, rest []byte, err error) {
+	if len(data) < n {
+		return nil, nil, errMsgpackShort
+	}
+	return string(data[:n]), data[n:], nil
+}
+
+// decodeMsgpackArray ...
+func decodeMsgpackArray(data []byte, n int) (value interface{}, rest []byte, err error) {
+	// Every item takes a byte at least, n is checked before allocating as it comes from the payload
+	if n > len(data) {
+		return nil, nil, errMsgpackShort
+	}
+	arr := make(bson.A, 0, n)
+	for i := 0; i < n; i++ {
+		var item interface{}
+		item, data, err = decodeMsgpack(data)
+		if err != nil {
+			return nil, nil, err
+		}
+		arr = append(arr, item)
+	}
+	return arr, data, nil
+}
+
+// decodeMsgpackMap ...
+func decodeMsgpackMap(data []byte, n int) (value interface{}, rest []byte, err error) {
+	// Every entry takes two bytes at least, n is checked before allocating as it comes from the payload
+	if n > len(data)/2 {
+		return nil, nil, errMsgpackShort
+	}
+	doc := make(bson.D, 0, n)
+	for i := 0; i < n; i++ {
+		var key, item interface{}
+		key, data, err = decodeMsgpack(data)
+		if err != nil {
+			return nil, nil, err
+		}
+		name, ok := key.(string)
+		if !ok {
+			return nil, nil, fmt.Errorf("map key %v is not a string", key)
+		}
+		item, data, err = decodeMsgpack(data)
+		if err != nil {
+			return nil, nil, err
+		}
+		doc = append(doc, bson.E{Key: name, Value: item})
+	}
+	return doc, data, nil
+}
+
+// decodeMsgpackExt supports the timestamp extension only
+func decodeMsgpackExt(data []byte, n int) (value interface{}, rest []byte, err error) {
+	if len(data) < n+1 {
+		return nil, nil, errMsgpackShort
+	}
+	extType := int8(data[0])
+	payload := data[1 : n+1]
+	rest = data[n+1:]
+	if extType != msgpackTimestampExt {
+		return nil, nil, fmt.Errorf("unsupported msgpack extension type %d", extType)
+	}
+
+	switch n {
+	case 4:
+		return time.Unix(int64(binary.BigEndian.Uint32(payload)), 0).UTC(), rest, nil
+	case 8:
+		v := binary.BigEndian.Uint64(payload)
+		return time.Unix(int64(v&0x3ffffffff), int64(v>>34)).UTC(), rest, nil
+	case 12:
+		nsec := binary.BigEndian.Uint32(payload[:4])
+		sec := int64(binary.BigEndian.Uint64(payload[4:]))
+		return time.Unix(sec, int64(nsec)).UTC(), rest, nil
+	}
+	return nil, nil, fmt.Errorf("invalid msgpack timestamp length %d", n)
+}
@@ -2,32 +2,159 @@ package mongodb
 
 import (
 	"context"
+	"sync"
 
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/bsoncodec"
+	"go.mongodb.org/mongo-driver/event"
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.mongodb.org/mongo-driver/mongo/readpref"
 )
 
 // Client ...
 type Client struct {
-	database *mongo.Database
+	client             *mongo.Client
+	database           *mongo.Database
+	journal            *journal // Local write ahead journal, nil when disabled
+	fetchParallelism   int
+	defaultIDGenerator IDGenerator
+	partitions         map[string]*mongo.Database // Databases of the partitions by name
+	partitionOf        map[string]string          // Partition name by collection
+	readPref           *readpref.ReadPref         // Default read preference, nil for primary
+	readBalancer       *readBalancer              // Round robin of reads across tagged secondaries, nil when disabled
+	stats              *statsCollector            // Command statistics
+	commandMonitor     *event.CommandMonitor      // Monitor of the commands of every pool
+	panicHandler       func(err *PanicError)      // Receives recovered callback panics
+	aggregateGuard     *aggregateGuard            // Guardrails of aggregation pipelines, nil when disabled
+	queryGuard         *queryGuard                // Collection scan checks of strict mode, nil when disabled
+	tenantMeter        *tenantMeter               // Usage per tenant, nil when disabled
+	quotas             tenantQuotas               // Quotas per tenant
+	limits             *ResultLimits              // Result limits of reads without limits in their context, nil for none
+	operations         *operationLog              // Last operations for post-mortems, nil when disabled
+	capabilities       *ServerCapabilities        // Features of the deployment detected at connect time
+	gridFS             *GridFSConfig              // Bucket settings of the GridFS APIs, nil for the defaults
+	registry           *bsoncodec.Registry        // Registry of the connection, nil for the default one
+	mu                 sync.RWMutex
+	collections        map[string]*collectionSettings // Client side rules per collection
 }
 
+// Wrappers accept native driver options as an escape hatch, they are applied after the library defaults and win on conflicts
+// Writes queued in the local journal are replayed without them
+
 // CreateOne ...
-func (c *Client) CreateOne(ctx context.Context, collection string, document interface{}) (err error) {
-	// Hits DB
-	_, err = c.database.Collection(collection).InsertOne(ctx, document)
+func (c *Client) CreateOne(ctx context.Context, collection string, document interface{}, opts ...*options.InsertOneOptions) (err error) {
+	// Checks tenant quota
+	err = c.checkQuota(ctx, true)
+	if err != nil {
+		return err
+	}
+
+	// Applies collection rules
+	document, err = c.prepareInsert(collection, document)
 	if err != nil {
 		return err
 	}
 
+	// Checks journal
+	if c.journaling(ctx) {
+		// Assigns id so a replayed insert is idempotent
+		document, err = withID(document, nil)
+		if err != nil {
+			return err
+		}
+		if c.journal.hasPending() {
+			return c.journalWrite(journalInsert, collection, nil, document)
+		}
+	}
+
+	// Hits DB
+	_, err = c.collection(ctx, collection).InsertOne(ctx, document, opts...)
+	if err != nil {
+		if c.journaling(ctx) && isUnreachable(err) {
+			return c.journalWrite(journalInsert, collection, nil, document)
+		}
+		return duplicateError(err)
+	}
+	c.addTenantStorage(ctx, document)
+
 	// Returns
 	return nil
 }
 
+// CreateMany inserts the documents and returns their _id in the order of the documents
+// Ordered inserts stop at the first failure, unordered ones insert every valid document
+// Failed documents are returned as a *BulkError, ids then only hold the inserted documents
+func (c *Client) CreateMany(ctx context.Context, collection string, documents []interface{}, ordered bool, opts ...*options.InsertManyOptions) (ids []interface{}, err error) {
+	if len(documents) == 0 {
+		return nil, nil
+	}
+
+	// Checks tenant quota
+	err = c.checkQuota(ctx, true)
+	if err != nil {
+		return nil, err
+	}
+
+	// Applies collection rules
+	docs := make([]interface{}, len(documents))
+	for i, document := range documents {
+		docs[i], err = c.prepareInsert(collection, document)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// Hits DB
+	res, err := c.collection(ctx, collection).InsertMany(ctx, docs, append([]*options.InsertManyOptions{options.InsertMany().SetOrdered(ordered)}, opts...)...)
+	if err == nil {
+		c.addTenantStorage(ctx, docs...)
+		return res.InsertedIDs, nil
+	}
+
+	// Keeps ids of inserted documents, the driver returns the ids of every document
+	converted := newBulkError(err, 0)
+	bulkErr, ok := converted.(*BulkError)
+	if !ok || res == nil {
+		return nil, converted
+	}
+	failed := map[int]bool{}
+	for _, index := range bulkErr.FailedIndexes() {
+		failed[index] = true
+	}
+	for i, id := range res.InsertedIDs {
+		if failed[i] {
+			if ordered {
+				break
+			}
+			continue
+		}
+		ids = append(ids, id)
+	}
+	c.addTenantStorage(ctx, bulkErr.written(docs, ordered)...)
+
+	// Returns
+	return ids, bulkErr
+}
+
 // ReadOne ...
-func (c *Client) ReadOne(ctx context.Context, collection string, query interface{}) (res interface{}, err error) {
+func (c *Client) ReadOne(ctx context.Context, collection string, query interface{}, opts ...*options.FindOneOptions) (res interface{}, err error) {
+	// Checks tenant quota
+	err = c.checkQuota(ctx, false)
+	if err != nil {
+		return nil, err
+	}
+
+	// Checks strict mode
+	err = c.checkFindOne(ctx, collection, query, opts)
+	if err != nil {
+		return nil, err
+	}
+
 	// Hits DB
-	err = c.database.Collection(collection).FindOne(ctx, query).Decode(&res)
+	coll, done := c.readCollection(ctx, collection)
+	defer func() { done(err) }()
+	err = coll.FindOne(ctx, query, append([]*options.FindOneOptions{c.findOneOptions(collection)}, opts...)...).Decode(&res)
 	if err != nil {
 		// Handles no document found
 		if err == mongo.ErrNoDocuments {
@@ -37,27 +164,254 @@ func (c *Client) ReadOne(ctx context.Context, collection string, query interface
 		return nil, err
 	}
 
+	// Verifies checksums
+	err = c.verifyChecksums(collection, res)
+	if err != nil {
+		return nil, err
+	}
+
+	// Decompresses fields
+	err = c.decompressResults(collection, res)
+	if err != nil {
+		return nil, err
+	}
+
 	// Returns
 	return res, nil
 }
 
 // UpdateOne ...
-func (c *Client) UpdateOne(ctx context.Context, collection string, query interface{}, fields interface{}) (err error) {
+func (c *Client) UpdateOne(ctx context.Context, collection string, query interface{}, fields interface{}, opts ...*options.UpdateOptions) (err error) {
+	// Checks tenant quota
+	err = c.checkQuota(ctx, true)
+	if err != nil {
+		return err
+	}
+
+	// Checks append-only
+	err = c.checkAppendOnly(ctx, collection, "UpdateOne")
+	if err != nil {
+		return err
+	}
+
+	// Applies collection rules
+	fields, err = c.prepareUpdate(collection, fields)
+	if err != nil {
+		return err
+	}
+
+	// Checks journal
+	if c.journaling(ctx) && c.journal.hasPending() {
+		return c.journalWrite(journalUpdate, collection, query, fields, opts...)
+	}
+
+	// Hits DB
+	_, err = c.collection(ctx, collection).UpdateOne(ctx, query, fields, opts...)
+	if err != nil {
+		if c.journaling(ctx) && isUnreachable(err) {
+			return c.journalWrite(journalUpdate, collection, query, fields, opts...)
+		}
+		return duplicateError(err)
+	}
+
+	// Returns
+	return nil
+}
+
+// UpsertOne updates the document matching the query, inserting one built from the query and the update when none matches
+// upsertedID is the _id of the inserted document, nil when an existing document was updated
+func (c *Client) UpsertOne(ctx context.Context, collection string, query interface{}, fields interface{}, opts ...*options.UpdateOptions) (upsertedID interface{}, err error) {
+	// Checks tenant quota
+	err = c.checkQuota(ctx, true)
+	if err != nil {
+		return nil, err
+	}
+
+	// Checks append-only
+	err = c.checkAppendOnly(ctx, collection, "UpsertOne")
+	if err != nil {
+		return nil, err
+	}
+
+	// Applies collection rules
+	fields, err = c.prepareUpdate(collection, fields)
+	if err != nil {
+		return nil, err
+	}
+
 	// Hits DB
-	_, err = c.database.Collection(collection).UpdateOne(ctx, query, fields)
+	result, err := c.collection(ctx, collection).UpdateOne(ctx, query, fields, append([]*options.UpdateOptions{options.Update().SetUpsert(true)}, opts...)...)
+	if err != nil {
+		return nil, duplicateError(err)
+	}
+
+	// Returns
+	return result.UpsertedID, nil
+}
+
+// UpdateMany updates every document matching the query
+func (c *Client) UpdateMany(ctx context.Context, collection string, query interface{}, fields interface{}, opts ...*options.UpdateOptions) (matched int64, modified int64, err error) {
+	// Checks tenant quota
+	err = c.checkQuota(ctx, true)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	// Checks append-only
+	err = c.checkAppendOnly(ctx, collection, "UpdateMany")
+	if err != nil {
+		return 0, 0, err
+	}
+
+	// Applies collection rules
+	fields, err = c.prepareUpdate(collection, fields)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	// Checks journal
+	if c.journaling(ctx) && c.journal.hasPending() {
+		return 0, 0, c.journalWrite(journalUpdateMany, collection, query, fields, opts...)
+	}
+
+	// Hits DB
+	result, err := c.collection(ctx, collection).UpdateMany(ctx, query, fields, opts...)
+	if err != nil {
+		if c.journaling(ctx) && isUnreachable(err) {
+			return 0, 0, c.journalWrite(journalUpdateMany, collection, query, fields, opts...)
+		}
+		return 0, 0, duplicateError(err)
+	}
+
+	// Returns
+	return result.MatchedCount, result.ModifiedCount, nil
+}
+
+// SaveByID replaces the document having the _id or inserts it when missing, in a single upsert call
+func (c *Client) SaveByID(ctx context.Context, collection string, id interface{}, document interface{}, opts ...*options.ReplaceOptions) (err error) {
+	// Checks tenant quota
+	err = c.checkQuota(ctx, true)
 	if err != nil {
 		return err
 	}
 
+	// Checks append-only
+	err = c.checkAppendOnly(ctx, collection, "SaveByID")
+	if err != nil {
+		return err
+	}
+
+	// Applies collection rules
+	document, err = c.prepareUpdate(collection, document)
+	if err != nil {
+		return err
+	}
+
+	// Hits DB
+	_, err = c.collection(ctx, collection).ReplaceOne(ctx, bson.D{{Key: "_id", Value: id}}, document, append([]*options.ReplaceOptions{options.Replace().SetUpsert(true)}, opts...)...)
+	if err != nil {
+		return duplicateError(err)
+	}
+
 	// Returns
 	return nil
 }
 
+// ReplaceResult ...
+type ReplaceResult struct {
+	MatchedCount  int64
+	ModifiedCount int64
+	UpsertedID    interface{} // _id of the inserted document when the upsert inserted one, else nil
+}
+
+// ReplaceOne atomically replaces the whole document matching the query, inserting the replacement when none matches and upsert is set
+func (c *Client) ReplaceOne(ctx context.Context, collection string, query interface{}, replacement interface{}, upsert bool, opts ...*options.ReplaceOptions) (res *ReplaceResult, err error) {
+	// Checks tenant quota
+	err = c.checkQuota(ctx, true)
+	if err != nil {
+		return nil, err
+	}
+
+	// Checks append-only
+	err = c.checkAppendOnly(ctx, collection, "ReplaceOne")
+	if err != nil {
+		return nil, err
+	}
+
+	// Applies collection rules
+	replacement, err = c.prepareUpdate(collection, replacement)
+	if err != nil {
+		return nil, err
+	}
+
+	// Hits DB
+	result, err := c.collection(ctx, collection).ReplaceOne(ctx, query, replacement, append([]*options.ReplaceOptions{options.Replace().SetUpsert(upsert)}, opts...)...)
+	if err != nil {
+		return nil, duplicateError(err)
+	}
+
+	// Returns
+	return &ReplaceResult{MatchedCount: result.MatchedCount, ModifiedCount: result.ModifiedCount, UpsertedID: result.UpsertedID}, nil
+}
+
 // DeleteOne ...
-func (c *Client) DeleteOne(ctx context.Context, collection string, query interface{}) (count int64, err error) {
+func (c *Client) DeleteOne(ctx context.Context, collection string, query interface{}, opts ...*options.DeleteOptions) (count int64, err error) {
+	// Checks tenant quota
+	err = c.checkQuota(ctx, false)
+	if err != nil {
+		return 0, err
+	}
+
+	// Checks append-only
+	err = c.checkAppendOnly(ctx, collection, "DeleteOne")
+	if err != nil {
+		return 0, err
+	}
+
+	// Checks journal
+	if c.journaling(ctx) && c.journal.hasPending() {
+		return 0, c.journalWrite(journalDelete, collection, query, nil)
+	}
+
+	// Hits DB
+	result, err := c.collection(ctx, collection).DeleteOne(ctx, query, opts...)
+	if err != nil {
+		if c.journaling(ctx) && isUnreachable(err) {
+			return 0, c.journalWrite(journalDelete, collection, query, nil)
+		}
+		return 0, err
+	}
+	count = result.DeletedCount
+
+	// Returns
+	return count, nil
+}
+
+// DeleteMany deletes every document matching the query
+func (c *Client) DeleteMany(ctx context.Context, collection string, query interface{}, opts ...*options.DeleteOptions) (count int64, err error) {
+	// Checks tenant quota
+	err = c.checkQuota(ctx, false)
+	if err != nil {
+		return 0, err
+	}
+
+	// Checks append-only
+	err = c.checkAppendOnly(ctx, collection, "DeleteMany")
+	if err != nil {
+		return 0, err
+	}
+
+	// Checks journal
+	if c.journaling(ctx) && c.journal.hasPending() {
+		return 0, c.journalWrite(journalDeleteMany, collection, query, nil)
+	}
+
 	// Hits DB
-	result, err := c.database.Collection(collection).DeleteOne(ctx, query)
+	result, err := c.collection(ctx, collection).DeleteMany(ctx, query, opts...)
 	if err != nil {
+		if c.journaling(ctx) && isUnreachable(err) {
+			return 0, c.journalWrite(journalDeleteMany, collection, query, nil)
+		}
 		return 0, err
 	}
 	count = result.DeletedCount
@@ -67,9 +421,29 @@ func (c *Client) DeleteOne(ctx context.Context, collection string, query interfa
 }
 
 // Read ...
-func (c *Client) Read(ctx context.Context, collection string, query interface{}) (res []interface{}, err error) {
+func (c *Client) Read(ctx context.Context, collection string, query interface{}, opts ...*options.FindOptions) (res []interface{}, err error) {
+	// Checks tenant quota
+	err = c.checkQuota(ctx, false)
+	if err != nil {
+		return nil, err
+	}
+
+	// Checks strict mode
+	err = c.checkFind(ctx, collection, query, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	// Limits result to the tenant quota and the result limits
+	maxResult, opts := c.resultQuota(ctx, opts)
+	if limits := c.resultLimits(ctx); limits != nil && limits.MaxDocuments > 0 {
+		opts = limitFind(opts, limits.MaxDocuments+1)
+	}
+
 	// Hits DB
-	cursor, err := c.database.Collection(collection).Find(ctx, query)
+	coll, done := c.readCollection(ctx, collection)
+	defer func() { done(err) }()
+	cursor, err := coll.Find(ctx, query, append([]*options.FindOptions{c.findOptions(collection)}, opts...)...)
 	if err != nil {
 		return nil, err
 	}
@@ -78,22 +452,61 @@ func (c *Client) Read(ctx context.Context, collection string, query interface{})
 	defer cursor.Close(ctx)
 
 	// Binds cursor response
-	err = cursor.All(ctx, &res)
+	partial, err := c.bindResults(ctx, collection, cursor, &res)
 	if err != nil {
 		return nil, err
 	}
-	if len(res) == 0 {
+	err = checkResultQuota(ctx, maxResult, len(res))
+	if err != nil {
+		return nil, err
+	}
+	if len(res) == 0 && partial == nil {
 		return nil, nil
 	}
 
+	// Verifies checksums
+	err = c.verifyChecksums(collection, res...)
+	if err != nil {
+		return nil, err
+	}
+
+	// Decompresses fields
+	err = c.decompressResults(collection, res...)
+	if err != nil {
+		return nil, err
+	}
+
 	// Returns
+	if partial != nil {
+		return res, partial
+	}
 	return res, nil
 }
 
 // ReadWithProjection ...
-func (c *Client) ReadWithProjection(ctx context.Context, collection string, query interface{}, projection interface{}) (res []interface{}, err error) {
+func (c *Client) ReadWithProjection(ctx context.Context, collection string, query interface{}, projection interface{}, opts ...*options.FindOptions) (res []interface{}, err error) {
+	// Checks tenant quota
+	err = c.checkQuota(ctx, false)
+	if err != nil {
+		return nil, err
+	}
+
+	// Checks strict mode
+	err = c.checkFind(ctx, collection, query, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	// Limits result to the tenant quota and the result limits
+	maxResult, opts := c.resultQuota(ctx, opts)
+	if limits := c.resultLimits(ctx); limits != nil && limits.MaxDocuments > 0 {
+		opts = limitFind(opts, limits.MaxDocuments+1)
+	}
+
 	// Hits DB
-	cursor, err := c.database.Collection(collection).Find(ctx, query, options.Find().SetProjection(projection))
+	coll, done := c.readCollection(ctx, collection)
+	defer func() { done(err) }()
+	cursor, err := coll.Find(ctx, query, append([]*options.FindOptions{c.findOptions(collection).SetProjection(projection)}, opts...)...)
 	if err != nil {
 		return nil, err
 	}
@@ -102,14 +515,62 @@ func (c *Client) ReadWithProjection(ctx context.Context, collection string, quer
 	defer cursor.Close(ctx)
 
 	// Binds cursor response
-	err = cursor.All(ctx, &res)
+	partial, err := c.bindResults(ctx, collection, cursor, &res)
+	if err != nil {
+		return nil, err
+	}
+	err = checkResultQuota(ctx, maxResult, len(res))
 	if err != nil {
 		return nil, err
 	}
-	if len(res) == 0 {
+	if len(res) == 0 && partial == nil {
 		return nil, nil
 	}
 
+	// Decompresses fields
+	err = c.decompressResults(collection, res...)
+	if err != nil {
+		return nil, err
+	}
+
 	// Returns
+	if partial != nil {
+		return res, partial
+	}
 	return res, nil
 }
+
+// ReadOptions paginates and orders reads without the driver options
+type ReadOptions struct {
+	Sort       bson.D      // Sort, e.g. bson.D{{Key: "createdAt", Value: -1}}. (default is the default sort of the collection)
+	Skip       int64       // Documents skipped before the first returned one (default is 0)
+	Limit      int64       // Maximum number of returned documents (default is 0, no limit)
+	Projection interface{} // Returned fields, e.g. bson.M{"name": 1} (default is nil, whole documents)
+	BatchSize  int32       // Documents per server batch. (default is server default)
+}
+
+// ReadWithOptions reads the documents matching the query with the given sort, skip, limit, projection and batch size
+func (c *Client) ReadWithOptions(ctx context.Context, collection string, query interface{}, readOpts *ReadOptions) (res []interface{}, err error) {
+	// Builds options
+	findOpts := options.Find()
+	if readOpts != nil {
+		if readOpts.Sort != nil {
+			findOpts.SetSort(readOpts.Sort)
+		}
+		if readOpts.Skip != 0 {
+			findOpts.SetSkip(readOpts.Skip)
+		}
+		if readOpts.Limit != 0 {
+			findOpts.SetLimit(readOpts.Limit)
+		}
+		if readOpts.BatchSize != 0 {
+			findOpts.SetBatchSize(readOpts.BatchSize)
+		}
+		if readOpts.Projection != nil {
+			return c.ReadWithProjection(ctx, collection, query, readOpts.Projection, findOpts)
+		}
+	}
+
+	// Hits DB
+	return c.Read(ctx, collection, query, findOpts)
+}
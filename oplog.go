@@ -0,0 +1,202 @@
+package mongodb
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"sync"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/bsontype"
+	"go.mongodb.org/mongo-driver/event"
+)
+
+// Default number of operations kept by the operation log
+const defaultOperationLogSize = 1000
+
+// Value replacing redacted command values
+const redactedValue = "?"
+
+// Command fields carrying session and cluster metadata, never logged
+var operationLogSkippedFields = map[string]bool{"lsid": true, "$clusterTime": true, "txnNumber": true, "$readPreference": true, "signature": true}
+
+// OperationLogConfig ...
+type OperationLogConfig struct {
+	Size       int  // Last operations kept in memory, older ones are dropped. (default is 1000)
+	KeepValues bool // Logs the values of filters and documents, only top level command fields are kept otherwise. (default is false, values are redacted)
+}
+
+// RecentOperation is an operation kept by the operation log
+type RecentOperation struct {
+	Time       time.Time     `json:"time"`
+	Database   string        `json:"database"`
+	Collection string        `json:"collection,omitempty"`
+	Command    string        `json:"command"`
+	Request    string        `json:"request"` // Redacted command as relaxed extended JSON
+	Duration   time.Duration `json:"durationNanos"`
+	Error      string        `json:"error,omitempty"`
+}
+
+// operationLog keeps the last operations of a client in a ring buffer
+type operationLog struct {
+	size       int
+	keepValues bool
+	mu         sync.Mutex
+	started    map[int64]*RecentOperation
+	ring       []RecentOperation
+	next       int // Position of the next operation in the ring
+}
+
+// newOperationLog ...
+func newOperationLog(config *OperationLogConfig) *operationLog {
+	size := config.Size
+	if size <= 0 {
+		size = defaultOperationLogSize
+	}
+	return &operationLog{size: size, keepValues: config.KeepValues, started: map[int64]*RecentOperation{}}
+}
+
+// monitor returns the command monitor feeding the log
+func (l *operationLog) monitor() *event.CommandMonitor {
+	return &event.CommandMonitor{
+		Started: func(_ context.Context, e *event.CommandStartedEvent) {
+			op := &RecentOperation{Time: time.Now(), Database: e.DatabaseName, Command: e.CommandName}
+			if elem, err := e.Command.IndexErr(0); err == nil {
+				op.Collection, _ = elem.Value().StringValueOK()
+			}
+			request, err := bson.MarshalExtJSON(l.redact(e.Command, true), false, false)
+			if err != nil {
+				op.Request = "unprintable command -> " + err.Error()
+			} else {
+				op.Request = string(request)
+			}
+			l.mu.Lock()
+			l.started[e.RequestID] = op
+			l.mu.Unlock()
+		},
+		Succeeded: func(_ context.Context, e *event.CommandSucceededEvent) {
+			failure := ""
+			if writeErrors, err := e.Reply.LookupErr("writeErrors"); err == nil {
+				failure = "write errors -> " + writeErrors.String()
+			} else if concernErr, err := e.Reply.LookupErr("writeConcernError"); err == nil {
+				failure = "write concern error -> " + concernErr.String()
+			}
+			l.finish(e.RequestID, time.Duration(e.DurationNanos), failure)
+		},
+		Failed: func(_ context.Context, e *event.CommandFailedEvent) {
+			l.finish(e.RequestID, time.Duration(e.DurationNanos), e.Failure)
+		},
+	}
+}
+
+// finish moves a started operation into the ring
+func (l *operationLog) finish(requestID int64, duration time.Duration, failure string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	op, ok := l.started[requestID]
+	if !ok {
+		return
+	}
+	delete(l.started, requestID)
+	op.Duration = duration
+	op.Error = failure
+
+	// Appends, overwriting the oldest once full
+	if len(l.ring) < l.size {
+		l.ring = append(l.ring, *op)
+	} else {
+		l.ring[l.next] = *op
+	}
+	l.next = (l.next + 1) % l.size
+}
+
+// recent returns the kept operations, oldest first
+func (l *operationLog) recent() []RecentOperation {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if len(l.ring) < l.size {
+		return append([]RecentOperation(nil), l.ring...)
+	}
+	return append(append([]RecentOperation(nil), l.ring[l.next:]...), l.ring[:l.next]...)
+}
+
+// redact keeps the top level scalar fields of a command and replaces nested values by "?"
+func (l *operationLog) redact(raw bson.Raw, top bool) bson.D {
+	elems, err := raw.Elements()
+	if err != nil {
+		return bson.D{{Key: "error", Value: err.Error()}}
+	}
+	doc := make(bson.D, 0, len(elems))
+	for _, elem := range elems {
+		key, value := elem.Key(), elem.Value()
+		if top && operationLogSkippedFields[key] {
+			continue
+		}
+		switch {
+		case value.Type == bsontype.EmbeddedDocument:
+			doc = append(doc, bson.E{Key: key, Value: l.redact(value.Document(), false)})
+		case value.Type == bsontype.Array:
+			doc = append(doc, bson.E{Key: key, Value: l.redactArray(value.Array())})
+		case top || l.keepValues:
+			doc = append(doc, bson.E{Key: key, Value: value})
+		default:
+			doc = append(doc, bson.E{Key: key, Value: redactedValue})
+		}
+	}
+	return doc
+}
+
+// redactArray ...
+func (l *operationLog) redactArray(raw bson.Raw) bson.A {
+	values, err := raw.Values()
+	if err != nil {
+		return bson.A{err.Error()}
+	}
+	arr := make(bson.A, len(values))
+	for i, value := range values {
+		switch {
+		case value.Type == bsontype.EmbeddedDocument:
+			arr[i] = l.redact(value.Document(), false)
+		case value.Type == bsontype.Array:
+			arr[i] = l.redactArray(value.Array())
+		case l.keepValues:
+			arr[i] = value
+		default:
+			arr[i] = redactedValue
+		}
+	}
+	return arr
+}
+
+// RecentOperations returns the last operations kept by the operation log, oldest first
+func (c *Client) RecentOperations() []RecentOperation {
+	if c.operations == nil {
+		return nil
+	}
+	return c.operations.recent()
+}
+
+// DumpRecentOperations writes the last operations kept by the operation log as JSON lines, oldest first
+// Meant to be called when an incident is detected, e.g. from an alert hook or a debug endpoint
+func (c *Client) DumpRecentOperations(w io.Writer) (err error) {
+	// Checks log
+	if c.operations == nil {
+		return errors.New("operation log is disabled, set Config.OperationLog")
+	}
+
+	// Writes
+	encoder := json.NewEncoder(w)
+	for _, op := range c.operations.recent() {
+		err = encoder.Encode(op)
+		if err != nil {
+			return err
+		}
+	}
+
+	// Returns
+	return nil
+}
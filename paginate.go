@@ -0,0 +1,91 @@
+package mongodb
+
+import (
+	"context"
+	"errors"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// Page is a page of an offset paginated read with the total count of the matching documents
+type Page struct {
+	Items   []interface{}
+	Total   int64 // Documents matching the filter
+	Page    int64 // Number of the page, from 1
+	PerPage int64
+	Pages   int64 // Number of pages
+}
+
+// Paginate returns a page, numbered from 1, of the documents matching the filter with their total count in a single $facet aggregation
+// The sort gets an _id tiebreaker so pages do not overlap (default is the default sort of the collection, else _id ascending)
+// The page is returned in one document so it must fit 16 MB, deep pages skip every previous document, prefer ReadKeyset for feeds
+// Opts may set allowDiskUse, required by Config.Aggregation to sort large collections without an index on the sort, maxTime, collation, hint...
+func (c *Client) Paginate(ctx context.Context, collection string, filter interface{}, page int64, perPage int64, sort bson.D, opts *options.AggregateOptions) (res *Page, err error) {
+	if page < 1 || perPage < 1 {
+		return nil, errors.New("page and per page must be positive")
+	}
+
+	// Builds pipeline
+	if filter == nil {
+		filter = bson.D{}
+	}
+	if len(sort) == 0 {
+		if s := c.settings(collection); s != nil {
+			sort = s.defaultSort
+		}
+	}
+	if len(sort) == 0 {
+		sort = bson.D{{Key: "_id", Value: 1}}
+	}
+	pipeline := bson.A{
+		bson.D{{Key: "$match", Value: filter}},
+		bson.D{{Key: "$sort", Value: withTiebreaker(sort)}},
+		bson.D{{Key: "$facet", Value: bson.D{
+			{Key: "items", Value: bson.A{
+				bson.D{{Key: "$skip", Value: (page - 1) * perPage}},
+				bson.D{{Key: "$limit", Value: perPage}},
+			}},
+			{Key: "total", Value: bson.A{bson.D{{Key: "$count", Value: "n"}}}},
+		}}},
+	}
+
+	// Hits DB, the single $facet document is bounded by the page size rather than the result limits
+	var facets []struct {
+		Items []interface{} `bson:"items"`
+		Total []struct {
+			N int64 `bson:"n"`
+		} `bson:"total"`
+	}
+	err = c.Aggregate(WithResultLimits(ctx, nil), collection, pipeline, &facets, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	// Binds facets
+	res = &Page{Items: []interface{}{}, Page: page, PerPage: perPage}
+	if len(facets) != 0 {
+		if facets[0].Items != nil {
+			res.Items = facets[0].Items
+		}
+		if len(facets[0].Total) != 0 {
+			res.Total = facets[0].Total[0].N
+		}
+	}
+	res.Pages = (res.Total + perPage - 1) / perPage
+
+	// Verifies checksums
+	err = c.verifyChecksums(collection, res.Items...)
+	if err != nil {
+		return nil, err
+	}
+
+	// Decompresses fields
+	err = c.decompressResults(collection, res.Items...)
+	if err != nil {
+		return nil, err
+	}
+
+	// Returns
+	return res, nil
+}
@@ -0,0 +1,38 @@
+package mongodb
+
+import (
+	"fmt"
+	"runtime/debug"
+)
+
+// PanicError is a panic of a user callback recovered by the library
+// The operation invoking the callback fails with it instead of crashing the process
+type PanicError struct {
+	Callback string      // Callback which panicked, e.g. "RenameOptions.OnProgress"
+	Value    interface{} // Value given to panic
+	Stack    []byte      // Stack trace of the panicking goroutine
+}
+
+// Error ...
+func (e *PanicError) Error() string {
+	return fmt.Sprintf("%s panicked -> %v", e.Callback, e.Value)
+}
+
+// safeCall runs a user callback, a panic is recovered, reported to the handler and returned as a *PanicError
+func safeCall(handler func(err *PanicError), callback string, fn func()) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			panicErr := &PanicError{Callback: callback, Value: r, Stack: debug.Stack()}
+			if handler != nil {
+				// A panicking handler must not escape either
+				func() {
+					defer func() { recover() }()
+					handler(panicErr)
+				}()
+			}
+			err = panicErr
+		}
+	}()
+	fn()
+	return nil
+}
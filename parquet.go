@@ -0,0 +1,422 @@
+package mongodb
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Parquet magic bytes, at the start and the end of files
+const parquetMagic = "PAR1"
+
+// Parquet physical types, encodings and converted types of the format specification
+const (
+	parquetBoolean   = 0
+	parquetInt64     = 2
+	parquetDouble    = 5
+	parquetByteArray = 6
+
+	parquetPlain = 0
+	parquetRLE   = 3
+
+	parquetUTF8            = 0
+	parquetTimestampMillis = 9
+
+	parquetDataPage = 0
+	parquetOptional = 1
+)
+
+// Thrift compact protocol types
+const (
+	thriftI32    = 5
+	thriftI64    = 6
+	thriftBinary = 8
+	thriftList   = 9
+	thriftStruct = 12
+)
+
+// parquetColumn is an optional flat column of a parquet file
+type parquetColumn struct {
+	name      string
+	kind      int // Physical type
+	timestamp bool
+}
+
+// parquetChunk is the metadata of a written column chunk
+type parquetChunk struct {
+	offset int64
+	size   int64
+	values int64
+}
+
+// parquetRowGroup is the metadata of a written row group
+type parquetRowGroup struct {
+	chunks []parquetChunk
+	rows   int64
+	size   int64
+}
+
+// parquetWriter writes rows of flat optional columns as an uncompressed parquet file, a row group at a time
+type parquetWriter struct {
+	w            io.Writer
+	offset       int64
+	columns      []parquetColumn
+	rowGroupSize int
+	rows         [][]interface{} // Rows of the pending row group
+	rowGroups    []parquetRowGroup
+	numRows      int64
+}
+
+// newParquetWriter writes the leading magic bytes
+func newParquetWriter(w io.Writer, columns []parquetColumn, rowGroupSize int) (p *parquetWriter, err error) {
+	p = &parquetWriter{w: w, columns: columns, rowGroupSize: rowGroupSize}
+	err = p.write([]byte(parquetMagic))
+	if err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// parquetColumnOf returns the column storing values like the given one, strings for nil and unsupported values
+func parquetColumnOf(name string, value interface{}) parquetColumn {
+	switch value.(type) {
+	case bool:
+		return parquetColumn{name: name, kind: parquetBoolean}
+	case int32, int64:
+		return parquetColumn{name: name, kind: parquetInt64}
+	case float64:
+		return parquetColumn{name: name, kind: parquetDouble}
+	case primitive.DateTime, time.Time:
+		return parquetColumn{name: name, kind: parquetInt64, timestamp: true}
+	}
+	return parquetColumn{name: name, kind: parquetByteArray}
+}
+
+// Write buffers a row, the row group is written once full
+func (p *parquetWriter) Write(row []interface{}) (err error) {
+	p.rows = append(p.rows, row)
+	if len(p.rows) >= p.rowGroupSize {
+		return p.flush()
+	}
+	return nil
+}
+
+// Close writes the pending row group and the footer
+func (p *parquetWriter) Close() (err error) {
+	err = p.flush()
+	if err != nil {
+		return err
+	}
+
+	// Writes footer
+	footer := p.footer()
+	length := make([]byte, 4)
+	binary.LittleEndian.PutUint32(length, uint32(len(footer)))
+	err = p.write(append(append(footer, length...), parquetMagic...))
+	if err != nil {
+		return err
+	}
+
+	// Returns
+	return nil
+}
+
+// flush writes the pending rows as a row group of one data page per column
+func (p *parquetWriter) flush() (err error) {
+	if len(p.rows) == 0 {
+		return nil
+	}
+	group := parquetRowGroup{rows: int64(len(p.rows))}
+	for i, column := range p.columns {
+		// Encodes values
+		defined := make([]bool, len(p.rows))
+		var values bytes.Buffer
+		var bools []bool
+		for r, row := range p.rows {
+			if row[i] == nil {
+				continue
+			}
+			defined[r] = true
+			err = column.encode(&values, &bools, row[i])
+			if err != nil {
+				return err
+			}
+		}
+		if column.kind == parquetBoolean {
+			values.Write(packBools(bools))
+		}
+
+		// Builds page
+		levels := rleBools(defined)
+		page := make([]byte, 4, 4+len(levels)+values.Len())
+		binary.LittleEndian.PutUint32(page, uint32(len(levels)))
+		page = append(append(page, levels...), values.Bytes()...)
+		header := &thriftWriter{}
+		header.i32(1, parquetDataPage)
+		header.i32(2, int32(len(page)))
+		header.i32(3, int32(len(page)))
+		header.structBegin(5)
+		header.i32(1, int32(len(p.rows)))
+		header.i32(2, parquetPlain)
+		header.i32(3, parquetRLE)
+		header.i32(4, parquetRLE)
+		header.structEnd()
+		header.stop()
+
+		// Writes page
+		chunk := parquetChunk{offset: p.offset, size: int64(header.buf.Len() + len(page)), values: int64(len(p.rows))}
+		err = p.write(append(header.buf.Bytes(), page...))
+		if err != nil {
+			return err
+		}
+		group.chunks = append(group.chunks, chunk)
+		group.size += chunk.size
+	}
+	p.rowGroups = append(p.rowGroups, group)
+	p.numRows += group.rows
+	p.rows = p.rows[:0]
+	return nil
+}
+
+// encode appends the PLAIN encoding of a value, booleans are collected to be bit packed
+func (c parquetColumn) encode(buf *bytes.Buffer, bools *[]bool, value interface{}) (err error) {
+	switch c.kind {
+	case parquetBoolean:
+		b, ok := value.(bool)
+		if !ok {
+			return fmt.Errorf("column %s holds %T, bool expected", c.name, value)
+		}
+		*bools = append(*bools, b)
+	case parquetInt64:
+		var n int64
+		switch v := value.(type) {
+		case int32:
+			n = int64(v)
+		case int64:
+			n = v
+		case primitive.DateTime:
+			n = int64(v)
+		case time.Time:
+			n = v.UnixMilli()
+		default:
+			return fmt.Errorf("column %s holds %T, integer or date expected", c.name, value)
+		}
+		if c.timestamp != isTime(value) {
+			return fmt.Errorf("column %s holds %T, mixing dates and integers", c.name, value)
+		}
+		binary.Write(buf, binary.LittleEndian, n)
+	case parquetDouble:
+		f, ok := toFloat(value)
+		if !ok {
+			return fmt.Errorf("column %s holds %T, number expected", c.name, value)
+		}
+		binary.Write(buf, binary.LittleEndian, math.Float64bits(f))
+	default:
+		s, err := cellString(value)
+		if err != nil {
+			return err
+		}
+		binary.Write(buf, binary.LittleEndian, uint32(len(s)))
+		buf.WriteString(s)
+	}
+	return nil
+}
+
+// footer encodes the file metadata
+func (p *parquetWriter) footer() []byte {
+	t := &thriftWriter{}
+	t.i32(1, 1)
+
+	// Writes schema
+	t.listBegin(2, thriftStruct, len(p.columns)+1)
+	t.elemBegin()
+	t.binary(4, "schema")
+	t.i32(5, int32(len(p.columns)))
+	t.elemEnd()
+	for _, column := range p.columns {
+		t.elemBegin()
+		t.i32(1, int32(column.kind))
+		t.i32(3, parquetOptional)
+		t.binary(4, column.name)
+		if column.kind == parquetByteArray {
+			t.i32(6, parquetUTF8)
+		} else if column.timestamp {
+			t.i32(6, parquetTimestampMillis)
+		}
+		t.elemEnd()
+	}
+	t.i64(3, p.numRows)
+
+	// Writes row groups
+	t.listBegin(4, thriftStruct, len(p.rowGroups))
+	for _, group := range p.rowGroups {
+		t.elemBegin()
+		t.listBegin(1, thriftStruct, len(group.chunks))
+		for i, chunk := range group.chunks {
+			column := p.columns[i]
+			t.elemBegin()
+			t.i64(2, chunk.offset)
+			t.structBegin(3)
+			t.i32(1, int32(column.kind))
+			t.listBegin(2, thriftI32, 2)
+			t.varint(zigzag(parquetPlain))
+			t.varint(zigzag(parquetRLE))
+			t.listBegin(3, thriftBinary, 1)
+			t.varint(uint64(len(column.name)))
+			t.buf.WriteString(column.name)
+			t.i32(4, 0)
+			t.i64(5, chunk.values)
+			t.i64(6, chunk.size)
+			t.i64(7, chunk.size)
+			t.i64(9, chunk.offset)
+			t.structEnd()
+			t.elemEnd()
+		}
+		t.i64(2, group.size)
+		t.i64(3, group.rows)
+		t.elemEnd()
+	}
+	t.binary(6, "go-mongo-lib")
+	t.stop()
+	return t.buf.Bytes()
+}
+
+// write ...
+func (p *parquetWriter) write(data []byte) (err error) {
+	n, err := p.w.Write(data)
+	p.offset += int64(n)
+	return err
+}
+
+// rleBools encodes definition levels of bit width 1 as RLE runs of the hybrid encoding
+func rleBools(values []bool) []byte {
+	var buf []byte
+	for i := 0; i < len(values); {
+		j := i
+		for j < len(values) && values[j] == values[i] {
+			j++
+		}
+		var header [binary.MaxVarintLen64]byte
+		buf = append(buf, header[:binary.PutUvarint(header[:], uint64(j-i)<<1)]...)
+		if values[i] {
+			buf = append(buf, 1)
+		} else {
+			buf = append(buf, 0)
+		}
+		i = j
+	}
+	return buf
+}
+
+// packBools bit packs booleans, least significant bit first
+func packBools(values []bool) []byte {
+	buf := make([]byte, (len(values)+7)/8)
+	for i, b := range values {
+		if b {
+			buf[i/8] |= 1 << (i % 8)
+		}
+	}
+	return buf
+}
+
+// isTime ...
+func isTime(value interface{}) bool {
+	switch value.(type) {
+	case primitive.DateTime, time.Time:
+		return true
+	}
+	return false
+}
+
+// thriftWriter encodes structs with the thrift compact protocol, the encoding of parquet metadata
+type thriftWriter struct {
+	buf  bytes.Buffer
+	last []int16 // Last field id of the enclosing structs
+	id   int16   // Last field id of the current struct
+}
+
+// field writes a field header, the id as a delta of the previous one when it fits
+func (t *thriftWriter) field(id int16, kind byte) {
+	if delta := id - t.id; delta > 0 && delta <= 15 {
+		t.buf.WriteByte(byte(delta)<<4 | kind)
+	} else {
+		t.buf.WriteByte(kind)
+		t.varint(zigzag(int64(id)))
+	}
+	t.id = id
+}
+
+// i32 ...
+func (t *thriftWriter) i32(id int16, v int32) {
+	t.field(id, thriftI32)
+	t.varint(zigzag(int64(v)))
+}
+
+// i64 ...
+func (t *thriftWriter) i64(id int16, v int64) {
+	t.field(id, thriftI64)
+	t.varint(zigzag(v))
+}
+
+// binary ...
+func (t *thriftWriter) binary(id int16, s string) {
+	t.field(id, thriftBinary)
+	t.varint(uint64(len(s)))
+	t.buf.WriteString(s)
+}
+
+// listBegin writes a list header, its elements follow
+func (t *thriftWriter) listBegin(id int16, kind byte, size int) {
+	t.field(id, thriftList)
+	if size < 15 {
+		t.buf.WriteByte(byte(size)<<4 | kind)
+		return
+	}
+	t.buf.WriteByte(0xf0 | kind)
+	t.varint(uint64(size))
+}
+
+// structBegin starts a struct field
+func (t *thriftWriter) structBegin(id int16) {
+	t.field(id, thriftStruct)
+	t.elemBegin()
+}
+
+// structEnd ...
+func (t *thriftWriter) structEnd() {
+	t.elemEnd()
+}
+
+// elemBegin starts a struct list element
+func (t *thriftWriter) elemBegin() {
+	t.last = append(t.last, t.id)
+	t.id = 0
+}
+
+// elemEnd ends a struct
+func (t *thriftWriter) elemEnd() {
+	t.stop()
+	t.id = t.last[len(t.last)-1]
+	t.last = t.last[:len(t.last)-1]
+}
+
+// stop ends the fields of the current struct
+func (t *thriftWriter) stop() {
+	t.buf.WriteByte(0)
+}
+
+// varint ...
+func (t *thriftWriter) varint(v uint64) {
+	var tmp [binary.MaxVarintLen64]byte
+	t.buf.Write(tmp[:binary.PutUvarint(tmp[:], v)])
+}
+
+// zigzag ...
+func zigzag(v int64) uint64 {
+	return uint64(v<<1) ^ uint64(v>>63)
+}
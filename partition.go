@@ -0,0 +1,100 @@
+package mongodb
+
+import (
+	"context"
+	"errors"
+
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// Partition is a separate connection pool isolating heavy collections or operation classes
+// e.g. analytics scans served by their own pool can not starve the pool of latency critical lookups
+type Partition struct {
+	Name        string      // Partition name, operations are routed to it with WithPartition
+	Collections []string    // Collections always served by the partition
+	Connection  *Connection // Pool options of the partition (default is Config.Connection)
+}
+
+// partitionKey ...
+type partitionKey struct{}
+
+// WithPartition routes the operations run with the returned context to the named partition
+// Unknown partition names fall back to the collection routing
+func WithPartition(ctx context.Context, name string) context.Context {
+	return context.WithValue(ctx, partitionKey{}, name)
+}
+
+// connectPartitions dials a client per configured partition
+func (c *Client) connectPartitions(config *Config) (err error) {
+	for _, p := range config.Partitions {
+		// Validates
+		if p.Name == "" {
+			err = errors.New("partition name is required")
+		} else if _, ok := c.partitions[p.Name]; ok {
+			err = errors.New("duplicate partition " + p.Name)
+		}
+		if err != nil {
+			c.disconnectPartitions()
+			return err
+		}
+
+		// Builds options
+		connection := p.Connection
+		if connection == nil {
+			connection = config.Connection
+		}
+		mongoConnOptions, err := config.clientOptions(connection)
+		if err != nil {
+			c.disconnectPartitions()
+			return err
+		}
+
+		// Dials
+		if c.commandMonitor != nil {
+			mongoConnOptions.SetMonitor(c.commandMonitor)
+		}
+		client, err := dial(mongoConnOptions)
+		if err != nil {
+			c.disconnectPartitions()
+			return errors.New("partition " + p.Name + " -> " + err.Error())
+		}
+
+		// Registers
+		if c.partitions == nil {
+			c.partitions = map[string]*mongo.Database{}
+			c.partitionOf = map[string]string{}
+		}
+		c.partitions[p.Name] = client.Database(config.Database)
+		for _, collection := range p.Collections {
+			c.partitionOf[collection] = p.Name
+		}
+	}
+
+	// Returns
+	return nil
+}
+
+// disconnectPartitions ...
+func (c *Client) disconnectPartitions() {
+	for _, db := range c.partitions {
+		db.Client().Disconnect(context.Background())
+	}
+	c.partitions = nil
+	c.partitionOf = nil
+}
+
+// collection returns the collection served by the partition of the context, else of the collection, else the main pool
+// Sessions belong to the main client, operations of a session always use the main pool
+func (c *Client) collection(ctx context.Context, name string) *mongo.Collection {
+	if len(c.partitions) != 0 && mongo.SessionFromContext(ctx) == nil {
+		if partition, ok := ctx.Value(partitionKey{}).(string); ok {
+			if db, ok := c.partitions[partition]; ok {
+				return db.Collection(name)
+			}
+		}
+		if db, ok := c.partitions[c.partitionOf[name]]; ok {
+			return db.Collection(name)
+		}
+	}
+	return c.database.Collection(name)
+}
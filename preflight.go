@@ -0,0 +1,196 @@
+package mongodb
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"strconv"
+	"strings"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// PreflightRequirements is what a service needs from the database before reporting ready
+type PreflightRequirements struct {
+	Collections             []string                 // Collections that must exist
+	Indexes                 map[string][]*IndexModel // Indexes that must exist by collection, matched by keys, uniqueness and expiry
+	MinServerVersion        string                   // Minimum server version, e.g. "5.0". (default is no minimum)
+	MinFeatureCompatibility string                   // Minimum feature compatibility version, e.g. "5.0", requires the getParameter privilege. (default is no minimum)
+}
+
+// PreflightReport is the outcome of the preflight checks
+type PreflightReport struct {
+	ServerVersion        string
+	FeatureCompatibility string                   // Empty unless a minimum was required
+	MissingCollections   []string                 // Required collections not found
+	MissingIndexes       map[string][]*IndexModel // Required indexes not found by collection
+	Problems             []string                 // Every unmet requirement in readable form
+}
+
+// PreflightError is returned when requirements are not met, it carries the full report
+type PreflightError struct {
+	Report *PreflightReport
+}
+
+// Error ...
+func (e *PreflightError) Error() string {
+	return "preflight failed -> " + strings.Join(e.Report.Problems, "; ")
+}
+
+// OK reports whether every requirement is met
+func (r *PreflightReport) OK() bool {
+	return len(r.Problems) == 0
+}
+
+// Preflight verifies the required collections, indexes and server versions at startup, so a misconfigured environment fails fast
+// Unmet requirements return the report along with a *PreflightError, failures to inspect the server return other errors
+func (c *Client) Preflight(ctx context.Context, requirements *PreflightRequirements) (report *PreflightReport, err error) {
+	if requirements == nil {
+		requirements = &PreflightRequirements{}
+	}
+	report = &PreflightReport{}
+
+	// Checks server version
+	report.ServerVersion, err = c.serverVersion(ctx)
+	if err != nil {
+		return nil, errors.New("reading server version -> " + err.Error())
+	}
+	if requirements.MinServerVersion != "" && compareVersions(report.ServerVersion, requirements.MinServerVersion) < 0 {
+		report.Problems = append(report.Problems, "server version "+report.ServerVersion+" is older than "+requirements.MinServerVersion)
+	}
+
+	// Checks feature compatibility
+	if requirements.MinFeatureCompatibility != "" {
+		report.FeatureCompatibility, err = c.featureCompatibility(ctx)
+		if err != nil {
+			return nil, errors.New("reading feature compatibility version -> " + err.Error())
+		}
+		if compareVersions(report.FeatureCompatibility, requirements.MinFeatureCompatibility) < 0 {
+			report.Problems = append(report.Problems, "feature compatibility version "+report.FeatureCompatibility+" is older than "+requirements.MinFeatureCompatibility)
+		}
+	}
+
+	// Checks collections
+	for _, collection := range requirements.Collections {
+		names, err := c.collection(ctx, collection).Database().ListCollectionNames(ctx, bson.D{{Key: "name", Value: collection}})
+		if err != nil {
+			return nil, errors.New("listing collection " + collection + " -> " + err.Error())
+		}
+		if len(names) == 0 {
+			report.MissingCollections = append(report.MissingCollections, collection)
+			report.Problems = append(report.Problems, "collection "+collection+" is missing")
+		}
+	}
+
+	// Checks indexes
+	collections := make([]string, 0, len(requirements.Indexes))
+	for collection := range requirements.Indexes {
+		collections = append(collections, collection)
+	}
+	sort.Strings(collections)
+	for _, collection := range collections {
+		existing, err := c.ListIndexes(ctx, collection)
+		if err != nil {
+			return nil, errors.New("listing indexes of " + collection + " -> " + err.Error())
+		}
+		for _, required := range requirements.Indexes[collection] {
+			found, err := hasIndex(existing, required)
+			if err != nil {
+				return nil, err
+			}
+			if found {
+				continue
+			}
+			if report.MissingIndexes == nil {
+				report.MissingIndexes = map[string][]*IndexModel{}
+			}
+			report.MissingIndexes[collection] = append(report.MissingIndexes[collection], required)
+			report.Problems = append(report.Problems, "index on "+indexKeysName(required.Keys)+" of collection "+collection+" is missing")
+		}
+	}
+
+	// Returns
+	if !report.OK() {
+		return report, &PreflightError{Report: report}
+	}
+	return report, nil
+}
+
+// serverVersion returns the version of the server, e.g. "6.0.4"
+func (c *Client) serverVersion(ctx context.Context) (version string, err error) {
+	var info struct {
+		Version string `bson:"version"`
+	}
+	err = c.client.Database("admin").RunCommand(ctx, bson.D{{Key: "buildInfo", Value: 1}}).Decode(&info)
+	if err != nil {
+		return "", err
+	}
+	return info.Version, nil
+}
+
+// featureCompatibility returns the feature compatibility version of the deployment, e.g. "6.0"
+func (c *Client) featureCompatibility(ctx context.Context) (version string, err error) {
+	var param struct {
+		FCV struct {
+			Version string `bson:"version"`
+		} `bson:"featureCompatibilityVersion"`
+	}
+	err = c.client.Database("admin").RunCommand(ctx, bson.D{{Key: "getParameter", Value: 1}, {Key: "featureCompatibilityVersion", Value: 1}}).Decode(&param)
+	if err != nil {
+		return "", err
+	}
+	return param.FCV.Version, nil
+}
+
+// hasIndex reports whether an existing index has the keys of the required one, and its uniqueness and expiry when required
+func hasIndex(existing []*IndexModel, required *IndexModel) (found bool, err error) {
+	key, err := indexKeysKey(required.Keys)
+	if err != nil {
+		return false, err
+	}
+	for _, index := range existing {
+		existingKey, err := indexKeysKey(index.Keys)
+		if err != nil {
+			return false, err
+		}
+		if existingKey != key {
+			continue
+		}
+		if required.Unique && !index.Unique {
+			continue
+		}
+		if required.ExpireAfter != 0 && required.ExpireAfter != index.ExpireAfter {
+			continue
+		}
+		return true, nil
+	}
+	return false, nil
+}
+
+// compareVersions compares dotted versions numerically, missing parts count as 0 and suffixes like "-rc1" are ignored
+func compareVersions(a string, b string) int {
+	as, bs := strings.Split(a, "."), strings.Split(b, ".")
+	for i := 0; i < len(as) || i < len(bs); i++ {
+		x, y := versionPart(as, i), versionPart(bs, i)
+		if x != y {
+			if x < y {
+				return -1
+			}
+			return 1
+		}
+	}
+	return 0
+}
+
+// versionPart ...
+func versionPart(parts []string, i int) int {
+	if i >= len(parts) {
+		return 0
+	}
+	part := parts[i]
+	if end := strings.IndexFunc(part, func(r rune) bool { return r < '0' || r > '9' }); end >= 0 {
+		part = part[:end]
+	}
+	n, _ := strconv.Atoi(part)
+	return n
+}
@@ -0,0 +1,213 @@
+package mongodb
+
+import (
+	"math/rand"
+	"reflect"
+	"sort"
+	"sync"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/bsoncodec"
+)
+
+// ProjectionSuggestion is a tightened projection for a named query
+type ProjectionSuggestion struct {
+	Query           string
+	Projection      bson.D        // Top level fields the callers decoded, _id included
+	UnusedFields    []string      // Returned fields no caller decoded
+	Documents       int64         // Sampled documents
+	MeanBytes       int64         // Mean size of the returned documents
+	MeanUnusedBytes int64         // Mean size of the fields the projection would drop
+	MeanLatency     time.Duration // Mean latency of the query, zero unless reported with ObserveLatency
+	LatencySaving   time.Duration // Estimated latency saved per query, assuming latency proportional to the payload
+}
+
+// projectionUsage ...
+type projectionUsage struct {
+	documents   int64
+	bytes       int64
+	unusedBytes int64
+	used        map[string]bool
+	returned    map[string]bool
+	queries     int64
+	latency     time.Duration
+}
+
+// ProjectionAdvisor records which fields of the returned documents are actually decoded and suggests projections per query name
+// Decode the documents of a query through it, or report the fields used by other decoding with Observe
+type ProjectionAdvisor struct {
+	sampleRate float64
+	mu         sync.Mutex
+	rand       *rand.Rand
+	queries    map[string]*projectionUsage
+}
+
+// NewProjectionAdvisor samples the given fraction of documents, e.g. 0.01 in production, 1 records all of them
+func NewProjectionAdvisor(sampleRate float64) *ProjectionAdvisor {
+	return &ProjectionAdvisor{sampleRate: sampleRate, rand: rand.New(rand.NewSource(time.Now().UnixNano())), queries: map[string]*projectionUsage{}}
+}
+
+// Decode decodes the document into out and records the top level fields the type of out consumes
+// Structs consume their bson fields, maps, interfaces and structs with an inline map consume every field
+func (a *ProjectionAdvisor) Decode(query string, doc bson.Raw, out interface{}) (err error) {
+	// Decodes
+	err = bson.Unmarshal(doc, out)
+	if err != nil {
+		return err
+	}
+
+	// Records
+	fields, all := decodedFields(reflect.TypeOf(out))
+	if all {
+		return a.Observe(query, doc)
+	}
+	return a.Observe(query, doc, fields...)
+}
+
+// Observe records a returned document with the top level fields the caller used, every field when none are given
+func (a *ProjectionAdvisor) Observe(query string, doc bson.Raw, usedFields ...string) (err error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	// Samples
+	if a.sampleRate < 1 && a.rand.Float64() >= a.sampleRate {
+		return nil
+	}
+
+	// Reads fields
+	elems, err := doc.Elements()
+	if err != nil {
+		return err
+	}
+	used := make(map[string]bool, len(usedFields))
+	for _, field := range usedFields {
+		used[field] = true
+	}
+
+	// Records
+	usage, ok := a.queries[query]
+	if !ok {
+		usage = &projectionUsage{used: map[string]bool{}, returned: map[string]bool{}}
+		a.queries[query] = usage
+	}
+	usage.documents++
+	usage.bytes += int64(len(doc))
+	for _, elem := range elems {
+		key := elem.Key()
+		usage.returned[key] = true
+		if len(usedFields) == 0 || used[key] {
+			usage.used[key] = true
+			continue
+		}
+		usage.unusedBytes += int64(len(elem))
+	}
+
+	// Returns
+	return nil
+}
+
+// ObserveLatency records the latency of an execution of the query, so suggestions estimate the latency saved
+func (a *ProjectionAdvisor) ObserveLatency(query string, latency time.Duration) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	usage, ok := a.queries[query]
+	if !ok {
+		usage = &projectionUsage{used: map[string]bool{}, returned: map[string]bool{}}
+		a.queries[query] = usage
+	}
+	usage.queries++
+	usage.latency += latency
+}
+
+// Suggestions returns a projection per query returning fields never used, the largest unused payloads first
+func (a *ProjectionAdvisor) Suggestions() (res []ProjectionSuggestion) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	for query, usage := range a.queries {
+		if usage.documents == 0 {
+			continue
+		}
+
+		// Lists unused fields
+		var unused []string
+		for field := range usage.returned {
+			if !usage.used[field] {
+				unused = append(unused, field)
+			}
+		}
+		if len(unused) == 0 {
+			continue
+		}
+		sort.Strings(unused)
+
+		// Builds projection
+		used := make([]string, 0, len(usage.used))
+		for field := range usage.used {
+			used = append(used, field)
+		}
+		sort.Strings(used)
+		projection := bson.D{{Key: "_id", Value: 1}}
+		for _, field := range used {
+			if field != "_id" {
+				projection = append(projection, bson.E{Key: field, Value: 1})
+			}
+		}
+
+		// Estimates savings
+		suggestion := ProjectionSuggestion{
+			Query:           query,
+			Projection:      projection,
+			UnusedFields:    unused,
+			Documents:       usage.documents,
+			MeanBytes:       usage.bytes / usage.documents,
+			MeanUnusedBytes: usage.unusedBytes / usage.documents,
+		}
+		if usage.queries != 0 {
+			suggestion.MeanLatency = usage.latency / time.Duration(usage.queries)
+			suggestion.LatencySaving = time.Duration(float64(suggestion.MeanLatency) * float64(usage.unusedBytes) / float64(usage.bytes))
+		}
+		res = append(res, suggestion)
+	}
+	sort.Slice(res, func(i, j int) bool {
+		if res[i].MeanUnusedBytes != res[j].MeanUnusedBytes {
+			return res[i].MeanUnusedBytes > res[j].MeanUnusedBytes
+		}
+		return res[i].Query < res[j].Query
+	})
+
+	// Returns
+	return res
+}
+
+// decodedFields returns the top level bson fields a decoding target consumes, all is true when it consumes every field
+func decodedFields(t reflect.Type) (fields []string, all bool) {
+	for t != nil && t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t == nil || t.Kind() != reflect.Struct {
+		return nil, true
+	}
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		if sf.PkgPath != "" && !sf.Anonymous {
+			continue
+		}
+		tags, err := bsoncodec.DefaultStructTagParser(sf)
+		if err != nil || tags.Skip {
+			continue
+		}
+		if tags.Inline {
+			inner, innerAll := decodedFields(sf.Type)
+			if innerAll {
+				return nil, true
+			}
+			fields = append(fields, inner...)
+			continue
+		}
+		fields = append(fields, tags.Name)
+	}
+	return fields, false
+}
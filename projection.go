@@ -0,0 +1,150 @@
+package mongodb
+
+import (
+	"errors"
+	"strings"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// Projection builds a projection, e.g. NewProjection().Include("name", "email").Exclude("_id")
+// It marshals to its document so it can be passed as is to ReadWithProjection or find options, an invalid projection fails to marshal
+// Stages translates it for Aggregate pipelines
+type Projection struct {
+	fields   bson.D
+	included string // First included field, empty when none
+	excluded string // First excluded field other than _id, empty when none
+}
+
+// NewProjection ...
+func NewProjection() *Projection {
+	return &Projection{}
+}
+
+// Include returns the fields, dotted paths of embedded fields
+func (p *Projection) Include(fields ...string) *Projection {
+	for _, field := range fields {
+		p.fields = append(p.fields, bson.E{Key: field, Value: 1})
+		if p.included == "" {
+			p.included = field
+		}
+	}
+	return p
+}
+
+// Exclude returns every field but these, _id may be excluded from an inclusion projection
+func (p *Projection) Exclude(fields ...string) *Projection {
+	for _, field := range fields {
+		p.fields = append(p.fields, bson.E{Key: field, Value: 0})
+		if p.excluded == "" && field != "_id" {
+			p.excluded = field
+		}
+	}
+	return p
+}
+
+// Slice returns the first limit elements of the array field, the last ones for a negative limit
+func (p *Projection) Slice(field string, limit int) *Projection {
+	p.fields = append(p.fields, bson.E{Key: field, Value: bson.D{{Key: "$slice", Value: limit}}})
+	return p
+}
+
+// SliceRange returns limit elements of the array field after skipping skip of them, counted from the end for a negative skip
+func (p *Projection) SliceRange(field string, skip int, limit int) *Projection {
+	p.fields = append(p.fields, bson.E{Key: field, Value: bson.D{{Key: "$slice", Value: bson.A{skip, limit}}}})
+	return p
+}
+
+// ElemMatch returns only the first element of the array field matching the filter, e.g. ElemMatch("items", bson.D{{Key: "sku", Value: sku}})
+func (p *Projection) ElemMatch(field string, filter interface{}) *Projection {
+	p.fields = append(p.fields, bson.E{Key: field, Value: bson.D{{Key: "$elemMatch", Value: filter}}})
+	return p
+}
+
+// Build validates the projection and returns its document
+// Inclusions and exclusions can not be mixed, except the exclusion of _id, and a field can not be projected with one of its parents
+func (p *Projection) Build() (projection bson.D, err error) {
+	if p.included != "" && p.excluded != "" {
+		return nil, errors.New("projection mixes inclusion of " + p.included + " and exclusion of " + p.excluded)
+	}
+
+	// Checks paths
+	seen := map[string]bool{}
+	for _, e := range p.fields {
+		if seen[e.Key] {
+			return nil, errors.New("projection has field " + e.Key + " twice")
+		}
+		seen[e.Key] = true
+	}
+	for _, e := range p.fields {
+		parts := strings.Split(e.Key, ".")
+		for i := 1; i < len(parts); i++ {
+			if parent := strings.Join(parts[:i], "."); seen[parent] {
+				return nil, errors.New("projection of " + e.Key + " collides with its parent " + parent)
+			}
+		}
+	}
+
+	// Returns
+	return append(bson.D{}, p.fields...), nil
+}
+
+// Stages returns the stages applying the projection in Aggregate pipelines, e.g. append(pipeline, stages...)
+// An inclusion projection is a single $project stage, otherwise slices are applied by an $addFields stage before the $project of the exclusions
+// Slices of fields which are not arrays keep them as is like find projections do, $elemMatch has no equivalent in stages
+func (p *Projection) Stages() (stages mongo.Pipeline, err error) {
+	projection, err := p.Build()
+	if err != nil {
+		return nil, err
+	}
+
+	// Translates slices
+	project, slices := bson.D{}, bson.D{}
+	for _, e := range projection {
+		operator, ok := e.Value.(bson.D)
+		if !ok {
+			project = append(project, e)
+			continue
+		}
+		switch operator[0].Key {
+		case "$elemMatch":
+			return nil, errors.New("projection of " + e.Key + " uses $elemMatch which aggregation stages do not support")
+		case "$slice":
+			args := bson.A{"$" + e.Key}
+			if r, ok := operator[0].Value.(bson.A); ok {
+				args = append(args, r...)
+			} else {
+				args = append(args, operator[0].Value)
+			}
+			slice := bson.E{Key: e.Key, Value: bson.D{{Key: "$cond", Value: bson.A{
+				bson.D{{Key: "$isArray", Value: "$" + e.Key}},
+				bson.D{{Key: "$slice", Value: args}},
+				"$" + e.Key,
+			}}}}
+			if p.included != "" {
+				project = append(project, slice)
+			} else {
+				slices = append(slices, slice)
+			}
+		}
+	}
+
+	// Returns
+	if len(slices) != 0 {
+		stages = append(stages, bson.D{{Key: "$addFields", Value: slices}})
+	}
+	if len(project) != 0 {
+		stages = append(stages, bson.D{{Key: "$project", Value: project}})
+	}
+	return stages, nil
+}
+
+// MarshalBSON ...
+func (p *Projection) MarshalBSON() ([]byte, error) {
+	projection, err := p.Build()
+	if err != nil {
+		return nil, err
+	}
+	return bson.Marshal(projection)
+}
@@ -0,0 +1,368 @@
+package mongodb
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// Default page sizes of the query language
+const (
+	defaultQueryLimit    = 20
+	defaultQueryMaxLimit = 100
+)
+
+// Query field types
+const (
+	QueryString   = "string"
+	QueryInt      = "int"
+	QueryFloat    = "float"
+	QueryBool     = "bool"
+	QueryDate     = "date" // RFC 3339 time or YYYY-MM-DD date
+	QueryObjectID = "objectId"
+)
+
+// Reserved parameters of the query language
+const (
+	querySortParam   = "sort"
+	queryLimitParam  = "limit"
+	queryCursorParam = "cursor"
+)
+
+// Operators of the query language by parameter suffix, field=value is eq
+var queryOperators = map[string]string{
+	"eq": "$eq", "ne": "$ne", "gt": "$gt", "gte": "$gte", "lt": "$lt", "lte": "$lte", "in": "$in", "nin": "$nin", "exists": "$exists",
+}
+
+// QueryField declares a field the query language may filter or sort on
+type QueryField struct {
+	Type      string   // Type values are converted to, QueryString, QueryInt... (default is QueryString)
+	Operators []string // Allowed operators, e.g. []string{"eq", "in"}. (default is every operator)
+	Sortable  bool     // Allows sorting on the field (default is false)
+}
+
+// QuerySchema is the whitelist of the fields of a collection exposed to the query language
+type QuerySchema struct {
+	Fields       map[string]QueryField // Fields by dotted path, any other field is rejected
+	DefaultLimit int                   // Page size without limit parameter. (default is 20)
+	MaxLimit     int                   // Upper bound of the limit parameter. (default is 100)
+}
+
+// QueryError is returned for query strings outside of the language or the whitelist, e.g. to answer 400 Bad Request
+type QueryError struct {
+	Param  string
+	Reason string
+}
+
+// Error ...
+func (e *QueryError) Error() string {
+	return fmt.Sprintf("invalid query parameter %s -> %s", e.Param, e.Reason)
+}
+
+// ParsedQuery is a query string translated into a filter and find options
+type ParsedQuery struct {
+	Filter  bson.D               // Filter, including the position of the cursor
+	Sort    bson.D               // Sort, always ending with an _id tiebreaker
+	Limit   int64                // Page size
+	Options *options.FindOptions // Sort and limit
+}
+
+// SetQuerySchema sets the fields of the collection the query language accepts, nil disables the language on the collection
+func (c *Client) SetQuerySchema(collection string, schema *QuerySchema) {
+	c.configure(collection, func(s *collectionSettings) { s.querySchema = schema })
+}
+
+// ParseQuery translates an OpenAPI deepObject style query string into a safe filter and find options, e.g.
+// status=paid&total[gte]=100&createdAt[lt]=2024-01-01&tags[in]=a,b&sort=-createdAt&limit=50&cursor=...
+// Only whitelisted fields and operators are accepted and values are converted to the declared type, so they are never read as operators
+// in and nin take comma separated values, sort takes comma separated fields prefixed by - for descending order
+func (c *Client) ParseQuery(collection string, query url.Values) (parsed *ParsedQuery, err error) {
+	s := c.settings(collection)
+	if s == nil || s.querySchema == nil {
+		return nil, errors.New("no query schema set for collection " + collection)
+	}
+	schema := s.querySchema
+
+	// Builds filter
+	params := make([]string, 0, len(query))
+	for param := range query {
+		params = append(params, param)
+	}
+	sort.Strings(params)
+	conditions := map[string]bson.D{}
+	var fields []string
+	var filter bson.D
+	for _, param := range params {
+		if param == querySortParam || param == queryLimitParam || param == queryCursorParam {
+			continue
+		}
+		values := query[param]
+		if len(values) != 1 {
+			return nil, &QueryError{Param: param, Reason: "repeated"}
+		}
+		field, op, err := parseQueryParam(param)
+		if err != nil {
+			return nil, err
+		}
+		def, ok := schema.Fields[field]
+		if !ok {
+			return nil, &QueryError{Param: param, Reason: "unknown field " + field}
+		}
+		if !def.allows(op) {
+			return nil, &QueryError{Param: param, Reason: "operator " + op + " not allowed on " + field}
+		}
+		value, err := def.convert(op, values[0])
+		if err != nil {
+			return nil, &QueryError{Param: param, Reason: err.Error()}
+		}
+		if _, ok := conditions[field]; !ok {
+			fields = append(fields, field)
+		}
+		conditions[field] = append(conditions[field], bson.E{Key: queryOperators[op], Value: value})
+	}
+	for _, field := range fields {
+		filter = append(filter, bson.E{Key: field, Value: conditions[field]})
+	}
+
+	// Builds sort
+	parsed = &ParsedQuery{}
+	if param, ok := query[querySortParam]; ok {
+		for _, key := range strings.Split(param[0], ",") {
+			direction := 1
+			if strings.HasPrefix(key, "-") {
+				key, direction = key[1:], -1
+			}
+			if def, ok := schema.Fields[key]; key != "_id" && (!ok || !def.Sortable) {
+				return nil, &QueryError{Param: querySortParam, Reason: "cannot sort on " + key}
+			}
+			parsed.Sort = append(parsed.Sort, bson.E{Key: key, Value: direction})
+		}
+	} else {
+		parsed.Sort = s.defaultSort
+	}
+	if len(parsed.Sort) == 0 {
+		parsed.Sort = bson.D{{Key: "_id", Value: 1}}
+	}
+	parsed.Sort = withTiebreaker(parsed.Sort)
+
+	// Sets limit
+	parsed.Limit = int64(defaultQueryLimit)
+	if schema.DefaultLimit != 0 {
+		parsed.Limit = int64(schema.DefaultLimit)
+	}
+	maxLimit := int64(defaultQueryMaxLimit)
+	if schema.MaxLimit != 0 {
+		maxLimit = int64(schema.MaxLimit)
+	}
+	if param, ok := query[queryLimitParam]; ok {
+		parsed.Limit, err = strconv.ParseInt(param[0], 10, 64)
+		if err != nil || parsed.Limit <= 0 {
+			return nil, &QueryError{Param: queryLimitParam, Reason: "not a positive integer"}
+		}
+	}
+	if parsed.Limit > maxLimit {
+		parsed.Limit = maxLimit
+	}
+
+	// Applies cursor
+	if param, ok := query[queryCursorParam]; ok {
+		position, err := cursorFilter(parsed.Sort, param[0])
+		if err != nil {
+			return nil, &QueryError{Param: queryCursorParam, Reason: err.Error()}
+		}
+		filter = append(filter, position...)
+	}
+	if filter == nil {
+		filter = bson.D{}
+	}
+	parsed.Filter = filter
+	parsed.Options = options.Find().SetSort(parsed.Sort).SetLimit(parsed.Limit)
+
+	// Returns
+	return parsed, nil
+}
+
+// ReadQuery runs the query string on the collection and returns a page of documents with the cursor of the next page, empty on the last page
+func (c *Client) ReadQuery(ctx context.Context, collection string, query url.Values) (res []interface{}, next string, err error) {
+	// Parses
+	parsed, err := c.ParseQuery(collection, query)
+	if err != nil {
+		return nil, "", err
+	}
+
+	// Hits DB, one more document tells whether a next page exists, the page is bounded by its limit rather than the result limits
+	res, err = c.Read(WithResultLimits(ctx, nil), collection, parsed.Filter, parsed.Options, options.Find().SetLimit(parsed.Limit+1))
+	if err != nil {
+		return nil, "", err
+	}
+	if int64(len(res)) <= parsed.Limit {
+		return res, "", nil
+	}
+	res = res[:parsed.Limit]
+
+	// Returns
+	next, err = parsed.NextCursor(res[len(res)-1])
+	if err != nil {
+		return nil, "", err
+	}
+	return res, next, nil
+}
+
+// NextCursor returns the cursor of the page following the document, the last one of the current page
+func (q *ParsedQuery) NextCursor(last interface{}) (cursor string, err error) {
+	return keysetCursor(q.Sort, last)
+}
+
+// keysetCursor encodes the sort values of the document, the position cursorFilter continues after
+func keysetCursor(sortFields bson.D, last interface{}) (cursor string, err error) {
+	doc, err := toDoc(last)
+	if err != nil {
+		return "", err
+	}
+
+	// Collects sort values
+	values := make(bson.A, len(sortFields))
+	for i, e := range sortFields {
+		values[i], _ = getPath(doc, strings.Split(e.Key, "."))
+	}
+
+	// Encodes
+	data, err := bson.MarshalExtJSON(bson.D{{Key: "s", Value: sortSignature(sortFields)}, {Key: "v", Value: values}}, true, false)
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(data), nil
+}
+
+// cursorFilter returns the filter of the documents after the cursor in the sort order
+func cursorFilter(sortFields bson.D, cursor string) (filter bson.D, err error) {
+	// Decodes
+	data, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return nil, errors.New("malformed cursor")
+	}
+	var position struct {
+		Sort   string `bson:"s"`
+		Values bson.A `bson:"v"`
+	}
+	err = bson.UnmarshalExtJSON(data, true, &position)
+	if err != nil {
+		return nil, errors.New("malformed cursor")
+	}
+	if position.Sort != sortSignature(sortFields) || len(position.Values) != len(sortFields) {
+		return nil, errors.New("cursor of another sort order")
+	}
+
+	// Returns
+	return keysetCondition(sortFields, position.Values), nil
+}
+
+// keysetCondition returns the filter of the documents after the sort values in the sort order, e.g. a > x or (a = x and _id > y)
+func keysetCondition(sortFields bson.D, values bson.A) bson.D {
+	branches := make(bson.A, len(sortFields))
+	for i, e := range sortFields {
+		branch := bson.D{}
+		for j := 0; j < i; j++ {
+			branch = append(branch, bson.E{Key: sortFields[j].Key, Value: values[j]})
+		}
+		op := "$gt"
+		if sortDirection(e.Value) < 0 {
+			op = "$lt"
+		}
+		branches[i] = append(branch, bson.E{Key: e.Key, Value: bson.D{{Key: op, Value: values[i]}}})
+	}
+	return bson.D{{Key: "$or", Value: branches}}
+}
+
+// sortSignature identifies a sort order, e.g. "-createdAt,-_id"
+func sortSignature(sortFields bson.D) string {
+	keys := make([]string, len(sortFields))
+	for i, e := range sortFields {
+		keys[i] = e.Key
+		if sortDirection(e.Value) < 0 {
+			keys[i] = "-" + e.Key
+		}
+	}
+	return strings.Join(keys, ",")
+}
+
+// parseQueryParam splits field[op] into the field and the operator, field alone is eq
+func parseQueryParam(param string) (field string, op string, err error) {
+	open := strings.IndexByte(param, '[')
+	if open < 0 {
+		return param, "eq", nil
+	}
+	if !strings.HasSuffix(param, "]") || open == 0 {
+		return "", "", &QueryError{Param: param, Reason: "malformed parameter"}
+	}
+	field, op = param[:open], param[open+1:len(param)-1]
+	if _, ok := queryOperators[op]; !ok {
+		return "", "", &QueryError{Param: param, Reason: "unknown operator " + op}
+	}
+	return field, op, nil
+}
+
+// allows tells whether the operator may be used on the field
+func (f QueryField) allows(op string) bool {
+	if len(f.Operators) == 0 {
+		return true
+	}
+	for _, allowed := range f.Operators {
+		if allowed == op {
+			return true
+		}
+	}
+	return false
+}
+
+// convert converts the parameter value to the type of the field, a list for in and nin
+func (f QueryField) convert(op string, value string) (res interface{}, err error) {
+	switch op {
+	case "exists":
+		return strconv.ParseBool(value)
+	case "in", "nin":
+		parts := strings.Split(value, ",")
+		list := make(bson.A, len(parts))
+		for i, part := range parts {
+			list[i], err = f.convertValue(part)
+			if err != nil {
+				return nil, err
+			}
+		}
+		return list, nil
+	}
+	return f.convertValue(value)
+}
+
+// convertValue ...
+func (f QueryField) convertValue(value string) (res interface{}, err error) {
+	switch f.Type {
+	case "", QueryString:
+		return value, nil
+	case QueryInt:
+		return strconv.ParseInt(value, 10, 64)
+	case QueryFloat:
+		return strconv.ParseFloat(value, 64)
+	case QueryBool:
+		return strconv.ParseBool(value)
+	case QueryDate:
+		t, err := time.Parse(time.RFC3339Nano, value)
+		if err != nil {
+			t, err = time.Parse(dateLayout, value)
+		}
+		return t, err
+	case QueryObjectID:
+		return primitive.ObjectIDFromHex(value)
+	}
+	return nil, errors.New("unknown query field type " + f.Type)
+}
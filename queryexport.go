@@ -0,0 +1,210 @@
+package mongodb
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// Export and import formats
+const (
+	FormatCSV     = "csv"
+	FormatParquet = "parquet" // Export only
+	FormatJSONL   = "jsonl"   // Import only, one extended JSON document per line
+)
+
+// Default rows per parquet row group
+const defaultParquetRowGroupSize = 10000
+
+// QueryExportOptions ...
+type QueryExportOptions struct {
+	Columns      []string             // Dotted paths of the exported fields, a document or array column holds relaxed extended JSON. (default is the flattened fields of the first document)
+	Find         *options.FindOptions // Sort, limit... of the query (default is nil)
+	Comma        rune                 // CSV field delimiter. (default is ',')
+	NoHeader     bool                 // Omits the CSV header row (default is false)
+	RowGroupSize int                  // Rows buffered per parquet row group. (default is 10000)
+}
+
+// ExportQuery streams the documents matching the filter to w as CSV or Parquet rows, one row per document, for analytics handoffs
+// Nested documents are flattened into dotted columns, arrays and unflattened documents are written as relaxed extended JSON and missing fields are empty
+// Parquet columns are optional, typed after the values of the first document: booleans, 64 bits integers, doubles, millisecond timestamps, else UTF-8 strings
+// Only one parquet row group is held in memory
+func (c *Client) ExportQuery(ctx context.Context, collection string, filter interface{}, format string, w io.Writer, opts *QueryExportOptions) (rows int64, err error) {
+	if opts == nil {
+		opts = &QueryExportOptions{}
+	}
+	if format != FormatCSV && format != FormatParquet {
+		return 0, errors.New("unknown export format " + format)
+	}
+
+	// Opens stream
+	findOpts := options.Find()
+	if len(opts.Columns) != 0 {
+		findOpts.SetProjection(ProjectionFromFields(opts.Columns, nil))
+	}
+	stream, err := c.ReadStream(ctx, collection, filter, findOpts, opts.Find)
+	if err != nil {
+		return 0, err
+	}
+
+	// Close connection at the last
+	defer stream.Close(ctx)
+
+	// Writes rows
+	columns := opts.Columns
+	var rowWriter interface {
+		Write(row []interface{}) error
+		Close() error
+	}
+	for stream.Next(ctx) {
+		var doc bson.D
+		err = stream.Decode(&doc)
+		if err != nil {
+			return rows, err
+		}
+		if rowWriter == nil {
+			if columns == nil {
+				columns = flattenColumns(doc, "")
+			}
+			rowWriter, err = newRowWriter(w, format, columns, doc, opts)
+			if err != nil {
+				return rows, err
+			}
+		}
+		row := make([]interface{}, len(columns))
+		for i, column := range columns {
+			row[i], _ = getPath(doc, strings.Split(column, "."))
+		}
+		err = rowWriter.Write(row)
+		if err != nil {
+			return rows, err
+		}
+		rows++
+	}
+	err = stream.Err()
+	if err != nil {
+		return rows, err
+	}
+
+	// Writes empty result
+	if rowWriter == nil {
+		rowWriter, err = newRowWriter(w, format, columns, nil, opts)
+		if err != nil {
+			return rows, err
+		}
+	}
+
+	// Returns
+	return rows, rowWriter.Close()
+}
+
+// newRowWriter returns the writer of the format, parquet columns are typed after the first document
+func newRowWriter(w io.Writer, format string, columns []string, first bson.D, opts *QueryExportOptions) (rowWriter interface {
+	Write(row []interface{}) error
+	Close() error
+}, err error) {
+	// Writes CSV
+	if format == FormatCSV {
+		cw := &csvRowWriter{w: csv.NewWriter(w)}
+		if opts.Comma != 0 {
+			cw.w.Comma = opts.Comma
+		}
+		if !opts.NoHeader {
+			err = cw.w.Write(columns)
+			if err != nil {
+				return nil, err
+			}
+		}
+		return cw, nil
+	}
+
+	// Writes Parquet
+	parquetColumns := make([]parquetColumn, len(columns))
+	for i, column := range columns {
+		value, _ := getPath(first, strings.Split(column, "."))
+		parquetColumns[i] = parquetColumnOf(column, value)
+	}
+	rowGroupSize := opts.RowGroupSize
+	if rowGroupSize <= 0 {
+		rowGroupSize = defaultParquetRowGroupSize
+	}
+	return newParquetWriter(w, parquetColumns, rowGroupSize)
+}
+
+// csvRowWriter writes rows as CSV records
+type csvRowWriter struct {
+	w      *csv.Writer
+	record []string
+}
+
+// Write ...
+func (c *csvRowWriter) Write(row []interface{}) (err error) {
+	c.record = c.record[:0]
+	for _, value := range row {
+		cell, err := cellString(value)
+		if err != nil {
+			return err
+		}
+		c.record = append(c.record, cell)
+	}
+	return c.w.Write(c.record)
+}
+
+// Close flushes the buffered records
+func (c *csvRowWriter) Close() (err error) {
+	c.w.Flush()
+	return c.w.Error()
+}
+
+// flattenColumns returns the dotted paths of the leaves of the document, arrays are leaves
+func flattenColumns(doc bson.D, prefix string) (columns []string) {
+	for _, e := range doc {
+		if child, ok := e.Value.(bson.D); ok && len(child) != 0 {
+			columns = append(columns, flattenColumns(child, prefix+e.Key+".")...)
+			continue
+		}
+		columns = append(columns, prefix+e.Key)
+	}
+	return columns
+}
+
+// cellString formats a value as text, documents and arrays as relaxed extended JSON
+func cellString(value interface{}) (s string, err error) {
+	switch v := value.(type) {
+	case nil:
+		return "", nil
+	case string:
+		return v, nil
+	case bool:
+		return strconv.FormatBool(v), nil
+	case int32:
+		return strconv.FormatInt(int64(v), 10), nil
+	case int64:
+		return strconv.FormatInt(v, 10), nil
+	case float64:
+		return strconv.FormatFloat(v, 'g', -1, 64), nil
+	case primitive.DateTime:
+		return v.Time().UTC().Format(time.RFC3339Nano), nil
+	case time.Time:
+		return v.UTC().Format(time.RFC3339Nano), nil
+	case primitive.ObjectID:
+		return v.Hex(), nil
+	case primitive.Decimal128:
+		return v.String(), nil
+	}
+
+	// Encodes as extended JSON, values are wrapped in a document which is stripped
+	data, err := bson.MarshalExtJSON(bson.D{{Key: "v", Value: value}}, false, false)
+	if err != nil {
+		return "", err
+	}
+	return string(data[len(`{"v":`) : len(data)-1]), nil
+}
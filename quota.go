@@ -0,0 +1,185 @@
+package mongodb
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// Quotas of TenantQuota
+const (
+	QuotaOperations      = "operations per second"
+	QuotaStorage         = "storage bytes"
+	QuotaResultDocuments = "result documents"
+)
+
+// TenantQuota limits the operations run with a WithTenant context, zero fields are unlimited
+type TenantQuota struct {
+	OperationsPerSecond float64 // Sustained operations of the wrappers, bursts up to one second of operations (default is 0, unlimited)
+	MaxStorageBytes     int64   // Estimated stored bytes from which inserts, updates and replacements are rejected, see SetTenantStorage (default is 0, unlimited)
+	MaxResultDocuments  int64   // Documents a single Read, ReadWithProjection or raw variant may return (default is 0, unlimited)
+}
+
+// QuotaExceededError is returned when an operation exceeds a quota of its tenant, e.g. to answer 429 Too Many Requests
+type QuotaExceededError struct {
+	Tenant string
+	Quota  string  // QuotaOperations, QuotaStorage or QuotaResultDocuments
+	Limit  float64 // Limit of the quota
+}
+
+// Error ...
+func (e *QuotaExceededError) Error() string {
+	return fmt.Sprintf("tenant %s exceeded its quota of %s %s", e.Tenant, strconv.FormatFloat(e.Limit, 'f', -1, 64), e.Quota)
+}
+
+// tenantQuotas holds the quotas of the tenants and their consumption
+type tenantQuotas struct {
+	mu      sync.Mutex
+	tenants map[string]*tenantQuotaState
+}
+
+// tenantQuotaState ...
+type tenantQuotaState struct {
+	quota   *TenantQuota
+	tokens  float64 // Operations available, refilled at OperationsPerSecond
+	refill  time.Time
+	storage int64 // Estimated stored bytes
+}
+
+// SetTenantQuota sets the quota of the tenant for this client, nil removes it
+// Quotas are enforced on client side only, before operations reach the server
+func (c *Client) SetTenantQuota(tenant string, quota *TenantQuota) {
+	q := &c.quotas
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	state := q.state(tenant)
+	state.quota = quota
+	if quota != nil {
+		state.tokens = quota.OperationsPerSecond
+		state.refill = time.Now()
+	}
+}
+
+// SetTenantStorage sets the estimated stored bytes of the tenant, e.g. from a periodic $bsonSize sum of its documents
+// The estimate then grows with the documents the tenant inserts through this client
+func (c *Client) SetTenantStorage(tenant string, bytes int64) {
+	q := &c.quotas
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	state := q.state(tenant)
+	state.storage = bytes
+}
+
+// state returns the state of the tenant, created when missing, the lock must be held
+func (q *tenantQuotas) state(tenant string) *tenantQuotaState {
+	if q.tenants == nil {
+		q.tenants = map[string]*tenantQuotaState{}
+	}
+	state, ok := q.tenants[tenant]
+	if !ok {
+		state = &tenantQuotaState{}
+		q.tenants[tenant] = state
+	}
+	return state
+}
+
+// checkQuota takes an operation from the rate of the tenant of the context, writes are rejected once the storage quota is reached
+func (c *Client) checkQuota(ctx context.Context, write bool) (err error) {
+	tenant, ok := TenantFromContext(ctx)
+	if !ok {
+		return nil
+	}
+	q := &c.quotas
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	state, ok := q.tenants[tenant]
+	if !ok || state.quota == nil {
+		return nil
+	}
+	quota := state.quota
+
+	// Checks storage
+	if write && quota.MaxStorageBytes > 0 && state.storage >= quota.MaxStorageBytes {
+		return &QuotaExceededError{Tenant: tenant, Quota: QuotaStorage, Limit: float64(quota.MaxStorageBytes)}
+	}
+
+	// Takes operation
+	if quota.OperationsPerSecond > 0 {
+		now := time.Now()
+		state.tokens += now.Sub(state.refill).Seconds() * quota.OperationsPerSecond
+		if state.tokens > quota.OperationsPerSecond {
+			state.tokens = quota.OperationsPerSecond
+		}
+		state.refill = now
+		if state.tokens < 1 {
+			return &QuotaExceededError{Tenant: tenant, Quota: QuotaOperations, Limit: quota.OperationsPerSecond}
+		}
+		state.tokens--
+	}
+
+	// Returns
+	return nil
+}
+
+// addTenantStorage grows the estimated stored bytes of the tenant of the context
+func (c *Client) addTenantStorage(ctx context.Context, documents ...interface{}) {
+	tenant, ok := TenantFromContext(ctx)
+	if !ok {
+		return
+	}
+	var bytes int64
+	for _, document := range documents {
+		bytes += int64(bsonSize(document))
+	}
+	q := &c.quotas
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if state, ok := q.tenants[tenant]; ok {
+		state.storage += bytes
+	}
+}
+
+// resultQuota returns the maximum documents a read of the tenant of the context may return, 0 when unlimited
+// The find options are limited to one more document so an exceeded quota is detected without reading the whole result
+func (c *Client) resultQuota(ctx context.Context, opts []*options.FindOptions) (max int64, res []*options.FindOptions) {
+	tenant, ok := TenantFromContext(ctx)
+	if !ok {
+		return 0, opts
+	}
+	q := &c.quotas
+	q.mu.Lock()
+	state, ok := q.tenants[tenant]
+	if ok && state.quota != nil {
+		max = state.quota.MaxResultDocuments
+	}
+	q.mu.Unlock()
+	if max <= 0 {
+		return 0, opts
+	}
+	return max, limitFind(opts, max+1)
+}
+
+// checkResultQuota rejects a result having more documents than the result quota of the tenant of the context
+func checkResultQuota(ctx context.Context, max int64, documents int) (err error) {
+	if max > 0 && int64(documents) > max {
+		tenant, _ := TenantFromContext(ctx)
+		return &QuotaExceededError{Tenant: tenant, Quota: QuotaResultDocuments, Limit: float64(max)}
+	}
+	return nil
+}
+
+// insertedDocuments returns the documents inserted by the write models, to grow the storage estimate
+func insertedDocuments(models []mongo.WriteModel) []interface{} {
+	docs := []interface{}{}
+	for _, model := range models {
+		if insert, ok := model.(*mongo.InsertOneModel); ok {
+			docs = append(docs, insert.Document)
+		}
+	}
+	return docs
+}
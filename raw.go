@@ -0,0 +1,172 @@
+package mongodb
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// Raw variants return documents as received from the server without decoding them, compressed fields are returned as stored
+// Checksums are verified, which decodes the documents of collections having them
+// Write operations accept bson.Raw documents, they are only re-encoded when collection rules, generated ids or the journal apply to them
+
+// ReadOneRaw ...
+func (c *Client) ReadOneRaw(ctx context.Context, collection string, query interface{}, opts ...*options.FindOneOptions) (res bson.Raw, err error) {
+	// Checks tenant quota
+	err = c.checkQuota(ctx, false)
+	if err != nil {
+		return nil, err
+	}
+
+	// Checks strict mode
+	err = c.checkFindOne(ctx, collection, query, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	// Hits DB
+	coll, done := c.readCollection(ctx, collection)
+	defer func() { done(err) }()
+	res, err = coll.FindOne(ctx, query, append([]*options.FindOneOptions{c.findOneOptions(collection)}, opts...)...).DecodeBytes()
+	if err != nil {
+		// Handles no document found
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	// Verifies checksums
+	err = c.verifyRawChecksums(collection, res)
+	if err != nil {
+		return nil, err
+	}
+
+	// Returns
+	return res, nil
+}
+
+// ReadRaw ...
+func (c *Client) ReadRaw(ctx context.Context, collection string, query interface{}, opts ...*options.FindOptions) (res []bson.Raw, err error) {
+	// Checks tenant quota
+	err = c.checkQuota(ctx, false)
+	if err != nil {
+		return nil, err
+	}
+
+	// Checks strict mode
+	err = c.checkFind(ctx, collection, query, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	// Limits result to the tenant quota
+	maxResult, opts := c.resultQuota(ctx, opts)
+
+	// Hits DB
+	coll, done := c.readCollection(ctx, collection)
+	defer func() { done(err) }()
+	cursor, err := coll.Find(ctx, query, append([]*options.FindOptions{c.findOptions(collection)}, opts...)...)
+	if err != nil {
+		return nil, err
+	}
+
+	// Binds cursor response
+	res, err = rawDocuments(ctx, cursor)
+	if err != nil {
+		return nil, err
+	}
+	err = checkResultQuota(ctx, maxResult, len(res))
+	if err != nil {
+		return nil, err
+	}
+
+	// Verifies checksums
+	err = c.verifyRawChecksums(collection, res...)
+	if err != nil {
+		return nil, err
+	}
+
+	// Returns
+	return res, nil
+}
+
+// ReadWithProjectionRaw ...
+func (c *Client) ReadWithProjectionRaw(ctx context.Context, collection string, query interface{}, projection interface{}, opts ...*options.FindOptions) (res []bson.Raw, err error) {
+	// Checks tenant quota
+	err = c.checkQuota(ctx, false)
+	if err != nil {
+		return nil, err
+	}
+
+	// Checks strict mode
+	err = c.checkFind(ctx, collection, query, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	// Limits result to the tenant quota
+	maxResult, opts := c.resultQuota(ctx, opts)
+
+	// Hits DB
+	coll, done := c.readCollection(ctx, collection)
+	defer func() { done(err) }()
+	cursor, err := coll.Find(ctx, query, append([]*options.FindOptions{c.findOptions(collection).SetProjection(projection)}, opts...)...)
+	if err != nil {
+		return nil, err
+	}
+
+	// Binds cursor response
+	res, err = rawDocuments(ctx, cursor)
+	if err != nil {
+		return nil, err
+	}
+	err = checkResultQuota(ctx, maxResult, len(res))
+	if err != nil {
+		return nil, err
+	}
+
+	// Returns
+	return res, nil
+}
+
+// rawDocuments collects the cursor documents
+// Cursor reuses its buffer between batches so every document is copied
+func rawDocuments(ctx context.Context, cursor *mongo.Cursor) (res []bson.Raw, err error) {
+	// Close connection at the last
+	defer cursor.Close(ctx)
+
+	// Binds cursor response
+	for cursor.Next(ctx) {
+		res = append(res, append(bson.Raw(nil), cursor.Current...))
+	}
+	err = cursor.Err()
+	if err != nil {
+		return nil, err
+	}
+
+	// Returns
+	return res, nil
+}
+
+// verifyRawChecksums decodes the documents to verify them when the collection has checksums
+func (c *Client) verifyRawChecksums(collection string, docs ...bson.Raw) (err error) {
+	if s := c.settings(collection); s == nil || s.checksum == nil {
+		return nil
+	}
+	for _, raw := range docs {
+		var doc bson.D
+		err = bson.Unmarshal(raw, &doc)
+		if err != nil {
+			return err
+		}
+		err = c.verifyChecksums(collection, doc)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,156 @@
+package mongodb
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.mongodb.org/mongo-driver/mongo/readpref"
+	"go.mongodb.org/mongo-driver/tag"
+)
+
+// ReadDistribution round robins the reads (ReadOne, Read, ReadWithProjection & raw variants) across tagged secondaries
+// Every secondary needs a replica set member tag identifying it, e.g. {"node": "db-2"}
+type ReadDistribution struct {
+	Nodes        []map[string]string // Tag set of every secondary reads are distributed to
+	MaxFailures  int                 // Consecutive network, selection or timeout failures ejecting a node. (default is 3)
+	EjectionTime int                 // In milliseconds, How long an ejected node receives no reads. (default is 30 seconds)
+}
+
+// readNode ...
+type readNode struct {
+	readPref     *readpref.ReadPref
+	failures     int
+	ejectedUntil time.Time
+}
+
+// readBalancer ...
+type readBalancer struct {
+	mu           sync.Mutex
+	nodes        []*readNode
+	next         int
+	maxFailures  int
+	ejectionTime time.Duration
+}
+
+// newReadBalancer ...
+func newReadBalancer(config *ReadDistribution, maxStaleness time.Duration) (b *readBalancer, err error) {
+	b = &readBalancer{maxFailures: config.MaxFailures, ejectionTime: time.Duration(config.EjectionTime) * time.Millisecond}
+	if b.maxFailures <= 0 {
+		b.maxFailures = 3
+	}
+	if b.ejectionTime <= 0 {
+		b.ejectionTime = 30 * time.Second
+	}
+
+	// Builds read preferences
+	for _, tags := range config.Nodes {
+		keys := make([]string, 0, len(tags))
+		for k := range tags {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		set := tag.Set{}
+		for _, k := range keys {
+			set = append(set, tag.Tag{Name: k, Value: tags[k]})
+		}
+		rpOpts := []readpref.Option{readpref.WithTagSets(set)}
+		if maxStaleness != 0 {
+			rpOpts = append(rpOpts, readpref.WithMaxStaleness(maxStaleness))
+		}
+		rp, err := readpref.New(readpref.SecondaryMode, rpOpts...)
+		if err != nil {
+			return nil, err
+		}
+		b.nodes = append(b.nodes, &readNode{readPref: rp})
+	}
+
+	// Returns
+	return b, nil
+}
+
+// pick returns the next healthy node, nil when every node is ejected
+func (b *readBalancer) pick() *readNode {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	now := time.Now()
+	for i := 0; i < len(b.nodes); i++ {
+		node := b.nodes[b.next]
+		b.next = (b.next + 1) % len(b.nodes)
+		if now.After(node.ejectedUntil) {
+			return node
+		}
+	}
+	return nil
+}
+
+// report records the outcome of a read on the node, ejecting it after too many consecutive failures
+func (b *readBalancer) report(node *readNode, err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if err == nil || !(isUnreachable(err) || mongo.IsTimeout(err)) {
+		node.failures = 0
+		return
+	}
+	node.failures++
+	if node.failures >= b.maxFailures {
+		node.failures = 0
+		node.ejectedUntil = time.Now().Add(b.ejectionTime)
+	}
+}
+
+// readCollection returns the collection to read from and a callback receiving the outcome of the read
+// Reads go to the next healthy tagged secondary when a read distribution is configured
+// A max staleness set on the context overrides the configured one, transactions always read from the primary
+func (c *Client) readCollection(ctx context.Context, name string) (coll *mongo.Collection, done func(err error)) {
+	coll = c.collection(ctx, name)
+	done = func(error) {}
+	if inTransaction(ctx) {
+		return coll, done
+	}
+
+	// Picks node, the default read preference serves reads when all are ejected
+	rp := c.readPref
+	var node *readNode
+	if c.readBalancer != nil {
+		node = c.readBalancer.pick()
+		if node != nil {
+			rp = node.readPref
+			done = func(err error) { c.readBalancer.report(node, err) }
+		}
+	}
+
+	// Overrides staleness, it does not apply to primary reads
+	if maxStaleness, ok := ctx.Value(maxStalenessKey{}).(time.Duration); ok && rp != nil && rp.Mode() != readpref.PrimaryMode {
+		stale, err := readpref.New(rp.Mode(), readpref.WithTagSets(rp.TagSets()...), readpref.WithMaxStaleness(maxStaleness))
+		if err == nil {
+			rp = stale
+		}
+	}
+	if rp == nil || rp == c.readPref {
+		return coll, done
+	}
+
+	// Applies read preference
+	clone, err := coll.Clone(options.Collection().SetReadPreference(rp))
+	if err != nil {
+		return coll, done
+	}
+
+	// Returns
+	return clone, done
+}
+
+// maxStalenessKey ...
+type maxStalenessKey struct{}
+
+// WithMaxStaleness bounds the replication lag of the secondaries serving the reads run with the returned context
+// It overrides Connection.MaxStaleness, the server requires at least 90 seconds
+func WithMaxStaleness(ctx context.Context, maxStaleness time.Duration) context.Context {
+	return context.WithValue(ctx, maxStalenessKey{}, maxStaleness)
+}
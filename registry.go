@@ -0,0 +1,170 @@
+package mongodb
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Default time a schema fetched from a registry is used before it is fetched again
+const defaultSchemaTTL = 5 * time.Minute
+
+// SchemaFetcher fetches the JSON schema of a subject from an external schema registry
+type SchemaFetcher interface {
+	FetchSchema(ctx context.Context, subject string) (jsonSchema string, err error)
+}
+
+// SchemaFetcherFunc adapts a function to a SchemaFetcher
+type SchemaFetcherFunc func(ctx context.Context, subject string) (jsonSchema string, err error)
+
+// FetchSchema ...
+func (f SchemaFetcherFunc) FetchSchema(ctx context.Context, subject string) (jsonSchema string, err error) {
+	return f(ctx, subject)
+}
+
+// HTTPSchemaFetcher fetches schemas with a GET request, e.g. from a Confluent compatible registry
+// The body is either the schema itself or an object carrying it as a string in its "schema" field
+type HTTPSchemaFetcher struct {
+	URL    string       // Schema URL, {subject} is replaced by the subject, e.g. "https://registry/subjects/{subject}/versions/latest"
+	Header http.Header  // Headers of the request, e.g. authorization
+	Client *http.Client // (default is http.DefaultClient)
+}
+
+// FetchSchema ...
+func (f *HTTPSchemaFetcher) FetchSchema(ctx context.Context, subject string) (jsonSchema string, err error) {
+	// Builds request
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, strings.ReplaceAll(f.URL, "{subject}", subject), nil)
+	if err != nil {
+		return "", err
+	}
+	for key, values := range f.Header {
+		req.Header[key] = values
+	}
+	client := f.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	// Hits registry
+	resp, err := client.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return "", errors.New("schema registry returned status " + strconv.Itoa(resp.StatusCode) + " for subject " + subject)
+	}
+
+	// Unwraps schema
+	var wrapped struct {
+		Schema string `json:"schema"`
+	}
+	if json.Unmarshal(body, &wrapped) == nil && wrapped.Schema != "" {
+		return wrapped.Schema, nil
+	}
+	return string(body), nil
+}
+
+// registrySchema is a schema fetched from a registry, refreshed in the background once stale
+type registrySchema struct {
+	fetcher    SchemaFetcher
+	subject    string
+	ttl        time.Duration
+	onError    func(subject string, err error)
+	mu         sync.Mutex
+	schema     *jsonSchema
+	fetchedAt  time.Time
+	refreshing bool
+}
+
+// SchemaRegistryOptions ...
+type SchemaRegistryOptions struct {
+	TTL     int                             // In milliseconds, How long a fetched schema is used before it is refreshed in the background. (default is 5 minutes)
+	OnError func(subject string, err error) // Called when a background refresh fails, the previous schema stays in use
+}
+
+// UseSchemaRegistry validates the documents of the collection against the schema of the subject fetched from a registry
+// The schema is fetched once now, then cached and refreshed in the background so writes never wait on the registry
+// It replaces a schema set with SetCollectionSchema
+func (c *Client) UseSchemaRegistry(ctx context.Context, collection string, subject string, fetcher SchemaFetcher, opts *SchemaRegistryOptions) (err error) {
+	if opts == nil {
+		opts = &SchemaRegistryOptions{}
+	}
+	r := &registrySchema{fetcher: fetcher, subject: subject, ttl: defaultSchemaTTL, onError: opts.OnError}
+	if opts.TTL != 0 {
+		r.ttl = time.Duration(opts.TTL) * time.Millisecond
+	}
+
+	// Fetches
+	err = r.fetch(ctx)
+	if err != nil {
+		return err
+	}
+
+	// Registers
+	c.configure(collection, func(s *collectionSettings) {
+		s.schema = nil
+		s.registry = r
+	})
+
+	// Returns
+	return nil
+}
+
+// fetch ...
+func (r *registrySchema) fetch(ctx context.Context) (err error) {
+	// Hits registry
+	definition, err := r.fetcher.FetchSchema(ctx, r.subject)
+	if err != nil {
+		return errors.New("fetching schema of subject " + r.subject + " failed -> " + err.Error())
+	}
+	schema, err := compileSchema(definition)
+	if err != nil {
+		return errors.New("schema of subject " + r.subject + " -> " + err.Error())
+	}
+
+	// Stores
+	r.mu.Lock()
+	r.schema = schema
+	r.fetchedAt = time.Now()
+	r.mu.Unlock()
+
+	// Returns
+	return nil
+}
+
+// current returns the cached schema, starting a background refresh once it is stale
+func (r *registrySchema) current() *jsonSchema {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if time.Since(r.fetchedAt) > r.ttl && !r.refreshing {
+		r.refreshing = true
+		go func() {
+			ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+			defer cancel()
+			err := r.fetch(ctx)
+			r.mu.Lock()
+			r.refreshing = false
+			if err != nil {
+				// Retries after another ttl
+				r.fetchedAt = time.Now()
+			}
+			r.mu.Unlock()
+			if err != nil && r.onError != nil {
+				r.onError(r.subject, err)
+			}
+		}()
+	}
+	return r.schema
+}
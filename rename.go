@@ -0,0 +1,130 @@
+package mongodb
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// Default number of documents renamed per batch
+const defaultRenameBatchSize = 1000
+
+// RenameOptions controls a batched field rename
+type RenameOptions struct {
+	BatchSize   int                                     // Number of documents renamed per batch. (default is 1000)
+	BatchDelay  int                                     // In milliseconds, Pause between batches to limit the load on the cluster. (default is 0)
+	ResumeAfter interface{}                             // The _id after which an interrupted rename resumes, as reported by OnProgress. (default is nil, from the start)
+	OnProgress  func(renamed int64, lastID interface{}) // Called after every batch with the total of renamed documents and the last _id processed
+}
+
+// RenameField renames a field across the collection in batches of _id ranges using $rename
+// The rename is resumable: documents already renamed no longer match, and ResumeAfter skips the processed _id range
+func (c *Client) RenameField(ctx context.Context, collection string, from string, to string, opts *RenameOptions) (renamed int64, err error) {
+	// Checks append-only
+	err = c.checkAppendOnly(ctx, collection, "RenameField")
+	if err != nil {
+		return 0, err
+	}
+
+	if from == "" || to == "" || from == to {
+		return 0, errors.New("invalid rename from " + from + " to " + to)
+	}
+	if opts == nil {
+		opts = &RenameOptions{}
+	}
+	batchSize := opts.BatchSize
+	if batchSize <= 0 {
+		batchSize = defaultRenameBatchSize
+	}
+	coll := c.collection(ctx, collection)
+	lastID := opts.ResumeAfter
+
+	for {
+		// Finds next batch
+		query := bson.D{{Key: from, Value: bson.D{{Key: "$exists", Value: true}}}}
+		if lastID != nil {
+			query = append(query, bson.E{Key: "_id", Value: bson.D{{Key: "$gt", Value: lastID}}})
+		}
+		findOpts := options.Find().
+			SetProjection(bson.D{{Key: "_id", Value: 1}}).
+			SetSort(bson.D{{Key: "_id", Value: 1}}).
+			SetLimit(int64(batchSize))
+		cursor, err := coll.Find(ctx, query, findOpts)
+		if err != nil {
+			return renamed, err
+		}
+		var batch []struct {
+			ID interface{} `bson:"_id"`
+		}
+		err = cursor.All(ctx, &batch)
+		if err != nil {
+			return renamed, err
+		}
+		if len(batch) == 0 {
+			return renamed, nil
+		}
+		ids := make(bson.A, 0, len(batch))
+		for _, doc := range batch {
+			ids = append(ids, doc.ID)
+		}
+
+		// Renames
+		result, err := coll.UpdateMany(ctx,
+			bson.D{{Key: "_id", Value: bson.D{{Key: "$in", Value: ids}}}, {Key: from, Value: bson.D{{Key: "$exists", Value: true}}}},
+			bson.D{{Key: "$rename", Value: bson.D{{Key: from, Value: to}}}},
+		)
+		if err != nil {
+			return renamed, err
+		}
+		renamed += result.ModifiedCount
+		lastID = ids[len(ids)-1]
+
+		// Reports progress
+		if opts.OnProgress != nil {
+			err = safeCall(c.panicHandler, "RenameOptions.OnProgress", func() { opts.OnProgress(renamed, lastID) })
+			if err != nil {
+				return renamed, err
+			}
+		}
+
+		// Limits rate
+		if opts.BatchDelay > 0 {
+			select {
+			case <-ctx.Done():
+				return renamed, ctx.Err()
+			case <-time.After(time.Duration(opts.BatchDelay) * time.Millisecond):
+			}
+		}
+	}
+}
+
+// DualReadFilter matches a condition against both the old and the new name of a field being renamed
+// It lets reads work while RenameField is in progress, e.g. DualReadFilter("userName", "username", "bob")
+func DualReadFilter(from string, to string, condition interface{}) bson.D {
+	return bson.D{{Key: "$or", Value: bson.A{
+		bson.D{{Key: from, Value: condition}},
+		bson.D{{Key: to, Value: condition}},
+	}}}
+}
+
+// DualReadValue returns the value of a field being renamed from a read document, preferring the new name
+// Document is a bson.D or bson.M as returned by reads
+func DualReadValue(document interface{}, from string, to string) (value interface{}, ok bool) {
+	var fields bson.M
+	switch d := document.(type) {
+	case bson.M:
+		fields = d
+	case bson.D:
+		fields = d.Map()
+	default:
+		return nil, false
+	}
+	if value, ok = fields[to]; ok {
+		return value, true
+	}
+	value, ok = fields[from]
+	return value, ok
+}
@@ -0,0 +1,128 @@
+package mongodb
+
+import (
+	"context"
+	"fmt"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// Limits of ResultLimits
+const (
+	LimitDocuments = "documents"
+	LimitBytes     = "bytes"
+)
+
+// resultLimitsKey is the context key of the result limits of reads
+type resultLimitsKey struct{}
+
+// ResultLimits bounds what a read loads in memory, zero fields are unlimited
+type ResultLimits struct {
+	MaxDocuments int64 // Documents returned by a read (default is 0, unlimited)
+	MaxBytes     int64 // BSON bytes of the documents returned by a read (default is 0, unlimited)
+}
+
+// PartialResultsError is returned along with the documents read so far when a result reached its limits, the other documents are not read
+type PartialResultsError struct {
+	Collection string
+	Limit      string // LimitDocuments or LimitBytes, the limit reached
+	Documents  int64  // Returned documents
+	Bytes      int64  // BSON bytes of the returned documents
+}
+
+// Error ...
+func (e *PartialResultsError) Error() string {
+	return fmt.Sprintf("result of %s truncated to %d documents (%d bytes), its %s limit was reached", e.Collection, e.Documents, e.Bytes, e.Limit)
+}
+
+// WithResultLimits sets the result limits of the Read, ReadWithProjection and Aggregate calls run with the returned context, nil removes them
+func WithResultLimits(ctx context.Context, limits *ResultLimits) context.Context {
+	return context.WithValue(ctx, resultLimitsKey{}, limits)
+}
+
+// resultLimits returns the limits of the context, else the limits of the client, nil when unlimited
+func (c *Client) resultLimits(ctx context.Context) *ResultLimits {
+	if limits, ok := ctx.Value(resultLimitsKey{}).(*ResultLimits); ok {
+		return limits
+	}
+	return c.limits
+}
+
+// limitFind limits the find to max documents unless its limit is lower, 0 max leaves it unlimited
+func limitFind(opts []*options.FindOptions, max int64) []*options.FindOptions {
+	if max <= 0 {
+		return opts
+	}
+	if limit := options.MergeFindOptions(opts...).Limit; limit == nil || *limit <= 0 || *limit > max {
+		opts = append(append([]*options.FindOptions{}, opts...), options.Find().SetLimit(max))
+	}
+	return opts
+}
+
+// iterateLimited calls fn with the documents of the cursor until a limit is reached, cursor.Current holds the document
+// partial is set when documents were left unread
+func iterateLimited(ctx context.Context, collection string, cursor *mongo.Cursor, limits *ResultLimits, fn func() error) (partial *PartialResultsError, err error) {
+	var documents, bytes int64
+	for cursor.Next(ctx) {
+		// Checks limits
+		if limits.MaxDocuments > 0 && documents >= limits.MaxDocuments {
+			partial = &PartialResultsError{Collection: collection, Limit: LimitDocuments}
+		} else if limits.MaxBytes > 0 && bytes+int64(len(cursor.Current)) > limits.MaxBytes {
+			partial = &PartialResultsError{Collection: collection, Limit: LimitBytes}
+		}
+		if partial != nil {
+			partial.Documents, partial.Bytes = documents, bytes
+			return partial, nil
+		}
+
+		// Binds
+		err = fn()
+		if err != nil {
+			return nil, err
+		}
+		documents++
+		bytes += int64(len(cursor.Current))
+	}
+	return nil, cursor.Err()
+}
+
+// bindResults decodes the documents of the cursor into res within the result limits of the context
+func (c *Client) bindResults(ctx context.Context, collection string, cursor *mongo.Cursor, res *[]interface{}) (partial *PartialResultsError, err error) {
+	limits := c.resultLimits(ctx)
+	if limits == nil {
+		return nil, cursor.All(ctx, res)
+	}
+	return iterateLimited(ctx, collection, cursor, limits, func() error {
+		var doc interface{}
+		err := cursor.Decode(&doc)
+		if err != nil {
+			return err
+		}
+		*res = append(*res, doc)
+		return nil
+	})
+}
+
+// bindLimitedAll decodes the documents of the cursor into out, a pointer to a slice, within the result limits
+func (c *Client) bindLimitedAll(ctx context.Context, collection string, cursor *mongo.Cursor, limits *ResultLimits, out interface{}) (partial *PartialResultsError, err error) {
+	// Collects documents, cursor reuses its buffer between batches so every document is copied
+	docs := []bson.Raw{}
+	partial, err = iterateLimited(ctx, collection, cursor, limits, func() error {
+		docs = append(docs, append(bson.Raw(nil), cursor.Current...))
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Decodes
+	err = c.decodeInto(docs, out)
+	if err != nil {
+		return nil, err
+	}
+
+	// Returns
+	return partial, nil
+}
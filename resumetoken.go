@@ -0,0 +1,66 @@
+package mongodb
+
+import (
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// ResumeTokenStore persists the resume tokens of named change streams, so they survive process restarts
+type ResumeTokenStore interface {
+	Load(ctx context.Context, name string) (token bson.Raw, err error) // Nil token when none was saved
+	Save(ctx context.Context, name string, token bson.Raw) (err error)
+}
+
+// MongoResumeTokenStore stores resume tokens in a collection of the database, as {_id: name, token, updatedAt}
+type MongoResumeTokenStore struct {
+	client     *Client
+	collection string
+}
+
+// NewMongoResumeTokenStore returns a store of the collection, the one of consumers when empty
+func (c *Client) NewMongoResumeTokenStore(collection string) *MongoResumeTokenStore {
+	if collection == "" {
+		collection = resumeTokenCollection
+	}
+	return &MongoResumeTokenStore{client: c, collection: collection}
+}
+
+// Load ...
+func (s *MongoResumeTokenStore) Load(ctx context.Context, name string) (token bson.Raw, err error) {
+	// Hits DB
+	var stored struct {
+		Token bson.Raw `bson:"token"`
+	}
+	err = s.client.collection(ctx, s.collection).FindOne(ctx, bson.D{{Key: "_id", Value: name}}).Decode(&stored)
+	if err != nil {
+		// Handles no document found
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	// Returns
+	return stored.Token, nil
+}
+
+// Save ...
+func (s *MongoResumeTokenStore) Save(ctx context.Context, name string, token bson.Raw) (err error) {
+	// Hits DB
+	_, err = s.client.collection(ctx, s.collection).UpdateOne(ctx,
+		bson.D{{Key: "_id", Value: name}},
+		bson.D{{Key: "$set", Value: bson.D{{Key: "token", Value: token}, {Key: "updatedAt", Value: time.Now()}}}},
+		options.Update().SetUpsert(true),
+	)
+	if err != nil {
+		return err
+	}
+
+	// Returns
+	return nil
+}
@@ -0,0 +1,298 @@
+// Package s3 streams objects to and from S3 compatible object storages (AWS S3, MinIO, GCS interoperability, R2...)
+// A Client implements the BlobSink and BlobSource interfaces of the mongodb package
+package s3
+
+import (
+	"bytes"
+	"context"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Default and minimum multipart upload part sizes
+const (
+	defaultPartSize = 8 << 20
+	minPartSize     = 5 << 20
+)
+
+// Config contains the properties required to reach a bucket
+type Config struct {
+	Endpoint        string       // Storage endpoint, e.g. https://s3.eu-west-1.amazonaws.com or http://minio:9000
+	Region          string       // Signing region. (default is us-east-1)
+	Bucket          string       // Bucket name
+	AccessKeyID     string       // Access key id
+	SecretAccessKey string       // Secret access key
+	SessionToken    string       // Temporary credentials session token (default is empty)
+	PathStyle       bool         // Addresses the bucket as endpoint/bucket instead of bucket.endpoint, as most S3 compatible servers require. (default is false)
+	PartSize        int          // In bytes, Size of the parts of multipart uploads, objects smaller than one part are uploaded in a single request. (default is 8 MiB) (minimum is 5 MiB)
+	HTTPClient      *http.Client // HTTP client used for requests. (default is a client without timeout, uploads can be long)
+}
+
+// Client reads and writes the objects of a bucket
+type Client struct {
+	config     *Config
+	endpoint   *url.URL
+	partSize   int
+	httpClient *http.Client
+}
+
+// New returns a new client of the bucket
+func New(config *Config) (client *Client, err error) {
+	// Validates
+	if config.Endpoint == "" || config.Bucket == "" {
+		return nil, errors.New("s3 endpoint and bucket are required")
+	}
+	if config.AccessKeyID == "" || config.SecretAccessKey == "" {
+		return nil, errors.New("s3 credentials are required")
+	}
+	endpoint, err := url.Parse(strings.TrimSuffix(config.Endpoint, "/"))
+	if err != nil {
+		return nil, errors.New("invalid s3 endpoint -> " + err.Error())
+	}
+	if config.PartSize != 0 && config.PartSize < minPartSize {
+		return nil, errors.New("s3 part size must be at least 5 MiB")
+	}
+
+	// Sets defaults
+	cfg := *config
+	if cfg.Region == "" {
+		cfg.Region = "us-east-1"
+	}
+	client = &Client{config: &cfg, endpoint: endpoint, partSize: cfg.PartSize, httpClient: cfg.HTTPClient}
+	if client.partSize == 0 {
+		client.partSize = defaultPartSize
+	}
+	if client.httpClient == nil {
+		client.httpClient = &http.Client{}
+	}
+
+	// Returns
+	return client, nil
+}
+
+// Create returns a writer uploading the object, parts are uploaded as they fill so the object is never held whole in memory
+// The object is stored once the writer is closed, a failed write aborts the upload
+func (c *Client) Create(ctx context.Context, key string) (w io.WriteCloser, err error) {
+	return &uploader{client: c, ctx: ctx, key: key}, nil
+}
+
+// Open returns the content of the object
+func (c *Client) Open(ctx context.Context, key string) (r io.ReadCloser, err error) {
+	// Hits API
+	res, err := c.do(ctx, http.MethodGet, key, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	// Returns
+	return res.Body, nil
+}
+
+// Delete deletes the object, deleting a missing object succeeds
+func (c *Client) Delete(ctx context.Context, key string) (err error) {
+	// Hits API
+	res, err := c.do(ctx, http.MethodDelete, key, nil, nil)
+	if err != nil {
+		return err
+	}
+	res.Body.Close()
+
+	// Returns
+	return nil
+}
+
+// uploader buffers a part and uploads it once full
+type uploader struct {
+	client   *Client
+	ctx      context.Context
+	key      string
+	buf      bytes.Buffer
+	uploadID string
+	etags    []string
+	err      error
+	closed   bool
+}
+
+// Write ...
+func (u *uploader) Write(p []byte) (n int, err error) {
+	if u.err != nil {
+		return 0, u.err
+	}
+	if u.closed {
+		return 0, errors.New("write to closed s3 object " + u.key)
+	}
+	n, _ = u.buf.Write(p)
+	for u.buf.Len() >= u.client.partSize {
+		err = u.uploadPart(u.buf.Next(u.client.partSize))
+		if err != nil {
+			u.fail(err)
+			return n, err
+		}
+	}
+	return n, nil
+}
+
+// Close uploads the remaining bytes and completes the upload
+func (u *uploader) Close() (err error) {
+	if u.err != nil || u.closed {
+		return u.err
+	}
+	u.closed = true
+
+	// Uploads small objects at once
+	if u.uploadID == "" {
+		res, err := u.client.do(u.ctx, http.MethodPut, u.key, nil, u.buf.Bytes())
+		if err != nil {
+			return err
+		}
+		res.Body.Close()
+		return nil
+	}
+
+	// Uploads last part
+	if u.buf.Len() != 0 {
+		err = u.uploadPart(u.buf.Bytes())
+		if err != nil {
+			u.fail(err)
+			return err
+		}
+	}
+
+	// Completes upload
+	var body bytes.Buffer
+	body.WriteString("<CompleteMultipartUpload>")
+	for i, etag := range u.etags {
+		body.WriteString("<Part><PartNumber>" + strconv.Itoa(i+1) + "</PartNumber><ETag>")
+		xml.EscapeText(&body, []byte(etag))
+		body.WriteString("</ETag></Part>")
+	}
+	body.WriteString("</CompleteMultipartUpload>")
+	res, err := u.client.do(u.ctx, http.MethodPost, u.key, url.Values{"uploadId": {u.uploadID}}, body.Bytes())
+	if err != nil {
+		u.fail(err)
+		return err
+	}
+	defer res.Body.Close()
+
+	// Checks embedded error, the server answers 200 before the assembly completes
+	data, err := ioutil.ReadAll(res.Body)
+	if err != nil {
+		u.fail(err)
+		return err
+	}
+	if bytes.Contains(data, []byte("<Error>")) {
+		err = responseError(res.StatusCode, data)
+		u.fail(err)
+		return err
+	}
+
+	// Returns
+	return nil
+}
+
+// uploadPart starts the multipart upload if needed and uploads the next part
+func (u *uploader) uploadPart(part []byte) (err error) {
+	// Starts upload
+	if u.uploadID == "" {
+		res, err := u.client.do(u.ctx, http.MethodPost, u.key, url.Values{"uploads": {""}}, nil)
+		if err != nil {
+			return err
+		}
+		defer res.Body.Close()
+		var initiated struct {
+			UploadID string `xml:"UploadId"`
+		}
+		err = xml.NewDecoder(res.Body).Decode(&initiated)
+		if err != nil {
+			return errors.New("decoding multipart upload -> " + err.Error())
+		}
+		u.uploadID = initiated.UploadID
+	}
+
+	// Uploads part
+	query := url.Values{"partNumber": {strconv.Itoa(len(u.etags) + 1)}, "uploadId": {u.uploadID}}
+	res, err := u.client.do(u.ctx, http.MethodPut, u.key, query, part)
+	if err != nil {
+		return err
+	}
+	res.Body.Close()
+	u.etags = append(u.etags, res.Header.Get("ETag"))
+
+	// Returns
+	return nil
+}
+
+// fail records the failure and aborts the multipart upload, so its parts are not billed
+func (u *uploader) fail(err error) {
+	u.err = err
+	if u.uploadID != "" {
+		res, abortErr := u.client.do(context.Background(), http.MethodDelete, u.key, url.Values{"uploadId": {u.uploadID}}, nil)
+		if abortErr == nil {
+			res.Body.Close()
+		}
+	}
+}
+
+// do sends a signed request for the object, responses other than 2xx are returned as errors
+func (c *Client) do(ctx context.Context, method string, key string, query url.Values, body []byte) (res *http.Response, err error) {
+	// Builds URL
+	u := *c.endpoint
+	path := "/" + strings.TrimPrefix(key, "/")
+	if c.config.PathStyle {
+		path = "/" + c.config.Bucket + path
+	} else {
+		u.Host = c.config.Bucket + "." + u.Host
+	}
+	u.Path = strings.TrimSuffix(c.endpoint.Path, "/") + path
+	u.RawPath = escape(u.Path, true)
+	u.RawQuery = canonicalQuery(query)
+
+	// Builds request
+	req, err := http.NewRequestWithContext(ctx, method, u.String(), bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.ContentLength = int64(len(body))
+	payloadHash := emptyPayloadHash
+	if len(body) != 0 {
+		payloadHash = hashHex(body)
+	}
+	c.sign(req, payloadHash, time.Now())
+
+	// Hits API
+	res, err = c.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if res.StatusCode/100 != 2 {
+		defer res.Body.Close()
+		data, _ := ioutil.ReadAll(io.LimitReader(res.Body, 64<<10))
+		if method == http.MethodDelete && res.StatusCode == http.StatusNotFound && query == nil {
+			return &http.Response{StatusCode: http.StatusNoContent, Body: ioutil.NopCloser(bytes.NewReader(nil))}, nil
+		}
+		return nil, responseError(res.StatusCode, data)
+	}
+
+	// Returns
+	return res, nil
+}
+
+// responseError converts an S3 error document into an error
+func responseError(status int, data []byte) error {
+	var e struct {
+		Code    string `xml:"Code"`
+		Message string `xml:"Message"`
+	}
+	if xml.Unmarshal(data, &e) == nil && e.Code != "" {
+		return fmt.Errorf("s3 %d %s -> %s", status, e.Code, e.Message)
+	}
+	return fmt.Errorf("s3 %d -> %s", status, strings.TrimSpace(string(data)))
+}
@@ -0,0 +1,586 @@
+package mongodb
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// SchemaViolation describes a single field failing the collection schema
+type SchemaViolation struct {
+	Path    string // Dotted path of the field, array elements are addressed by index
+	Message string // Reason of the failure
+}
+
+// SchemaError is returned when a document fails the client side collection schema
+type SchemaError struct {
+	Collection string
+	Violations []SchemaViolation
+}
+
+// Error ...
+func (e *SchemaError) Error() string {
+	msgs := make([]string, 0, len(e.Violations))
+	for _, v := range e.Violations {
+		msgs = append(msgs, v.Path+": "+v.Message)
+	}
+	return "document failed schema of collection " + e.Collection + " -> " + strings.Join(msgs, "; ")
+}
+
+// jsonSchema is the supported subset of JSON schema, including the mongodb bsonType keyword
+type jsonSchema struct {
+	types                []string
+	required             []string
+	properties           map[string]*jsonSchema
+	additionalProperties *bool
+	items                *jsonSchema
+	enum                 []interface{}
+	minimum              *float64
+	maximum              *float64
+	minLength            *int
+	maxLength            *int
+	minItems             *int
+	maxItems             *int
+	pattern              *regexp.Regexp
+}
+
+// SetCollectionSchema registers a JSON schema checked on client side before documents of the collection are inserted or updated
+// Schema may be given as is or wrapped as the server side validator {"$jsonSchema": {...}}, an empty schema removes validation
+func (c *Client) SetCollectionSchema(collection string, jsonSchema string) (err error) {
+	// Removes schema
+	if strings.TrimSpace(jsonSchema) == "" {
+		c.configure(collection, func(s *collectionSettings) {
+			s.schema = nil
+			s.registry = nil
+		})
+		return nil
+	}
+
+	// Parses
+	schema, err := compileSchema(jsonSchema)
+	if err != nil {
+		return err
+	}
+
+	// Registers
+	c.configure(collection, func(s *collectionSettings) {
+		s.schema = schema
+		s.registry = nil
+	})
+
+	// Returns
+	return nil
+}
+
+// compileSchema parses a JSON schema given as is or wrapped as {"$jsonSchema": {...}}
+func compileSchema(jsonSchema string) (schema *jsonSchema, err error) {
+	var definition map[string]interface{}
+	err = json.Unmarshal([]byte(jsonSchema), &definition)
+	if err != nil {
+		return nil, errors.New("invalid json schema -> " + err.Error())
+	}
+	if wrapped, ok := definition["$jsonSchema"].(map[string]interface{}); ok {
+		definition = wrapped
+	}
+	return parseJSONSchema(definition)
+}
+
+// parseJSONSchema ...
+func parseJSONSchema(definition map[string]interface{}) (schema *jsonSchema, err error) {
+	schema = &jsonSchema{}
+
+	// Types
+	for _, keyword := range []string{"type", "bsonType"} {
+		switch t := definition[keyword].(type) {
+		case nil:
+		case string:
+			schema.types = append(schema.types, t)
+		case []interface{}:
+			for _, v := range t {
+				name, ok := v.(string)
+				if !ok {
+					return nil, errors.New("invalid json schema -> " + keyword + " must be a string or an array of strings")
+				}
+				schema.types = append(schema.types, name)
+			}
+		default:
+			return nil, errors.New("invalid json schema -> " + keyword + " must be a string or an array of strings")
+		}
+	}
+
+	// Required
+	if required, ok := definition["required"].([]interface{}); ok {
+		for _, v := range required {
+			name, ok := v.(string)
+			if !ok {
+				return nil, errors.New("invalid json schema -> required must be an array of strings")
+			}
+			schema.required = append(schema.required, name)
+		}
+	}
+
+	// Properties
+	if properties, ok := definition["properties"].(map[string]interface{}); ok {
+		schema.properties = map[string]*jsonSchema{}
+		for name, v := range properties {
+			sub, ok := v.(map[string]interface{})
+			if !ok {
+				return nil, errors.New("invalid json schema -> property " + name + " must be an object")
+			}
+			schema.properties[name], err = parseJSONSchema(sub)
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+	if additional, ok := definition["additionalProperties"].(bool); ok {
+		schema.additionalProperties = &additional
+	}
+
+	// Items
+	if items, ok := definition["items"].(map[string]interface{}); ok {
+		schema.items, err = parseJSONSchema(items)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// Enum
+	if enum, ok := definition["enum"].([]interface{}); ok {
+		schema.enum = enum
+	}
+
+	// Bounds
+	schema.minimum = schemaNumber(definition["minimum"])
+	schema.maximum = schemaNumber(definition["maximum"])
+	schema.minLength = schemaInt(definition["minLength"])
+	schema.maxLength = schemaInt(definition["maxLength"])
+	schema.minItems = schemaInt(definition["minItems"])
+	schema.maxItems = schemaInt(definition["maxItems"])
+
+	// Pattern
+	if pattern, ok := definition["pattern"].(string); ok {
+		schema.pattern, err = regexp.Compile(pattern)
+		if err != nil {
+			return nil, errors.New("invalid json schema -> " + err.Error())
+		}
+	}
+
+	// Returns
+	return schema, nil
+}
+
+// schemaNumber ...
+func schemaNumber(v interface{}) *float64 {
+	n, ok := v.(float64)
+	if !ok {
+		return nil
+	}
+	return &n
+}
+
+// schemaInt ...
+func schemaInt(v interface{}) *int {
+	n, ok := v.(float64)
+	if !ok {
+		return nil
+	}
+	i := int(n)
+	return &i
+}
+
+// validateDocument checks a whole document against the schema
+func (s *jsonSchema) validateDocument(collection string, document interface{}) (err error) {
+	// Converts
+	doc, err := toMap(document)
+	if err != nil {
+		return err
+	}
+
+	// Validates
+	var violations []SchemaViolation
+	s.validate("", doc, &violations)
+	if len(violations) != 0 {
+		return &SchemaError{Collection: collection, Violations: violations}
+	}
+
+	// Returns
+	return nil
+}
+
+// validateUpdate checks an update document against the schema
+// Replacement documents are validated as a whole, $set and $setOnInsert values against the schema of their path
+// Paths not described by the schema are rejected where the enclosing schema forbids additional properties
+func (s *jsonSchema) validateUpdate(collection string, update interface{}) (err error) {
+	// Pipeline updates can not be checked on client side
+	if isPipeline(update) {
+		return nil
+	}
+
+	// Converts
+	doc, err := toMap(update)
+	if err != nil {
+		return err
+	}
+
+	// Checks replacement
+	operators := false
+	for key := range doc {
+		if strings.HasPrefix(key, "$") {
+			operators = true
+			break
+		}
+	}
+	if !operators {
+		return s.validateDocument(collection, doc)
+	}
+
+	// Validates operators
+	var violations []SchemaViolation
+	for _, operator := range []string{"$set", "$setOnInsert"} {
+		fields, ok := doc[operator].(bson.M)
+		if !ok {
+			continue
+		}
+		for _, path := range sortedKeys(fields) {
+			value := fields[path]
+			if sub := s.lookup(path); sub != nil {
+				sub.validate(path, value, &violations)
+			} else if !s.allows(path) {
+				violations = append(violations, SchemaViolation{Path: path, Message: "additional field is not allowed"})
+			}
+		}
+	}
+	if fields, ok := doc["$unset"].(bson.M); ok {
+		for _, path := range sortedKeys(fields) {
+			if s.requires(path) {
+				violations = append(violations, SchemaViolation{Path: path, Message: "required field can not be unset"})
+			}
+		}
+	}
+	if len(violations) != 0 {
+		return &SchemaError{Collection: collection, Violations: violations}
+	}
+
+	// Returns
+	return nil
+}
+
+// isDocumentSlice reports whether the value is an ordered document (bson.D) rather than a pipeline
+func isDocumentSlice(v interface{}) bool {
+	switch v.(type) {
+	case bson.D, bson.Raw:
+		return true
+	}
+	return false
+}
+
+// isPipeline reports whether an update is an aggregation pipeline rather than a document
+func isPipeline(update interface{}) bool {
+	return reflect.ValueOf(update).Kind() == reflect.Slice && !isDocumentSlice(update)
+}
+
+// lookup returns the schema of a dotted path, nil when the path is not described
+func (s *jsonSchema) lookup(path string) *jsonSchema {
+	current := s
+	for _, part := range strings.Split(path, ".") {
+		if _, err := strconv.Atoi(part); err == nil && current.items != nil {
+			current = current.items
+			continue
+		}
+		next, ok := current.properties[part]
+		if !ok {
+			return nil
+		}
+		current = next
+	}
+	return current
+}
+
+// allows reports whether a dotted path not described by the schema may be set, false when its enclosing schema forbids additional properties
+func (s *jsonSchema) allows(path string) bool {
+	current := s
+	for _, part := range strings.Split(path, ".") {
+		if _, err := strconv.Atoi(part); err == nil && current.items != nil {
+			current = current.items
+			continue
+		}
+		next, ok := current.properties[part]
+		if !ok {
+			return current.additionalProperties == nil || *current.additionalProperties
+		}
+		current = next
+	}
+	return true
+}
+
+// requires reports whether the dotted path is a required field
+func (s *jsonSchema) requires(path string) bool {
+	parent := s
+	name := path
+	if i := strings.LastIndex(path, "."); i != -1 {
+		parent = s.lookup(path[:i])
+		name = path[i+1:]
+	}
+	if parent == nil {
+		return false
+	}
+	for _, r := range parent.required {
+		if r == name {
+			return true
+		}
+	}
+	return false
+}
+
+// validate appends the violations of the value at path
+func (s *jsonSchema) validate(path string, value interface{}, violations *[]SchemaViolation) {
+	report := func(message string) {
+		p := path
+		if p == "" {
+			p = "$"
+		}
+		*violations = append(*violations, SchemaViolation{Path: p, Message: message})
+	}
+
+	// Type
+	if len(s.types) != 0 && !matchesAnyType(value, s.types) {
+		report(fmt.Sprintf("expected type %s, got %s", strings.Join(s.types, " or "), bsonTypeName(value)))
+		return
+	}
+
+	// Enum
+	if len(s.enum) != 0 && !inEnum(value, s.enum) {
+		report(fmt.Sprintf("value %v is not one of the allowed values", value))
+	}
+
+	// Numbers
+	if n, ok := toFloat(value); ok {
+		if s.minimum != nil && n < *s.minimum {
+			report(fmt.Sprintf("value %v is less than minimum %v", value, *s.minimum))
+		}
+		if s.maximum != nil && n > *s.maximum {
+			report(fmt.Sprintf("value %v is greater than maximum %v", value, *s.maximum))
+		}
+	}
+
+	// Strings
+	if str, ok := value.(string); ok {
+		length := len([]rune(str))
+		if s.minLength != nil && length < *s.minLength {
+			report(fmt.Sprintf("length %d is less than minLength %d", length, *s.minLength))
+		}
+		if s.maxLength != nil && length > *s.maxLength {
+			report(fmt.Sprintf("length %d is greater than maxLength %d", length, *s.maxLength))
+		}
+		if s.pattern != nil && !s.pattern.MatchString(str) {
+			report("value does not match pattern " + s.pattern.String())
+		}
+	}
+
+	// Arrays
+	if arr, ok := value.(bson.A); ok {
+		if s.minItems != nil && len(arr) < *s.minItems {
+			report(fmt.Sprintf("%d items are less than minItems %d", len(arr), *s.minItems))
+		}
+		if s.maxItems != nil && len(arr) > *s.maxItems {
+			report(fmt.Sprintf("%d items are more than maxItems %d", len(arr), *s.maxItems))
+		}
+		if s.items != nil {
+			for i, item := range arr {
+				s.items.validate(joinPath(path, strconv.Itoa(i)), item, violations)
+			}
+		}
+	}
+
+	// Objects
+	if doc, ok := value.(bson.M); ok {
+		for _, name := range s.required {
+			if _, ok := doc[name]; !ok {
+				*violations = append(*violations, SchemaViolation{Path: joinPath(path, name), Message: "required field is missing"})
+			}
+		}
+		for _, name := range sortedKeys(doc) {
+			v := doc[name]
+			sub, ok := s.properties[name]
+			if ok {
+				sub.validate(joinPath(path, name), v, violations)
+				continue
+			}
+			if s.additionalProperties != nil && !*s.additionalProperties {
+				*violations = append(*violations, SchemaViolation{Path: joinPath(path, name), Message: "additional field is not allowed"})
+			}
+		}
+	}
+}
+
+// joinPath ...
+func joinPath(parent string, name string) string {
+	if parent == "" {
+		return name
+	}
+	return parent + "." + name
+}
+
+// matchesAnyType ...
+func matchesAnyType(value interface{}, types []string) bool {
+	for _, t := range types {
+		if matchesType(value, t) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchesType checks a decoded bson value against a JSON schema type or a mongodb bsonType alias
+func matchesType(value interface{}, t string) bool {
+	switch t {
+	case "object":
+		_, ok := value.(bson.M)
+		return ok
+	case "array":
+		_, ok := value.(bson.A)
+		return ok
+	case "string":
+		_, ok := value.(string)
+		return ok
+	case "bool", "boolean":
+		_, ok := value.(bool)
+		return ok
+	case "null":
+		return value == nil
+	case "number":
+		_, ok := toFloat(value)
+		if !ok {
+			_, ok = value.(primitive.Decimal128)
+		}
+		return ok
+	case "integer":
+		switch v := value.(type) {
+		case int32, int64:
+			return true
+		case float64:
+			return v == math.Trunc(v)
+		}
+		return false
+	case "int":
+		_, ok := value.(int32)
+		return ok
+	case "long":
+		_, ok := value.(int64)
+		return ok
+	case "double":
+		_, ok := value.(float64)
+		return ok
+	case "decimal":
+		_, ok := value.(primitive.Decimal128)
+		return ok
+	case "objectId":
+		_, ok := value.(primitive.ObjectID)
+		return ok
+	case "date":
+		_, ok := value.(primitive.DateTime)
+		return ok
+	case "timestamp":
+		_, ok := value.(primitive.Timestamp)
+		return ok
+	case "binData":
+		_, ok := value.(primitive.Binary)
+		return ok
+	case "regex":
+		_, ok := value.(primitive.Regex)
+		return ok
+	}
+	return false
+}
+
+// bsonTypeName returns the mongodb bsonType alias of a decoded value
+func bsonTypeName(value interface{}) string {
+	switch value.(type) {
+	case nil:
+		return "null"
+	case bson.M:
+		return "object"
+	case bson.A:
+		return "array"
+	case string:
+		return "string"
+	case bool:
+		return "bool"
+	case int32:
+		return "int"
+	case int64:
+		return "long"
+	case float64:
+		return "double"
+	case primitive.Decimal128:
+		return "decimal"
+	case primitive.ObjectID:
+		return "objectId"
+	case primitive.DateTime:
+		return "date"
+	case primitive.Timestamp:
+		return "timestamp"
+	case primitive.Binary:
+		return "binData"
+	case primitive.Regex:
+		return "regex"
+	}
+	return fmt.Sprintf("%T", value)
+}
+
+// toFloat converts numbers of any Go numeric kind, e.g. the int of bson.D{{Key: "email", Value: 1}}
+func toFloat(value interface{}) (float64, bool) {
+	switch v := value.(type) {
+	case int:
+		return float64(v), true
+	case int8:
+		return float64(v), true
+	case int16:
+		return float64(v), true
+	case int32:
+		return float64(v), true
+	case int64:
+		return float64(v), true
+	case uint:
+		return float64(v), true
+	case uint8:
+		return float64(v), true
+	case uint16:
+		return float64(v), true
+	case uint32:
+		return float64(v), true
+	case uint64:
+		return float64(v), true
+	case float32:
+		return float64(v), true
+	case float64:
+		return v, true
+	}
+	return 0, false
+}
+
+// inEnum compares numbers by value as JSON numbers are always decoded as float64
+func inEnum(value interface{}, enum []interface{}) bool {
+	n, isNumber := toFloat(value)
+	for _, e := range enum {
+		if f, ok := e.(float64); ok && isNumber {
+			if f == n {
+				return true
+			}
+			continue
+		}
+		if reflect.DeepEqual(value, e) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,95 @@
+package mongodb
+
+import (
+	"errors"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// Serializer converts an encoded payload into a BSON document
+type Serializer interface {
+	Serialize(data []byte) (bson.Raw, error)
+}
+
+// Built in serializers
+var (
+	BSONSerializer    Serializer = bsonSerializer{}    // Payload is already BSON, it is only validated
+	ExtJSONSerializer Serializer = extJSONSerializer{} // Payload is canonical or relaxed MongoDB extended JSON
+	MsgpackSerializer Serializer = msgpackSerializer{} // Payload is a msgpack map
+)
+
+// Encoded is a document given as an encoded payload instead of a Go value
+// It can be used anywhere a document is accepted (documents, queries, updates), it is converted to BSON through its serializer when sent
+type Encoded struct {
+	Serializer Serializer
+	Data       []byte
+}
+
+// ExtJSON returns a document given as MongoDB extended JSON
+func ExtJSON(data string) *Encoded {
+	return &Encoded{Serializer: ExtJSONSerializer, Data: []byte(data)}
+}
+
+// Msgpack returns a document given as msgpack bytes
+func Msgpack(data []byte) *Encoded {
+	return &Encoded{Serializer: MsgpackSerializer, Data: data}
+}
+
+// MarshalBSON implements bson.Marshaler
+func (e *Encoded) MarshalBSON() ([]byte, error) {
+	if e.Serializer == nil {
+		return nil, errors.New("encoded document has no serializer")
+	}
+	return e.Serializer.Serialize(e.Data)
+}
+
+// bsonSerializer ...
+type bsonSerializer struct{}
+
+// Serialize ...
+func (bsonSerializer) Serialize(data []byte) (bson.Raw, error) {
+	doc := bson.Raw(data)
+	err := doc.Validate()
+	if err != nil {
+		return nil, errors.New("invalid bson payload -> " + err.Error())
+	}
+	return doc, nil
+}
+
+// extJSONSerializer ...
+type extJSONSerializer struct{}
+
+// Serialize ...
+func (extJSONSerializer) Serialize(data []byte) (bson.Raw, error) {
+	// Decodes keeping field order & bson types
+	var doc bson.D
+	err := bson.UnmarshalExtJSON(data, false, &doc)
+	if err != nil {
+		return nil, errors.New("invalid extended json payload -> " + err.Error())
+	}
+
+	// Encodes
+	return bson.Marshal(doc)
+}
+
+// msgpackSerializer ...
+type msgpackSerializer struct{}
+
+// Serialize ...
+func (msgpackSerializer) Serialize(data []byte) (bson.Raw, error) {
+	// Decodes
+	value, rest, err := decodeMsgpack(data)
+	if err != nil {
+		return nil, errors.New("invalid msgpack payload -> " + err.Error())
+	}
+	if len(rest) != 0 {
+		return nil, errors.New("invalid msgpack payload -> trailing bytes after document")
+	}
+	doc, ok := value.(bson.D)
+	if !ok {
+		return nil, errors.New("invalid msgpack payload -> top level value must be a map")
+	}
+
+	// Encodes
+	return bson.Marshal(doc)
+}
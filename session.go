@@ -0,0 +1,46 @@
+package mongodb
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.mongodb.org/mongo-driver/mongo/readconcern"
+	"go.mongodb.org/mongo-driver/mongo/writeconcern"
+)
+
+// Session is a causally consistent session, reads run with its context see the writes run before them with it
+// even when served by a secondary, e.g. a sequence of reads-after-writes of a single HTTP request
+// A session is not safe for concurrent use
+type Session struct {
+	session mongo.Session
+}
+
+// StartSession starts a causally consistent session with majority read and write concerns, which causal guarantees require
+// End it once done, typically deferred
+func (c *Client) StartSession(opts ...*options.SessionOptions) (session *Session, err error) {
+	// Sets options
+	defaults := options.Session().
+		SetCausalConsistency(true).
+		SetDefaultReadConcern(readconcern.Majority()).
+		SetDefaultWriteConcern(writeconcern.New(writeconcern.WMajority()))
+
+	// Starts
+	s, err := c.client.StartSession(append([]*options.SessionOptions{defaults}, opts...)...)
+	if err != nil {
+		return nil, err
+	}
+
+	// Returns
+	return &Session{session: s}, nil
+}
+
+// Context returns ctx carrying the session, client operations run with it use the session
+func (s *Session) Context(ctx context.Context) context.Context {
+	return mongo.NewSessionContext(ctx, s.session)
+}
+
+// End ends the session, aborting its transaction in progress if any
+func (s *Session) End(ctx context.Context) {
+	s.session.EndSession(ctx)
+}
@@ -0,0 +1,60 @@
+package mongodb
+
+import (
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// SetDefaultSort sets the sort of the reads of the collection (ReadOne, Read, ReadWithProjection & raw variants), nil removes it
+// An _id tiebreaker in the direction of the last sort field is appended so equal sort values keep a stable order across pages
+func (c *Client) SetDefaultSort(collection string, sort bson.D) {
+	sort = withTiebreaker(sort)
+	c.configure(collection, func(s *collectionSettings) { s.defaultSort = sort })
+}
+
+// withTiebreaker appends _id to the sort unless it is already sorted on
+func withTiebreaker(sort bson.D) bson.D {
+	if len(sort) == 0 {
+		return nil
+	}
+	for _, e := range sort {
+		if e.Key == "_id" {
+			return sort
+		}
+	}
+	res := append(bson.D{}, sort...)
+	return append(res, bson.E{Key: "_id", Value: sortDirection(sort[len(sort)-1].Value)})
+}
+
+// sortDirection returns -1 for a descending sort value, else 1
+func sortDirection(value interface{}) int {
+	switch n := value.(type) {
+	case int:
+		if n < 0 {
+			return -1
+		}
+	default:
+		if f, ok := toFloat(n); ok && f < 0 {
+			return -1
+		}
+	}
+	return 1
+}
+
+// findOptions returns the find options applying the default sort of the collection
+func (c *Client) findOptions(collection string) *options.FindOptions {
+	opts := options.Find()
+	if s := c.settings(collection); s != nil && s.defaultSort != nil {
+		opts.SetSort(s.defaultSort)
+	}
+	return opts
+}
+
+// findOneOptions returns the find one options applying the default sort of the collection
+func (c *Client) findOneOptions(collection string) *options.FindOneOptions {
+	opts := options.FindOne()
+	if s := c.settings(collection); s != nil && s.defaultSort != nil {
+		opts.SetSort(s.defaultSort)
+	}
+	return opts
+}
@@ -0,0 +1,165 @@
+package mongodb
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"go.mongodb.org/mongo-driver/event"
+)
+
+// Number of latency histogram buckets, bucket i counts latencies below 2^i microseconds
+const latencyBuckets = 32
+
+// OperationStats summarises the commands of one kind sent for a collection
+type OperationStats struct {
+	Count       int64         // Commands sent
+	Errors      int64         // Commands failed or replied with write errors
+	MeanLatency time.Duration // Mean round trip time
+	MaxLatency  time.Duration // Slowest round trip time
+	P50Latency  time.Duration // Approximate median, the upper bound of its power of two bucket
+	P99Latency  time.Duration // Approximate 99th percentile, the upper bound of its power of two bucket
+}
+
+// operationCounter ...
+type operationCounter struct {
+	count     int64
+	errors    int64
+	total     time.Duration
+	max       time.Duration
+	histogram [latencyBuckets]int64
+}
+
+// startedCommand ...
+type startedCommand struct {
+	collection string
+	name       string
+}
+
+// statsCollector counts the commands of a client from the driver command monitor
+type statsCollector struct {
+	mu       sync.Mutex
+	started  map[int64]startedCommand
+	counters map[string]map[string]*operationCounter // By collection then command name
+}
+
+// newStatsCollector ...
+func newStatsCollector() *statsCollector {
+	return &statsCollector{started: map[int64]startedCommand{}, counters: map[string]map[string]*operationCounter{}}
+}
+
+// monitor returns the command monitor feeding the collector
+func (s *statsCollector) monitor() *event.CommandMonitor {
+	return &event.CommandMonitor{
+		Started: func(_ context.Context, e *event.CommandStartedEvent) {
+			// Commands name their collection in their first field, getMore in its collection field
+			collection := ""
+			if e.CommandName == "getMore" {
+				collection, _ = e.Command.Lookup("collection").StringValueOK()
+			} else if elem, err := e.Command.IndexErr(0); err == nil {
+				collection, _ = elem.Value().StringValueOK()
+			}
+			if collection == "" {
+				return
+			}
+			s.mu.Lock()
+			s.started[e.RequestID] = startedCommand{collection: collection, name: e.CommandName}
+			s.mu.Unlock()
+		},
+		Succeeded: func(_ context.Context, e *event.CommandSucceededEvent) {
+			_, writeErr := e.Reply.LookupErr("writeErrors")
+			_, concernErr := e.Reply.LookupErr("writeConcernError")
+			s.record(e.RequestID, time.Duration(e.DurationNanos), writeErr == nil || concernErr == nil)
+		},
+		Failed: func(_ context.Context, e *event.CommandFailedEvent) {
+			s.record(e.RequestID, time.Duration(e.DurationNanos), true)
+		},
+	}
+}
+
+// record ...
+func (s *statsCollector) record(requestID int64, latency time.Duration, failed bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	cmd, ok := s.started[requestID]
+	if !ok {
+		return
+	}
+	delete(s.started, requestID)
+
+	// Gets counter
+	byName, ok := s.counters[cmd.collection]
+	if !ok {
+		byName = map[string]*operationCounter{}
+		s.counters[cmd.collection] = byName
+	}
+	counter, ok := byName[cmd.name]
+	if !ok {
+		counter = &operationCounter{}
+		byName[cmd.name] = counter
+	}
+
+	// Counts
+	counter.count++
+	if failed {
+		counter.errors++
+	}
+	counter.total += latency
+	if latency > counter.max {
+		counter.max = latency
+	}
+	bucket := 0
+	for bucket < latencyBuckets-1 && latency >= time.Duration(1<<uint(bucket))*time.Microsecond {
+		bucket++
+	}
+	counter.histogram[bucket]++
+}
+
+// snapshot ...
+func (s *statsCollector) snapshot() map[string]map[string]OperationStats {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	res := make(map[string]map[string]OperationStats, len(s.counters))
+	for collection, byName := range s.counters {
+		res[collection] = make(map[string]OperationStats, len(byName))
+		for name, counter := range byName {
+			res[collection][name] = OperationStats{
+				Count:       counter.count,
+				Errors:      counter.errors,
+				MeanLatency: counter.total / time.Duration(counter.count),
+				MaxLatency:  counter.max,
+				P50Latency:  counter.percentile(0.50),
+				P99Latency:  counter.percentile(0.99),
+			}
+		}
+	}
+	return res
+}
+
+// percentile ...
+func (o *operationCounter) percentile(p float64) time.Duration {
+	rank := int64(p * float64(o.count))
+	var seen int64
+	for i, n := range o.histogram {
+		seen += n
+		if seen > rank {
+			bound := time.Duration(1<<uint(i)) * time.Microsecond
+			if bound > o.max {
+				return o.max
+			}
+			return bound
+		}
+	}
+	return o.max
+}
+
+// Stats returns the commands sent since startup by collection then command name (find, insert, update, getMore...)
+// Commands not targeting a collection, such as ping or listCollections, are not counted
+func (c *Client) Stats() map[string]map[string]OperationStats {
+	if c.stats == nil {
+		return map[string]map[string]OperationStats{}
+	}
+	return c.stats.snapshot()
+}
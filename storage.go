@@ -0,0 +1,159 @@
+package mongodb
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// Default storage advisor thresholds
+const (
+	defaultFragmentationThreshold = 0.3
+	defaultIndexRatioThreshold    = 1.0
+)
+
+// StorageOptions sets the thresholds of the storage advisor
+type StorageOptions struct {
+	FragmentationThreshold float64 // Share of the collection file free for reuse above which a collection is flagged, compact reclaims it. (default is 0.3)
+	IndexRatioThreshold    float64 // Ratio of index size to data size above which indexes are flagged as oversized. (default is 1.0)
+}
+
+// CollectionStorage is the storage usage of a collection, sizes are in bytes
+type CollectionStorage struct {
+	Collection      string           `json:"collection"`
+	Documents       int64            `json:"documents"`
+	DataSize        int64            `json:"dataSize"`        // Uncompressed size of the documents
+	StorageSize     int64            `json:"storageSize"`     // Size of the collection file on disk
+	FreeStorageSize int64            `json:"freeStorageSize"` // Bytes of the collection file available for reuse
+	IndexSize       int64            `json:"indexSize"`
+	IndexSizes      map[string]int64 `json:"indexSizes"`
+	Fragmentation   float64          `json:"fragmentation"` // FreeStorageSize / StorageSize
+	Warnings        []string         `json:"warnings,omitempty"`
+}
+
+// StorageReport is the storage usage of every collection of the database
+type StorageReport struct {
+	Database           string              `json:"database"`
+	StorageSize        int64               `json:"storageSize"`
+	IndexSize          int64               `json:"indexSize"`
+	Collections        []CollectionStorage `json:"collections"` // Ordered by storage size, largest first
+	FlaggedCollections []string            `json:"flaggedCollections,omitempty"`
+}
+
+// JSON returns the report as JSON for dashboards
+func (r *StorageReport) JSON() ([]byte, error) {
+	return json.Marshal(r)
+}
+
+// StorageReport combines collection and index sizes of all collections and flags high fragmentation and oversized indexes
+func (c *Client) StorageReport(ctx context.Context, opts *StorageOptions) (report *StorageReport, err error) {
+	if opts == nil {
+		opts = &StorageOptions{}
+	}
+	fragmentationThreshold := opts.FragmentationThreshold
+	if fragmentationThreshold <= 0 {
+		fragmentationThreshold = defaultFragmentationThreshold
+	}
+	indexRatioThreshold := opts.IndexRatioThreshold
+	if indexRatioThreshold <= 0 {
+		indexRatioThreshold = defaultIndexRatioThreshold
+	}
+
+	// Lists collections
+	collections, err := c.database.ListCollectionNames(ctx, bson.D{{Key: "type", Value: "collection"}})
+	if err != nil {
+		return nil, err
+	}
+
+	// Collects stats
+	report = &StorageReport{Database: c.database.Name()}
+	for _, collection := range collections {
+		stats, err := c.collectionStorage(ctx, collection)
+		if err != nil {
+			return nil, err
+		}
+
+		// Advises
+		if stats.StorageSize > 0 {
+			stats.Fragmentation = float64(stats.FreeStorageSize) / float64(stats.StorageSize)
+		}
+		if stats.Fragmentation > fragmentationThreshold {
+			stats.Warnings = append(stats.Warnings, fmt.Sprintf("%.0f%% of the collection file is free for reuse, consider compact", stats.Fragmentation*100))
+		}
+		if stats.DataSize > 0 && float64(stats.IndexSize) > float64(stats.DataSize)*indexRatioThreshold {
+			stats.Warnings = append(stats.Warnings, fmt.Sprintf("indexes are %.1fx the data size, check for unused or redundant indexes", float64(stats.IndexSize)/float64(stats.DataSize)))
+		}
+		if len(stats.Warnings) != 0 {
+			report.FlaggedCollections = append(report.FlaggedCollections, collection)
+		}
+
+		report.StorageSize += stats.StorageSize
+		report.IndexSize += stats.IndexSize
+		report.Collections = append(report.Collections, *stats)
+	}
+	sort.Slice(report.Collections, func(i, j int) bool {
+		return report.Collections[i].StorageSize > report.Collections[j].StorageSize
+	})
+	sort.Strings(report.FlaggedCollections)
+
+	// Returns
+	return report, nil
+}
+
+// collectionStorage reads the storage stats of a collection using $collStats, summing shards
+func (c *Client) collectionStorage(ctx context.Context, collection string) (stats *CollectionStorage, err error) {
+	// Hits DB
+	pipeline := mongo.Pipeline{{{Key: "$collStats", Value: bson.D{{Key: "storageStats", Value: bson.D{}}}}}}
+	cursor, err := c.collection(ctx, collection).Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+
+	// Close connection at the last
+	defer cursor.Close(ctx)
+
+	// Binds cursor response
+	var docs []bson.M
+	err = cursor.All(ctx, &docs)
+	if err != nil {
+		return nil, err
+	}
+
+	// Sums shards
+	stats = &CollectionStorage{Collection: collection, IndexSizes: map[string]int64{}}
+	for _, doc := range docs {
+		storage, _ := doc["storageStats"].(bson.M)
+		stats.Documents += statInt(storage["count"])
+		stats.DataSize += statInt(storage["size"])
+		stats.StorageSize += statInt(storage["storageSize"])
+		stats.IndexSize += statInt(storage["totalIndexSize"])
+
+		// Free space, reported by 4.4+ servers or by wiredTiger block manager
+		if free, ok := storage["freeStorageSize"]; ok {
+			stats.FreeStorageSize += statInt(free)
+		} else if wt, ok := storage["wiredTiger"].(bson.M); ok {
+			if bm, ok := wt["block-manager"].(bson.M); ok {
+				stats.FreeStorageSize += statInt(bm["file bytes available for reuse"])
+			}
+		}
+
+		if sizes, ok := storage["indexSizes"].(bson.M); ok {
+			for name, size := range sizes {
+				stats.IndexSizes[name] += statInt(size)
+			}
+		}
+	}
+
+	// Returns
+	return stats, nil
+}
+
+// statInt reads a numeric stat whatever its bson number type
+func statInt(v interface{}) int64 {
+	n, _ := toFloat(v)
+	return int64(n)
+}
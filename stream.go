@@ -0,0 +1,223 @@
+package mongodb
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// Server error code of an unknown cursor id
+const cursorNotFoundCode = 43
+
+// Default server wait for new documents on a tailable cursor getMore
+const defaultMaxAwaitTime = time.Second
+
+// CursorResumableError is returned by a stream when its server cursor was lost (killed, timed out or dead)
+// The stream can be re-established from the documents after LastID
+type CursorResumableError struct {
+	LastID interface{} // The _id of the last document returned, nil when none was returned
+	Err    error       // Underlying cursor error, nil for a dead cursor
+}
+
+// Error ...
+func (e *CursorResumableError) Error() string {
+	if e.Err == nil {
+		return "cursor closed by the server, resume after the last document"
+	}
+	return "cursor lost, resume after the last document -> " + e.Err.Error()
+}
+
+// Unwrap ...
+func (e *CursorResumableError) Unwrap() error {
+	return e.Err
+}
+
+// TailOptions controls a tailable cursor
+type TailOptions struct {
+	MaxAwaitTime int // In milliseconds, How long each getMore waits on the server for new documents. (default is 1 second)
+	PollInterval int // In milliseconds, Pause between getMores when no document arrived, limits the getMore cadence. (default is 0)
+}
+
+// Stream iterates query results one document at a time
+type Stream struct {
+	client       *Client // Applies the read rules of the collection on Decode, nil for tailable streams
+	collection   string
+	cursor       *mongo.Cursor
+	tailable     bool
+	pollInterval time.Duration
+	lastID       interface{}
+	err          error
+}
+
+// ReadStream iterates the documents matching the query without loading them all in memory, documents are fetched batch by batch
+// Close the stream once done, e.g. defer stream.Close(ctx), so the server cursor is released
+func (c *Client) ReadStream(ctx context.Context, collection string, query interface{}, opts ...*options.FindOptions) (stream *Stream, err error) {
+	// Checks tenant quota
+	err = c.checkQuota(ctx, false)
+	if err != nil {
+		return nil, err
+	}
+
+	// Checks strict mode
+	err = c.checkFind(ctx, collection, query, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	// Hits DB
+	coll, done := c.readCollection(ctx, collection)
+	defer func() { done(err) }()
+	cursor, err := coll.Find(ctx, query, append([]*options.FindOptions{c.findOptions(collection)}, opts...)...)
+	if err != nil {
+		return nil, err
+	}
+
+	// Returns
+	return &Stream{client: c, collection: collection, cursor: cursor}, nil
+}
+
+// ReadTailable tails a capped collection, Next blocks until a new document matching the query is inserted
+// A lost server cursor is reported as a *CursorResumableError, a new tail can resume with {_id: {$gt: LastID}}
+func (c *Client) ReadTailable(ctx context.Context, collection string, query interface{}, opts *TailOptions) (stream *Stream, err error) {
+	if opts == nil {
+		opts = &TailOptions{}
+	}
+	maxAwait := defaultMaxAwaitTime
+	if opts.MaxAwaitTime > 0 {
+		maxAwait = time.Duration(opts.MaxAwaitTime) * time.Millisecond
+	}
+
+	// Hits DB
+	findOpts := options.Find().SetCursorType(options.TailableAwait).SetMaxAwaitTime(maxAwait)
+	cursor, err := c.collection(ctx, collection).Find(ctx, query, findOpts)
+	if err != nil {
+		return nil, err
+	}
+
+	// Returns
+	return &Stream{
+		cursor:       cursor,
+		tailable:     true,
+		pollInterval: time.Duration(opts.PollInterval) * time.Millisecond,
+	}, nil
+}
+
+// Next moves to the next document, it returns false at the end of results or on error
+// On a tailable stream it waits for new documents until ctx is done
+func (s *Stream) Next(ctx context.Context) bool {
+	if s.err != nil {
+		return false
+	}
+
+	// Reads plain cursor
+	if !s.tailable {
+		if s.cursor.Next(ctx) {
+			s.track()
+			return true
+		}
+		s.err = resumableCursorError(s.cursor.Err(), s.lastID)
+		return false
+	}
+
+	// Polls tailable cursor, every empty TryNext is a getMore waiting MaxAwaitTime on the server
+	for {
+		if s.cursor.TryNext(ctx) {
+			s.track()
+			return true
+		}
+		err := s.cursor.Err()
+		if err != nil {
+			s.err = resumableCursorError(err, s.lastID)
+			return false
+		}
+		if s.cursor.ID() == 0 {
+			s.err = &CursorResumableError{LastID: s.lastID}
+			return false
+		}
+
+		// Waits before the next getMore
+		if s.pollInterval > 0 {
+			select {
+			case <-ctx.Done():
+				s.err = ctx.Err()
+				return false
+			case <-time.After(s.pollInterval):
+			}
+		}
+		if ctx.Err() != nil {
+			s.err = ctx.Err()
+			return false
+		}
+	}
+}
+
+// track remembers the _id of the current document for resumption
+func (s *Stream) track() {
+	if id, err := s.cursor.Current.LookupErr("_id"); err == nil {
+		var value interface{}
+		if id.Unmarshal(&value) == nil {
+			s.lastID = value
+		}
+	}
+}
+
+// Decode decodes the current document into v, checksums are verified and compressed fields restored as Read does
+func (s *Stream) Decode(v interface{}) (err error) {
+	if s.client == nil {
+		return s.cursor.Decode(v)
+	}
+	settings := s.client.settings(s.collection)
+	if settings == nil || (settings.checksum == nil && settings.compression == nil) {
+		return s.cursor.Decode(v)
+	}
+
+	// Decodes document
+	var doc interface{}
+	err = s.cursor.Decode(&doc)
+	if err != nil {
+		return err
+	}
+
+	// Verifies checksums
+	err = s.client.verifyChecksums(s.collection, doc)
+	if err != nil {
+		return err
+	}
+
+	// Decompresses fields
+	err = s.client.decompressResults(s.collection, doc)
+	if err != nil {
+		return err
+	}
+
+	// Returns
+	return s.client.decodeInto(doc, v)
+}
+
+// Current returns the current document, valid until the next call to Next
+func (s *Stream) Current() bson.Raw {
+	return s.cursor.Current
+}
+
+// Err returns the error which stopped the iteration, if any
+func (s *Stream) Err() error {
+	return s.err
+}
+
+// Close closes the server cursor
+func (s *Stream) Close(ctx context.Context) error {
+	return s.cursor.Close(ctx)
+}
+
+// resumableCursorError wraps cursor not found errors into a *CursorResumableError
+func resumableCursorError(err error, lastID interface{}) error {
+	var serverErr mongo.ServerError
+	if errors.As(err, &serverErr) && serverErr.HasErrorCode(cursorNotFoundCode) {
+		return &CursorResumableError{LastID: lastID, Err: err}
+	}
+	return err
+}
@@ -0,0 +1,233 @@
+package mongodb
+
+import (
+	"errors"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/bsontype"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// StrictDecodeError is returned by strict decoding when documents and the destination struct do not match
+type StrictDecodeError struct {
+	UnknownFields  []string // Document fields having no struct field, e.g. stale struct definitions
+	UnmappedFields []string // Projected fields having no struct field, e.g. typos in projections
+}
+
+// Error ...
+func (e *StrictDecodeError) Error() string {
+	msgs := []string{}
+	if len(e.UnknownFields) != 0 {
+		msgs = append(msgs, "document fields not in struct: "+strings.Join(e.UnknownFields, ", "))
+	}
+	if len(e.UnmappedFields) != 0 {
+		msgs = append(msgs, "projected fields not in struct: "+strings.Join(e.UnmappedFields, ", "))
+	}
+	return "strict decode failed -> " + strings.Join(msgs, "; ")
+}
+
+// DecodeStrict decodes the document into out, a pointer to a struct, failing when the document has fields the struct does not declare
+// When an inclusion projection is given, projected fields missing from the struct are reported too
+func DecodeStrict(doc bson.Raw, out interface{}, projection interface{}) (err error) {
+	// Validates destination
+	v := reflect.ValueOf(out)
+	if v.Kind() != reflect.Ptr || v.IsNil() || v.Elem().Kind() != reflect.Struct {
+		return errors.New("strict decode destination must be a pointer to a struct")
+	}
+	t := v.Elem().Type()
+
+	// Checks fields
+	strictErr := &StrictDecodeError{}
+	strictErr.UnknownFields = unknownFields("", doc, t)
+
+	// Checks projection
+	if projection != nil {
+		fields, err := projectedFields(projection)
+		if err != nil {
+			return err
+		}
+		for _, path := range fields {
+			if !structHasPath(t, strings.Split(path, ".")) {
+				strictErr.UnmappedFields = append(strictErr.UnmappedFields, path)
+			}
+		}
+	}
+	if len(strictErr.UnknownFields) != 0 || len(strictErr.UnmappedFields) != 0 {
+		return strictErr
+	}
+
+	// Decodes
+	return bson.Unmarshal(doc, out)
+}
+
+// bsonFields returns the bson keys of a struct, inline structs are flattened
+// The returned flag reports an inline map which accepts any key
+func bsonFields(t reflect.Type) (fields map[string]reflect.Type, anyKey bool) {
+	fields = map[string]reflect.Type{}
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if f.PkgPath != "" && !f.Anonymous {
+			continue
+		}
+
+		// Parses tag
+		tag, ok := f.Tag.Lookup("bson")
+		if !ok && !strings.Contains(string(f.Tag), ":") {
+			tag = string(f.Tag)
+		}
+		if tag == "-" {
+			continue
+		}
+		parts := strings.Split(tag, ",")
+		name := parts[0]
+		inline := false
+		for _, opt := range parts[1:] {
+			if opt == "inline" {
+				inline = true
+			}
+		}
+
+		// Flattens inline
+		if inline {
+			ft := indirectType(f.Type)
+			switch ft.Kind() {
+			case reflect.Map:
+				anyKey = true
+			case reflect.Struct:
+				inner, innerAny := bsonFields(ft)
+				for k, v := range inner {
+					fields[k] = v
+				}
+				anyKey = anyKey || innerAny
+			}
+			continue
+		}
+		if f.PkgPath != "" {
+			continue
+		}
+		if name == "" {
+			name = strings.ToLower(f.Name)
+		}
+		fields[name] = f.Type
+	}
+	return fields, anyKey
+}
+
+// unknownFields returns the document paths not declared by the struct type
+func unknownFields(prefix string, doc bson.Raw, t reflect.Type) (unknown []string) {
+	fields, anyKey := bsonFields(t)
+	elements, err := doc.Elements()
+	if err != nil {
+		return nil
+	}
+	for _, e := range elements {
+		path := joinPath(prefix, e.Key())
+		f, ok := fields[e.Key()]
+		if !ok {
+			if !anyKey {
+				unknown = append(unknown, path)
+			}
+			continue
+		}
+		unknown = append(unknown, unknownNested(path, e.Value(), f)...)
+	}
+	return unknown
+}
+
+// unknownNested checks embedded documents and arrays of embedded documents decoded into structs
+func unknownNested(path string, value bson.RawValue, t reflect.Type) (unknown []string) {
+	t = indirectType(t)
+	switch value.Type {
+	case bsontype.EmbeddedDocument:
+		if isPlainStruct(t) {
+			return unknownFields(path, value.Document(), t)
+		}
+	case bsontype.Array:
+		if t.Kind() != reflect.Slice && t.Kind() != reflect.Array {
+			return nil
+		}
+		values, err := value.Array().Values()
+		if err != nil {
+			return nil
+		}
+		for i, item := range values {
+			unknown = append(unknown, unknownNested(joinPath(path, strconv.Itoa(i)), item, t.Elem())...)
+		}
+	}
+	return unknown
+}
+
+// structHasPath reports whether a dotted projection path resolves to a struct field
+func structHasPath(t reflect.Type, path []string) bool {
+	t = indirectType(t)
+	if t.Kind() == reflect.Slice || t.Kind() == reflect.Array {
+		t = indirectType(t.Elem())
+	}
+	if !isPlainStruct(t) {
+		return true
+	}
+	fields, anyKey := bsonFields(t)
+	f, ok := fields[path[0]]
+	if !ok {
+		return anyKey
+	}
+	if len(path) == 1 {
+		return true
+	}
+	return structHasPath(f, path[1:])
+}
+
+// projectedFields returns the included paths of a projection, exclusions and _id are ignored
+func projectedFields(projection interface{}) (fields []string, err error) {
+	doc, err := toMap(projection)
+	if err != nil {
+		return nil, err
+	}
+	for _, key := range sortedKeys(doc) {
+		if key == "_id" {
+			continue
+		}
+		switch v := doc[key].(type) {
+		case bool:
+			if v {
+				fields = append(fields, key)
+			}
+		case int32, int64, float64:
+			if n, _ := toFloat(v); n != 0 {
+				fields = append(fields, key)
+			}
+		default:
+			// Operators like $slice or $elemMatch still return the field
+			fields = append(fields, key)
+		}
+	}
+	return fields, nil
+}
+
+// indirectType ...
+func indirectType(t reflect.Type) reflect.Type {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	return t
+}
+
+// isPlainStruct reports whether values of the type are decoded field by field
+func isPlainStruct(t reflect.Type) bool {
+	if t.Kind() != reflect.Struct {
+		return false
+	}
+	switch t {
+	case reflect.TypeOf(time.Time{}), reflect.TypeOf(primitive.DateTime(0)), reflect.TypeOf(primitive.Decimal128{}),
+		reflect.TypeOf(primitive.Binary{}), reflect.TypeOf(primitive.Timestamp{}), reflect.TypeOf(primitive.Regex{}):
+		return false
+	}
+	unmarshaler := reflect.TypeOf((*bson.Unmarshaler)(nil)).Elem()
+	valueUnmarshaler := reflect.TypeOf((*bson.ValueUnmarshaler)(nil)).Elem()
+	ptr := reflect.PtrTo(t)
+	return !ptr.Implements(unmarshaler) && !ptr.Implements(valueUnmarshaler)
+}
@@ -0,0 +1,185 @@
+package mongodb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// collectionScanKey is the context key of reads allowed to scan collections
+type collectionScanKey struct{}
+
+// StrictQueries rejects reads the server would answer with a collection scan
+type StrictQueries struct {
+	LargeCollection int64    // Estimated documents above which collection scans are rejected. (default is 0, every collection scan is rejected)
+	Allowed         []string // Collections which may be scanned, e.g. small lookup collections (default is nil)
+}
+
+// CollectionScanError is returned in strict mode when a read would scan a large collection
+type CollectionScanError struct {
+	Collection string
+	Shape      string // Query shape, values replaced by "?"
+	Documents  int64  // Estimated documents of the collection
+}
+
+// Error ...
+func (e *CollectionScanError) Error() string {
+	return fmt.Sprintf("query %s on %s (~%d documents) is a collection scan, add an index or read with a WithCollectionScan context", e.Shape, e.Collection, e.Documents)
+}
+
+// queryGuard caches the verdict of the query shapes it explained
+type queryGuard struct {
+	config  *StrictQueries
+	allowed map[string]bool
+	counts  collectionCounts
+	mu      sync.Mutex
+	scans   map[string]bool // Whether the shape is a collection scan, by collection and shape
+}
+
+// newQueryGuard ...
+func newQueryGuard(config *StrictQueries) *queryGuard {
+	g := &queryGuard{config: config, allowed: map[string]bool{}, scans: map[string]bool{}}
+	for _, collection := range config.Allowed {
+		g.allowed[collection] = true
+	}
+	return g
+}
+
+// WithCollectionScan allows the reads run with the returned context to scan collections in strict mode, e.g. for batch jobs
+func WithCollectionScan(ctx context.Context) context.Context {
+	return context.WithValue(ctx, collectionScanKey{}, true)
+}
+
+// checkFind rejects a find the server would answer with a collection scan of a large collection
+// Each query shape is explained once, reads in transactions are not checked as explain can not run in them
+func (c *Client) checkFind(ctx context.Context, collection string, query interface{}, opts []*options.FindOptions) (err error) {
+	g := c.queryGuard
+	if g == nil || g.allowed[collection] || inTransaction(ctx) {
+		return nil
+	}
+	if allowed, _ := ctx.Value(collectionScanKey{}).(bool); allowed {
+		return nil
+	}
+
+	// Checks cache
+	findOpts := options.MergeFindOptions(append([]*options.FindOptions{c.findOptions(collection)}, opts...)...)
+	shape, err := findShape(query, findOpts)
+	if err != nil {
+		return err
+	}
+	key := collection + " " + shape
+	g.mu.Lock()
+	scan, ok := g.scans[key]
+	g.mu.Unlock()
+
+	// Explains
+	if !ok {
+		cost, err := c.EstimateQueryCost(ctx, collection, query, opts...)
+		if err != nil {
+			return errors.New("strict query check -> " + err.Error())
+		}
+		scan = cost.Verdict == CostCollectionScan
+		g.mu.Lock()
+		g.scans[key] = scan
+		g.mu.Unlock()
+	}
+	if !scan {
+		return nil
+	}
+
+	// Checks size
+	count, err := g.counts.count(ctx, c.collection(ctx, collection))
+	if err != nil {
+		return err
+	}
+	if count > g.config.LargeCollection {
+		return &CollectionScanError{Collection: collection, Shape: shape, Documents: count}
+	}
+
+	// Returns
+	return nil
+}
+
+// checkFindOne ...
+func (c *Client) checkFindOne(ctx context.Context, collection string, query interface{}, opts []*options.FindOneOptions) (err error) {
+	if c.queryGuard == nil {
+		return nil
+	}
+	findOpts := options.Find().SetLimit(1)
+	for _, opt := range opts {
+		if opt == nil {
+			continue
+		}
+		if opt.Sort != nil {
+			findOpts.SetSort(opt.Sort)
+		}
+		if opt.Hint != nil {
+			findOpts.SetHint(opt.Hint)
+		}
+		if opt.Collation != nil {
+			findOpts.SetCollation(opt.Collation)
+		}
+	}
+	return c.checkFind(ctx, collection, query, []*options.FindOptions{findOpts})
+}
+
+// findShape identifies the plan of a find: its filter with values replaced by "?", its sort and its hint
+func findShape(query interface{}, opts *options.FindOptions) (shape string, err error) {
+	filter := bson.D{}
+	if query != nil {
+		filter, err = toDoc(query)
+		if err != nil {
+			return "", err
+		}
+	}
+	parts := []string{}
+	for _, value := range []interface{}{filterShape(filter), opts.Sort, opts.Hint} {
+		if value == nil {
+			continue
+		}
+		data, err := bson.MarshalExtJSON(bson.D{{Key: "v", Value: value}}, false, false)
+		if err != nil {
+			return "", err
+		}
+		parts = append(parts, string(data[len(`{"v":`):len(data)-1]))
+	}
+	return strings.Join(parts, " "), nil
+}
+
+// filterShape replaces the values of the filter by "?", keeping fields and operators
+// Keys are sorted as filters built from maps have no stable order
+func filterShape(filter bson.D) bson.D {
+	shape := make(bson.D, len(filter))
+	for i, e := range filter {
+		shape[i] = bson.E{Key: e.Key, Value: valueShape(e.Key, e.Value)}
+	}
+	sort.Slice(shape, func(i, j int) bool { return shape[i].Key < shape[j].Key })
+	return shape
+}
+
+// valueShape ...
+func valueShape(key string, value interface{}) interface{} {
+	switch v := value.(type) {
+	case bson.D:
+		// Operators and $elemMatch or $not clauses keep their shape, documents matched as a whole do not
+		if len(v) != 0 && (strings.HasPrefix(v[0].Key, "$") || strings.HasPrefix(key, "$")) {
+			return filterShape(v)
+		}
+	case bson.A:
+		// Clauses of $and, $or and $nor
+		if key == "$and" || key == "$or" || key == "$nor" {
+			clauses := make(bson.A, len(v))
+			for i, clause := range v {
+				clauses[i] = valueShape(key, clause)
+			}
+			return clauses
+		}
+	}
+	return "?"
+}
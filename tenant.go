@@ -0,0 +1,186 @@
+package mongodb
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/event"
+)
+
+// Default interval of the tenant usage reports
+const defaultTenantUsageInterval = time.Minute
+
+// tenantKey is the context key of the tenant of operations
+type tenantKey struct{}
+
+// TenantUsage is the usage of a tenant since startup
+type TenantUsage struct {
+	Operations       int64 // Commands sent, getMore included
+	DocumentsRead    int64 // Documents returned by find, aggregate and getMore
+	DocumentsWritten int64 // Documents inserted, modified, upserted or deleted
+	BytesSent        int64 // BSON size of the commands
+	BytesReceived    int64 // BSON size of the replies
+}
+
+// tenantMeter counts the usage of tenants from the driver command monitor
+type tenantMeter struct {
+	mu    sync.Mutex
+	usage map[string]*TenantUsage
+}
+
+// WithTenant runs the operations of the returned context on behalf of the tenant, they are metered when Config.TenantMetering is set
+func WithTenant(ctx context.Context, tenant string) context.Context {
+	return context.WithValue(ctx, tenantKey{}, tenant)
+}
+
+// TenantFromContext returns the tenant set by WithTenant
+func TenantFromContext(ctx context.Context) (tenant string, ok bool) {
+	tenant, ok = ctx.Value(tenantKey{}).(string)
+	return tenant, ok
+}
+
+// newTenantMeter ...
+func newTenantMeter() *tenantMeter {
+	return &tenantMeter{usage: map[string]*TenantUsage{}}
+}
+
+// monitor returns the command monitor feeding the meter, commands run without tenant are not metered
+func (m *tenantMeter) monitor() *event.CommandMonitor {
+	return &event.CommandMonitor{
+		Started: func(ctx context.Context, e *event.CommandStartedEvent) {
+			tenant, ok := TenantFromContext(ctx)
+			if !ok {
+				return
+			}
+			m.add(tenant, func(u *TenantUsage) {
+				u.Operations++
+				u.BytesSent += int64(len(e.Command))
+			})
+		},
+		Succeeded: func(ctx context.Context, e *event.CommandSucceededEvent) {
+			tenant, ok := TenantFromContext(ctx)
+			if !ok {
+				return
+			}
+			read, written := replyDocuments(e.CommandName, e.Reply)
+			m.add(tenant, func(u *TenantUsage) {
+				u.BytesReceived += int64(len(e.Reply))
+				u.DocumentsRead += read
+				u.DocumentsWritten += written
+			})
+		},
+	}
+}
+
+// add ...
+func (m *tenantMeter) add(tenant string, update func(u *TenantUsage)) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	u, ok := m.usage[tenant]
+	if !ok {
+		u = &TenantUsage{}
+		m.usage[tenant] = u
+	}
+	update(u)
+}
+
+// snapshot ...
+func (m *tenantMeter) snapshot() map[string]TenantUsage {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	res := make(map[string]TenantUsage, len(m.usage))
+	for tenant, u := range m.usage {
+		res[tenant] = *u
+	}
+	return res
+}
+
+// replyDocuments returns the documents a command reply returned and the documents it wrote
+func replyDocuments(name string, reply bson.Raw) (read int64, written int64) {
+	switch name {
+	case "find", "aggregate", "getMore":
+		for _, batch := range []string{"firstBatch", "nextBatch"} {
+			if docs, err := reply.LookupErr("cursor", batch); err == nil {
+				values, _ := docs.Array().Values()
+				read += int64(len(values))
+			}
+		}
+	case "insert", "delete":
+		n, _ := reply.Lookup("n").AsInt64OK()
+		written = n
+	case "update":
+		n, _ := reply.Lookup("nModified").AsInt64OK()
+		written = n
+		if upserted, err := reply.LookupErr("upserted"); err == nil {
+			values, _ := upserted.Array().Values()
+			written += int64(len(values))
+		}
+	case "findAndModify":
+		n, _ := reply.Lookup("lastErrorObject", "n").AsInt64OK()
+		written = n
+		if value, err := reply.LookupErr("value"); err == nil && value.Type == bson.TypeEmbeddedDocument {
+			read = 1
+		}
+	}
+	return read, written
+}
+
+// TenantUsage returns the usage of every tenant since startup, e.g. for billing or fair use checks
+// Only operations run with a WithTenant context are metered, metering requires Config.TenantMetering
+func (c *Client) TenantUsage() map[string]TenantUsage {
+	if c.tenantMeter == nil {
+		return map[string]TenantUsage{}
+	}
+	return c.tenantMeter.snapshot()
+}
+
+// StartTenantUsageReporter reports the usage of every tenant to the sink periodically until stop is called
+// Gauges are mongodb.tenant.{operations,documents.read,documents.written,bytes.sent,bytes.received} tagged by tenant, totals since startup
+// Interval is in milliseconds (default is 1 minute)
+func (c *Client) StartTenantUsageReporter(sink MetricsSink, interval int) (stop func(), err error) {
+	if c.tenantMeter == nil {
+		return nil, errors.New("tenant usage reporter requires Config.TenantMetering")
+	}
+	if sink == nil {
+		return nil, errors.New("tenant usage reporter requires a sink")
+	}
+	every := defaultTenantUsageInterval
+	if interval != 0 {
+		every = time.Duration(interval) * time.Millisecond
+	}
+
+	// Runs reports
+	stopCh, done := make(chan struct{}), make(chan struct{})
+	go func() {
+		defer close(done)
+		ticker := time.NewTicker(every)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-stopCh:
+				return
+			case <-ticker.C:
+			}
+			for tenant, u := range c.tenantMeter.snapshot() {
+				tags := map[string]string{"tenant": tenant}
+				safeCall(c.panicHandler, "MetricsSink.Gauge", func() {
+					sink.Gauge("mongodb.tenant.operations", float64(u.Operations), tags)
+					sink.Gauge("mongodb.tenant.documents.read", float64(u.DocumentsRead), tags)
+					sink.Gauge("mongodb.tenant.documents.written", float64(u.DocumentsWritten), tags)
+					sink.Gauge("mongodb.tenant.bytes.sent", float64(u.BytesSent), tags)
+					sink.Gauge("mongodb.tenant.bytes.received", float64(u.BytesReceived), tags)
+				})
+			}
+		}
+	}()
+
+	// Returns, stop may be called several times
+	var once sync.Once
+	return func() {
+		once.Do(func() { close(stopCh) })
+		<-done
+	}, nil
+}
@@ -0,0 +1,104 @@
+package mongodb
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/bsoncodec"
+	"go.mongodb.org/mongo-driver/bson/bsonrw"
+	"go.mongodb.org/mongo-driver/bson/bsontype"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Layout of Date strings
+const dateLayout = "2006-01-02"
+
+// timeZoneRegistry returns a registry decoding time.Time values in the given location
+// Times are always stored as UTC by the driver, only decoding is affected
+func timeZoneRegistry(loc *time.Location) *bsoncodec.Registry {
+	base := bsoncodec.NewTimeCodec()
+	decoder := func(dc bsoncodec.DecodeContext, vr bsonrw.ValueReader, val reflect.Value) error {
+		err := base.DecodeValue(dc, vr, val)
+		if err != nil {
+			return err
+		}
+		val.Set(reflect.ValueOf(val.Interface().(time.Time).In(loc)))
+		return nil
+	}
+	return bson.NewRegistryBuilder().
+		RegisterTypeDecoder(reflect.TypeOf(time.Time{}), bsoncodec.ValueDecoderFunc(decoder)).
+		Build()
+}
+
+// Date is a calendar date without a time of day, e.g. a birth date
+// It is stored as midnight UTC of the date so it never shifts a day when read in another time zone
+type Date struct {
+	Year  int
+	Month time.Month
+	Day   int
+}
+
+// NewDate ...
+func NewDate(year int, month time.Month, day int) Date {
+	// Normalises overflowing days & months
+	return DateOf(time.Date(year, month, day, 0, 0, 0, 0, time.UTC))
+}
+
+// DateOf returns the calendar date of the time in its own location
+func DateOf(t time.Time) Date {
+	year, month, day := t.Date()
+	return Date{Year: year, Month: month, Day: day}
+}
+
+// ParseDate parses a YYYY-MM-DD date
+func ParseDate(value string) (d Date, err error) {
+	t, err := time.Parse(dateLayout, value)
+	if err != nil {
+		return Date{}, err
+	}
+	return DateOf(t), nil
+}
+
+// IsZero ...
+func (d Date) IsZero() bool {
+	return d == Date{}
+}
+
+// In returns the midnight starting the date in the location
+func (d Date) In(loc *time.Location) time.Time {
+	return time.Date(d.Year, d.Month, d.Day, 0, 0, 0, 0, loc)
+}
+
+// String returns the date as YYYY-MM-DD
+func (d Date) String() string {
+	return fmt.Sprintf("%04d-%02d-%02d", d.Year, int(d.Month), d.Day)
+}
+
+// MarshalBSONValue stores the date as midnight UTC, a zero date as null
+func (d Date) MarshalBSONValue() (bsontype.Type, []byte, error) {
+	if d.IsZero() {
+		return bsontype.Null, nil, nil
+	}
+	return bson.MarshalValue(primitive.NewDateTimeFromTime(d.In(time.UTC)))
+}
+
+// UnmarshalBSONValue reads dates stored as date times or YYYY-MM-DD strings
+func (d *Date) UnmarshalBSONValue(t bsontype.Type, data []byte) (err error) {
+	value := bson.RawValue{Type: t, Value: data}
+	switch t {
+	case bsontype.Null, bsontype.Undefined:
+		*d = Date{}
+	case bsontype.DateTime:
+		*d = DateOf(value.Time().UTC())
+	case bsontype.String:
+		*d, err = ParseDate(value.StringValue())
+	default:
+		err = errors.New("can not decode " + t.String() + " into a date")
+	}
+
+	// Returns
+	return err
+}
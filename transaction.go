@@ -0,0 +1,59 @@
+package mongodb
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// transactionKey ...
+type transactionKey struct{}
+
+// WithTransaction runs fn in a multi-document transaction, committed when fn returns nil and aborted otherwise
+// Client operations run with txCtx join the transaction, they are served by the main pool and read from the primary
+// The whole callback is retried on TransientTransactionError and the commit on UnknownTransactionCommitResult, so fn must be safe to run again
+// Nested calls run fn in the enclosing transaction
+func (c *Client) WithTransaction(ctx context.Context, fn func(txCtx context.Context) error, opts ...*options.TransactionOptions) (err error) {
+	// Joins enclosing transaction
+	if inTransaction(ctx) {
+		return fn(ctx)
+	}
+
+	// Checks capability
+	err = c.requireCapability("WithTransaction", CapabilityTransactions)
+	if err != nil {
+		return err
+	}
+
+	// Starts session
+	session, err := c.client.StartSession()
+	if err != nil {
+		return err
+	}
+	defer session.EndSession(ctx)
+
+	// Runs with retries
+	_, err = session.WithTransaction(ctx, func(sessCtx mongo.SessionContext) (interface{}, error) {
+		var fnErr error
+		panicErr := safeCall(c.panicHandler, "WithTransaction callback", func() {
+			fnErr = fn(context.WithValue(sessCtx, transactionKey{}, true))
+		})
+		if panicErr != nil {
+			return nil, panicErr
+		}
+		return nil, fnErr
+	}, opts...)
+	if err != nil {
+		return err
+	}
+
+	// Returns
+	return nil
+}
+
+// inTransaction reports whether the context runs in a WithTransaction callback
+func inTransaction(ctx context.Context) bool {
+	in, _ := ctx.Value(transactionKey{}).(bool)
+	return in
+}
@@ -0,0 +1,172 @@
+package mongodb
+
+import (
+	"context"
+	"errors"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// Collection is a typed handle of a collection, documents are decoded into T instead of bson.D
+// Operations go through the Client wrappers so collection rules, partitions and the journal apply as usual
+type Collection[T any] struct {
+	client *Client
+	name   string
+}
+
+// NewCollection returns a typed handle of the collection, e.g. NewCollection[User](client, "users")
+func NewCollection[T any](c *Client, name string) *Collection[T] {
+	return &Collection[T]{client: c, name: name}
+}
+
+// Name ...
+func (t *Collection[T]) Name() string {
+	return t.name
+}
+
+// FindOne returns the first document matching the filter, nil when none matches
+func (t *Collection[T]) FindOne(ctx context.Context, filter interface{}, opts ...*options.FindOneOptions) (doc *T, err error) {
+	// Hits DB
+	res, err := t.client.ReadOne(ctx, t.name, filter, opts...)
+	if err != nil || res == nil {
+		return nil, err
+	}
+
+	// Decodes
+	doc = new(T)
+	err = t.client.decodeInto(res, doc)
+	if err != nil {
+		return nil, err
+	}
+
+	// Returns
+	return doc, nil
+}
+
+// Find returns the documents matching the filter
+// Past the result limits the documents read so far are returned with a *PartialResultsError
+func (t *Collection[T]) Find(ctx context.Context, filter interface{}, opts ...*options.FindOptions) (docs []T, err error) {
+	// Hits DB
+	res, err := t.client.Read(ctx, t.name, filter, opts...)
+	var partial *PartialResultsError
+	if err != nil && !errors.As(err, &partial) {
+		return nil, err
+	}
+
+	// Decodes
+	docs = make([]T, len(res))
+	for i := range res {
+		err = t.client.decodeInto(res[i], &docs[i])
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// Returns
+	if partial != nil {
+		return docs, partial
+	}
+	return docs, nil
+}
+
+// Insert ...
+func (t *Collection[T]) Insert(ctx context.Context, doc T, opts ...*options.InsertOneOptions) (err error) {
+	return t.client.CreateOne(ctx, t.name, doc, opts...)
+}
+
+// InsertMany inserts the documents and returns their ids
+func (t *Collection[T]) InsertMany(ctx context.Context, docs []T, ordered bool, opts ...*options.InsertManyOptions) (ids []interface{}, err error) {
+	documents := make([]interface{}, len(docs))
+	for i := range docs {
+		documents[i] = docs[i]
+	}
+	return t.client.CreateMany(ctx, t.name, documents, ordered, opts...)
+}
+
+// Update applies the update to the first document matching the filter, e.g. bson.M{"$set": bson.M{"name": name}}
+func (t *Collection[T]) Update(ctx context.Context, filter interface{}, update interface{}, opts ...*options.UpdateOptions) (err error) {
+	return t.client.UpdateOne(ctx, t.name, filter, update, opts...)
+}
+
+// UpdateMany ...
+func (t *Collection[T]) UpdateMany(ctx context.Context, filter interface{}, update interface{}, opts ...*options.UpdateOptions) (matched int64, modified int64, err error) {
+	return t.client.UpdateMany(ctx, t.name, filter, update, opts...)
+}
+
+// Replace replaces the document matching the filter, inserting it when none matches and upsert is set
+func (t *Collection[T]) Replace(ctx context.Context, filter interface{}, doc T, upsert bool, opts ...*options.ReplaceOptions) (res *ReplaceResult, err error) {
+	return t.client.ReplaceOne(ctx, t.name, filter, doc, upsert, opts...)
+}
+
+// Delete ...
+func (t *Collection[T]) Delete(ctx context.Context, filter interface{}, opts ...*options.DeleteOptions) (count int64, err error) {
+	return t.client.DeleteOne(ctx, t.name, filter, opts...)
+}
+
+// DeleteMany ...
+func (t *Collection[T]) DeleteMany(ctx context.Context, filter interface{}, opts ...*options.DeleteOptions) (count int64, err error) {
+	return t.client.DeleteMany(ctx, t.name, filter, opts...)
+}
+
+// ReadOneInto decodes the first document matching the query into out, e.g. ReadOneInto(ctx, "users", filter, &user)
+// found is false and out is left untouched when no document matches
+func (c *Client) ReadOneInto(ctx context.Context, collection string, query interface{}, out interface{}, opts ...*options.FindOneOptions) (found bool, err error) {
+	// Hits DB
+	res, err := c.ReadOne(ctx, collection, query, opts...)
+	if err != nil || res == nil {
+		return false, err
+	}
+
+	// Decodes
+	err = c.decodeInto(res, out)
+	if err != nil {
+		return false, err
+	}
+
+	// Returns
+	return true, nil
+}
+
+// ReadInto decodes the documents matching the query into the slice out points to, e.g. ReadInto(ctx, "users", filter, &users)
+// Past the result limits the documents read so far are decoded and a *PartialResultsError is returned
+func (c *Client) ReadInto(ctx context.Context, collection string, query interface{}, out interface{}, opts ...*options.FindOptions) (err error) {
+	// Hits DB
+	res, err := c.Read(ctx, collection, query, opts...)
+	var partial *PartialResultsError
+	if err != nil && !errors.As(err, &partial) {
+		return err
+	}
+
+	// Decodes
+	if res == nil {
+		res = []interface{}{}
+	}
+	err = c.decodeInto(res, out)
+	if err != nil {
+		return err
+	}
+
+	// Returns
+	if partial != nil {
+		return partial
+	}
+	return nil
+}
+
+// decodeInto decodes a document or an array of documents returned by the untyped reads into out, with the registry of the client
+func (c *Client) decodeInto(value interface{}, out interface{}) (err error) {
+	t, data, err := bson.MarshalValue(value)
+	if err != nil {
+		return errors.New("encoding document -> " + err.Error())
+	}
+	registry := c.registry
+	if registry == nil {
+		registry = bson.DefaultRegistry
+	}
+	err = bson.RawValue{Type: t, Value: data}.UnmarshalWithRegistry(registry, out)
+	if err != nil {
+		return errors.New("decoding document -> " + err.Error())
+	}
+	return nil
+}
@@ -0,0 +1,88 @@
+package mongodb
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"go.mongodb.org/mongo-driver/event"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/readpref"
+)
+
+// poolWarmer counts the ready connections of every server pool
+type poolWarmer struct {
+	mu    sync.Mutex
+	ready map[string]int
+}
+
+// newPoolWarmer ...
+func newPoolWarmer() *poolWarmer {
+	return &poolWarmer{ready: map[string]int{}}
+}
+
+// monitor returns the pool monitor feeding the warmer
+func (w *poolWarmer) monitor() *event.PoolMonitor {
+	return &event.PoolMonitor{Event: w.event}
+}
+
+// event ...
+func (w *poolWarmer) event(e *event.PoolEvent) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	switch e.Type {
+	case event.PoolReady:
+		if _, ok := w.ready[e.Address]; !ok {
+			w.ready[e.Address] = 0
+		}
+	case event.ConnectionReady:
+		w.ready[e.Address]++
+	case event.ConnectionClosed:
+		w.ready[e.Address]--
+	case event.PoolClosedEvent:
+		delete(w.ready, e.Address)
+	}
+}
+
+// cold returns the servers having less than min ready connections
+func (w *poolWarmer) cold(min int) (servers []string) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	for address, ready := range w.ready {
+		if ready < min {
+			servers = append(servers, address+" ("+strconv.Itoa(ready)+" ready)")
+		}
+	}
+	return servers
+}
+
+// warmUp primes server selection for the read preference in use and waits until every server has min ready connections
+func (w *poolWarmer) warmUp(ctx context.Context, client *mongo.Client, rp *readpref.ReadPref, min int) (err error) {
+	// Primes server selection
+	if rp != nil {
+		err = client.Ping(ctx, rp)
+		if err != nil {
+			return errors.New("warm up ping failed -> " + err.Error())
+		}
+	}
+
+	// Waits for pools
+	ticker := time.NewTicker(50 * time.Millisecond)
+	defer ticker.Stop()
+	for {
+		cold := w.cold(min)
+		if len(cold) == 0 {
+			return nil
+		}
+		select {
+		case <-ctx.Done():
+			return errors.New("connection warm up timed out -> " + strings.Join(cold, ", "))
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,210 @@
+package mongodb
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// WatchOptions controls a change stream
+type WatchOptions struct {
+	Pipeline             mongo.Pipeline       // Stages appended to the stream pipeline, e.g. built with NewChangeFilter
+	FullDocument         bool                 // Update events carry the current version of the document (updateLookup)
+	ResumeAfter          bson.Raw             // Resume token the stream starts after
+	StartAfter           bson.Raw             // Resume token the stream starts after, unlike ResumeAfter it may be the token of an invalidate event
+	StartAtOperationTime *primitive.Timestamp // Cluster time the stream starts at
+	MaxAwaitTime         int                  // In milliseconds, How long each getMore waits on the server for new events. (default is 1 second)
+	BatchSize            int32                // Events per server batch. (default is server default)
+	Labels               map[string]string    // Label of the events of every collection, e.g. {"orders_v2": "orders"}. (default is the collection name)
+	TokenStore           ResumeTokenStore     // Resumes after the stored token of TokenName, and saves the token of every event once the next one is requested (default is nil, not persisted)
+	TokenName            string               // Name of the stream in the token store, required with TokenStore
+}
+
+// ChangeStream iterates change events one at a time
+type ChangeStream struct {
+	stream    *mongo.ChangeStream
+	labels    map[string]string
+	label     string
+	store     ResumeTokenStore
+	tokenName string
+	unsaved   bool  // Current event was not saved to the store yet
+	err       error // Failure to save a token
+}
+
+// Watch watches the changes of a collection matching the pipeline, e.g. built with NewChangeFilter, nil for every change
+// Decode events with CurrentEvent for typed operation type, document key and full document
+func (c *Client) Watch(ctx context.Context, collection string, pipeline mongo.Pipeline, opts *WatchOptions) (stream *ChangeStream, err error) {
+	err = c.requireCapability("Watch", CapabilityChangeStreams)
+	if err != nil {
+		return nil, err
+	}
+
+	// Hits DB
+	return c.watch(ctx, c.collection(ctx, collection).Watch, append(mongo.Pipeline{}, pipeline...), opts)
+}
+
+// WatchCollections watches a set of collections of the database as a single stream ordered by cluster time
+// Events of other collections are filtered out by the server, every event is labelled with its collection
+func (c *Client) WatchCollections(ctx context.Context, collections []string, opts *WatchOptions) (stream *ChangeStream, err error) {
+	if len(collections) == 0 {
+		return nil, errors.New("no collection to watch")
+	}
+	err = c.requireCapability("WatchCollections", CapabilityChangeStreams)
+	if err != nil {
+		return nil, err
+	}
+
+	// Matches collections
+	names := bson.A{}
+	for _, collection := range collections {
+		names = append(names, collection)
+	}
+	pipeline := mongo.Pipeline{bson.D{{Key: "$match", Value: bson.D{
+		{Key: "ns.db", Value: c.database.Name()},
+		{Key: "ns.coll", Value: bson.D{{Key: "$in", Value: names}}},
+	}}}}
+
+	// Hits DB
+	return c.watch(ctx, c.database.Watch, pipeline, opts)
+}
+
+// watch opens a change stream with the pipeline followed by the stages of the options
+func (c *Client) watch(ctx context.Context, open func(context.Context, interface{}, ...*options.ChangeStreamOptions) (*mongo.ChangeStream, error), pipeline mongo.Pipeline, opts *WatchOptions) (stream *ChangeStream, err error) {
+	if opts == nil {
+		opts = &WatchOptions{}
+	}
+
+	// Sets options
+	maxAwait := defaultMaxAwaitTime
+	if opts.MaxAwaitTime != 0 {
+		maxAwait = time.Duration(opts.MaxAwaitTime) * time.Millisecond
+	}
+	csOpts := options.ChangeStream().SetMaxAwaitTime(maxAwait)
+	if opts.FullDocument {
+		csOpts.SetFullDocument(options.UpdateLookup)
+	}
+	resumeAfter, startAt := opts.ResumeAfter, opts.StartAtOperationTime
+	if opts.TokenStore != nil {
+		if opts.TokenName == "" {
+			return nil, errors.New("token name is required with a token store")
+		}
+		token, err := opts.TokenStore.Load(ctx, opts.TokenName)
+		if err != nil {
+			return nil, errors.New("loading resume token failed -> " + err.Error())
+		}
+		if token != nil {
+			resumeAfter, startAt = token, nil
+		}
+	}
+	if resumeAfter != nil {
+		csOpts.SetResumeAfter(resumeAfter)
+	} else if opts.StartAfter != nil {
+		csOpts.SetStartAfter(opts.StartAfter)
+	}
+	if startAt != nil {
+		csOpts.SetStartAtOperationTime(startAt)
+	}
+	if opts.BatchSize != 0 {
+		csOpts.SetBatchSize(opts.BatchSize)
+	}
+	pipeline = append(pipeline, opts.Pipeline...)
+
+	// Hits DB
+	cs, err := open(ctx, pipeline, csOpts)
+	if err != nil {
+		return nil, err
+	}
+
+	// Returns
+	return &ChangeStream{stream: cs, labels: opts.Labels, store: opts.TokenStore, tokenName: opts.TokenName}, nil
+}
+
+// Next blocks until the next event is available, false when the stream failed or the context is done
+func (s *ChangeStream) Next(ctx context.Context) bool {
+	s.label = ""
+	if !s.saveToken(ctx) || !s.stream.Next(ctx) {
+		return false
+	}
+	s.unsaved = s.store != nil
+	s.labelCurrent()
+	return true
+}
+
+// TryNext returns the next event if one is available without blocking
+func (s *ChangeStream) TryNext(ctx context.Context) bool {
+	s.label = ""
+	if !s.saveToken(ctx) || !s.stream.TryNext(ctx) {
+		return false
+	}
+	s.unsaved = s.store != nil
+	s.labelCurrent()
+	return true
+}
+
+// saveToken saves the token of the current event, the caller is done with it when requesting the next one
+func (s *ChangeStream) saveToken(ctx context.Context) bool {
+	if s.err != nil {
+		return false
+	}
+	if s.unsaved {
+		err := s.store.Save(ctx, s.tokenName, s.stream.ResumeToken())
+		if err != nil {
+			s.err = errors.New("saving resume token failed -> " + err.Error())
+			return false
+		}
+		s.unsaved = false
+	}
+	return true
+}
+
+// labelCurrent labels the current event with its collection
+func (s *ChangeStream) labelCurrent() {
+	collection, _ := s.stream.Current.Lookup("ns", "coll").StringValueOK()
+	s.label = collection
+	if label, ok := s.labels[collection]; ok {
+		s.label = label
+	}
+}
+
+// Label returns the label of the collection of the current event
+func (s *ChangeStream) Label() string {
+	return s.label
+}
+
+// Current returns the current event, valid until the next call of Next
+func (s *ChangeStream) Current() bson.Raw {
+	return s.stream.Current
+}
+
+// CurrentEvent decodes the current event of the stream with its documents decoded into T
+func CurrentEvent[T any](s *ChangeStream) (event *ChangeEvent[T], err error) {
+	return DecodeChangeEvent[T](s.stream.Current)
+}
+
+// Decode decodes the current event into v
+func (s *ChangeStream) Decode(v interface{}) error {
+	return s.stream.Decode(v)
+}
+
+// ResumeToken returns the token resuming the stream after the current event
+func (s *ChangeStream) ResumeToken() bson.Raw {
+	return s.stream.ResumeToken()
+}
+
+// Err ...
+func (s *ChangeStream) Err() error {
+	if s.err != nil {
+		return s.err
+	}
+	return s.stream.Err()
+}
+
+// Close ...
+func (s *ChangeStream) Close(ctx context.Context) error {
+	return s.stream.Close(ctx)
+}
@@ -0,0 +1,312 @@
+package mongodb
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// Default webhook sink settings
+const (
+	defaultWebhookTimeout      = 10 * time.Second
+	defaultWebhookDeadLetters  = "mongo_lib_webhook_dead_letters"
+	webhookSignatureHeader     = "X-Webhook-Signature"
+	webhookTimestampHeader     = "X-Webhook-Timestamp"
+	webhookEventIDHeader       = "X-Webhook-Event-Id"
+	webhookOperationTypeHeader = "X-Webhook-Operation-Type"
+	webhookResponseBodyLimit   = 4 << 10
+)
+
+// Operation types delivered to endpoints registered without any
+var webhookOperations = []string{OpInsert, OpUpdate, OpReplace, OpDelete, OpDrop, OpRename}
+
+// WebhookEndpoint receives the change events of a collection as POST requests with the event in relaxed extended JSON
+// Payloads are signed when Secret is set: the X-Webhook-Signature header is "sha256=" followed by the hex HMAC-SHA256
+// of the X-Webhook-Timestamp header value, a dot and the body, receivers recompute it and reject stale timestamps
+type WebhookEndpoint struct {
+	URL            string            // Endpoint URL
+	Collection     string            // Collection whose events are delivered
+	OperationTypes []string          // Delivered operation types, e.g. OpInsert. (default is every operation type)
+	Secret         string            // HMAC key of the payload signature (default is empty, unsigned)
+	Headers        map[string]string // Extra request headers, e.g. Authorization (default is nil)
+}
+
+// WebhookOptions ...
+type WebhookOptions struct {
+	Watch                *WatchOptions                                                          // Options of the underlying stream, e.g. FullDocument for the documents of update events
+	TokenStore           ResumeTokenStore                                                       // Saves the token of every delivered event under Name, a restarted sink resumes after it (default is nil, starts from now)
+	Name                 string                                                                 // Name of the sink in the token store, required with TokenStore
+	Timeout              int                                                                    // In milliseconds, Timeout of a delivery attempt. (default is 10 seconds)
+	MaxRetries           int                                                                    // Times a failed delivery is retried before the event is dead lettered. (default is 5)
+	RetryDelay           int                                                                    // In milliseconds, Delay before the first retry, doubled on every retry. (default is 100 milliseconds)
+	MaxRetryDelay        int                                                                    // In milliseconds, Upper bound of the retry delay. (default is 30 seconds)
+	DeadLetterCollection string                                                                 // Collection of the deliveries given up. (default is "mongo_lib_webhook_dead_letters")
+	HTTPClient           *http.Client                                                           // HTTP client of the deliveries (default is http.DefaultClient)
+	OnError              func(endpoint *WebhookEndpoint, event *ChangeEvent[bson.M], err error) // Called with every delivery given up and stream failures (nil endpoint and event) (default is nil)
+}
+
+// WebhookDeadLetter is a delivery given up, stored in the dead letter collection for inspection and replay
+type WebhookDeadLetter struct {
+	URL           string    `bson:"url"`
+	Collection    string    `bson:"collection"`
+	OperationType string    `bson:"operationType"`
+	EventID       string    `bson:"eventId"`
+	Payload       string    `bson:"payload"`
+	Error         string    `bson:"error"`
+	Attempts      int       `bson:"attempts"`
+	FailedAt      time.Time `bson:"failedAt"`
+}
+
+// WebhookSink delivers change events to the registered HTTP endpoints, each endpoint is retried independently
+// Deliveries given up are stored in the dead letter collection and the sink moves on
+type WebhookSink struct {
+	client     *Client
+	opts       *WebhookOptions
+	dispatcher *Dispatcher
+	mu         sync.Mutex
+	endpoints  map[string][]*WebhookEndpoint // Endpoints by collection
+}
+
+// NewWebhookSink returns a sink, register endpoints then Start it
+func (c *Client) NewWebhookSink(opts *WebhookOptions) *WebhookSink {
+	if opts == nil {
+		opts = &WebhookOptions{}
+	}
+	w := &WebhookSink{client: c, opts: opts, endpoints: map[string][]*WebhookEndpoint{}}
+	w.dispatcher = c.NewDispatcher(&DispatcherOptions{
+		Watch:      opts.Watch,
+		TokenStore: opts.TokenStore,
+		Name:       opts.Name,
+		OnError: func(collection string, event *ChangeEvent[bson.M], err error) {
+			w.reportError(nil, event, err)
+		},
+	})
+	return w
+}
+
+// Register adds an endpoint, before Start
+func (w *WebhookSink) Register(endpoint *WebhookEndpoint) *WebhookSink {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	operationTypes := endpoint.OperationTypes
+	if len(operationTypes) == 0 {
+		operationTypes = webhookOperations
+	}
+	collection := endpoint.Collection
+	w.endpoints[collection] = append(w.endpoints[collection], endpoint)
+	for _, operationType := range operationTypes {
+		w.dispatcher.On(collection, operationType, w.deliver)
+	}
+	return w
+}
+
+// Start delivers events in the background until Stop
+func (w *WebhookSink) Start(ctx context.Context) (err error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	for _, endpoints := range w.endpoints {
+		for _, endpoint := range endpoints {
+			if endpoint.URL == "" {
+				return errors.New("webhook endpoint url is required")
+			}
+		}
+	}
+	return w.dispatcher.Start(ctx)
+}
+
+// Stop stops delivering and waits for the running deliveries to end
+func (w *WebhookSink) Stop() {
+	w.dispatcher.Stop()
+}
+
+// deliver posts the event to the endpoints of its collection and operation type concurrently
+// It fails only when a dead letter cannot be stored, so the dispatcher retries the event
+func (w *WebhookSink) deliver(ctx context.Context, event *ChangeEvent[bson.M]) (err error) {
+	// Encodes event
+	payload, err := changeEventJSON(event)
+	if err != nil {
+		return errors.New("encoding change event -> " + err.Error())
+	}
+	eventID, _ := event.ResumeToken.Lookup("_data").StringValueOK()
+
+	// Lists endpoints
+	w.mu.Lock()
+	var endpoints []*WebhookEndpoint
+	for _, endpoint := range w.endpoints[event.Namespace.Collection] {
+		if endpoint.accepts(event.OperationType) {
+			endpoints = append(endpoints, endpoint)
+		}
+	}
+	w.mu.Unlock()
+
+	// Delivers
+	errs := make([]error, len(endpoints))
+	var wg sync.WaitGroup
+	for i, endpoint := range endpoints {
+		wg.Add(1)
+		go func(i int, endpoint *WebhookEndpoint) {
+			defer wg.Done()
+			attempts, deliveryErr := w.post(ctx, endpoint, event.OperationType, eventID, payload)
+			if deliveryErr == nil || ctx.Err() != nil {
+				return
+			}
+			w.reportError(endpoint, event, deliveryErr)
+			errs[i] = w.deadLetter(ctx, &WebhookDeadLetter{
+				URL:           endpoint.URL,
+				Collection:    event.Namespace.Collection,
+				OperationType: event.OperationType,
+				EventID:       eventID,
+				Payload:       string(payload),
+				Error:         deliveryErr.Error(),
+				Attempts:      attempts,
+				FailedAt:      time.Now(),
+			})
+		}(i, endpoint)
+	}
+	wg.Wait()
+
+	// Returns
+	for _, err = range errs {
+		if err != nil {
+			return errors.New("storing webhook dead letter failed -> " + err.Error())
+		}
+	}
+	return nil
+}
+
+// post sends the payload to the endpoint, retrying with exponential backoff, and returns the number of attempts
+func (w *WebhookSink) post(ctx context.Context, endpoint *WebhookEndpoint, operationType string, eventID string, payload []byte) (attempts int, err error) {
+	// Sets backoff
+	maxRetries := w.opts.MaxRetries
+	if maxRetries <= 0 {
+		maxRetries = defaultDispatchRetries
+	}
+	delay := defaultDispatchRetryDelay
+	if w.opts.RetryDelay != 0 {
+		delay = time.Duration(w.opts.RetryDelay) * time.Millisecond
+	}
+	maxDelay := defaultDispatchMaxDelay
+	if w.opts.MaxRetryDelay != 0 {
+		maxDelay = time.Duration(w.opts.MaxRetryDelay) * time.Millisecond
+	}
+
+	// Sends
+	for attempts = 1; ; attempts++ {
+		err = w.attempt(ctx, endpoint, operationType, eventID, payload)
+		if err == nil || attempts > maxRetries {
+			return attempts, err
+		}
+		select {
+		case <-ctx.Done():
+			return attempts, ctx.Err()
+		case <-time.After(delay):
+		}
+		delay *= 2
+		if delay > maxDelay {
+			delay = maxDelay
+		}
+	}
+}
+
+// attempt sends one signed request, responses other than 2xx are errors
+func (w *WebhookSink) attempt(ctx context.Context, endpoint *WebhookEndpoint, operationType string, eventID string, payload []byte) (err error) {
+	timeout := defaultWebhookTimeout
+	if w.opts.Timeout != 0 {
+		timeout = time.Duration(w.opts.Timeout) * time.Millisecond
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	// Builds request
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint.URL, bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	for name, value := range endpoint.Headers {
+		req.Header.Set(name, value)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(webhookEventIDHeader, eventID)
+	req.Header.Set(webhookOperationTypeHeader, operationType)
+	if endpoint.Secret != "" {
+		timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+		req.Header.Set(webhookTimestampHeader, timestamp)
+		req.Header.Set(webhookSignatureHeader, "sha256="+webhookSignature(endpoint.Secret, timestamp, payload))
+	}
+
+	// Hits endpoint
+	httpClient := w.opts.HTTPClient
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	res, err := httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+	body, _ := ioutil.ReadAll(io.LimitReader(res.Body, webhookResponseBodyLimit))
+	if res.StatusCode/100 != 2 {
+		return fmt.Errorf("webhook %s answered %d -> %s", endpoint.URL, res.StatusCode, bytes.TrimSpace(body))
+	}
+
+	// Returns
+	return nil
+}
+
+// deadLetter stores a delivery given up
+func (w *WebhookSink) deadLetter(ctx context.Context, letter *WebhookDeadLetter) (err error) {
+	collection := w.opts.DeadLetterCollection
+	if collection == "" {
+		collection = defaultWebhookDeadLetters
+	}
+
+	// Hits DB
+	_, err = w.client.collection(ctx, collection).InsertOne(ctx, letter)
+	if err != nil {
+		return err
+	}
+
+	// Returns
+	return nil
+}
+
+// reportError ...
+func (w *WebhookSink) reportError(endpoint *WebhookEndpoint, event *ChangeEvent[bson.M], err error) {
+	if w.opts.OnError != nil {
+		safeCall(w.client.panicHandler, "WebhookOptions.OnError", func() { w.opts.OnError(endpoint, event, err) })
+	}
+}
+
+// accepts tells whether the endpoint receives the operation type
+func (e *WebhookEndpoint) accepts(operationType string) bool {
+	operationTypes := e.OperationTypes
+	if len(operationTypes) == 0 {
+		operationTypes = webhookOperations
+	}
+	for _, op := range operationTypes {
+		if op == operationType {
+			return true
+		}
+	}
+	return false
+}
+
+// webhookSignature returns the hex HMAC-SHA256 of the timestamp, a dot and the payload
+func webhookSignature(secret string, timestamp string, payload []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp + "."))
+	mac.Write(payload)
+	return hex.EncodeToString(mac.Sum(nil))
+}